@@ -25,9 +25,7 @@ func main() {
 		}
 	}()
 
-	application := app.New()
-
-	if err := application.Run(ctx); err != nil {
-		log.Fatalf("failed to start application: %s", err.Error())
+	if err := app.NewCommand().ExecuteContext(ctx); err != nil {
+		log.Fatalf("failed to execute command: %s", err.Error())
 	}
 }
@@ -7,36 +7,48 @@ allow if {
 	"admin" in input.user.roles
 }
 
-# allow users endpoints
+# allow users actions
 allow if {
-	some rule in users_endpoints
-	glob.match(rule.path, ["/"], input.path)
-	input.method in rule.methods
+	input.action in users_actions
 }
 
 # allow specific user for change config values
 allow if {
-    input.method == "PUT"
-	glob.match("/api/v1/projects/*/envs/*/releases/*/configs", ["/"], input.path)
-	project := project_name(input.path)
-	input.user.username in users_configs_changes[project]
+	input.action == "set_config_values"
+	input.user.username in users_configs_changes[input.params.project]
+	changes_allowed
 }
 
-project_name(path) := project_name if {
-	parts := split(path, "/")
-	project_name := parts[4]
+# any keys outside of prod
+changes_allowed if {
+	input.params.env != "prod"
 }
 
-users_endpoints := [
-	{"path": "/api/v1/projects", "methods": {"GET"}},
-	{"path": "/api/v1/projects/*/envs", "methods": {"GET"}},
-	{"path": "/api/v1/projects/*/envs/*/releases", "methods": {"GET"}},
-	{"path": "/api/v1/projects/*/envs/*/releases/*/configs", "methods": {"GET"}},
-	{"path": "/api/v1/audits", "methods": {"GET"}},
-	{"path": "/api/v1/audits/actions", "methods": {"GET"}},
-]
+# only keys of limits group in prod
+changes_allowed if {
+	input.params.env == "prod"
+	every change in input.changes {
+		change.group == "limits"
+	}
+}
 
-users_configs_changes := {
-    "example": {"simple_user"},
+users_actions := {
+	"list_projects",
+	"list_envs",
+	"list_releases",
+	"list_configs",
+	"list_config_history",
+	"list_audits",
+	"list_audit_actions",
+	"list_tokens",
+	"create_token",
+	"revoke_token",
+	"change_password",
+	"enroll_totp",
+	"confirm_totp",
+	"disable_totp",
 }
 
+users_configs_changes := {
+	"example": {"simple_user"},
+}
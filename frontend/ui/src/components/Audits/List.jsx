@@ -1,4 +1,4 @@
-import React, {useEffect, useMemo, useState, useCallback, useRef} from "react";
+import React, {useEffect, useState, useCallback, useRef} from "react";
 import { useNavigate } from "react-router-dom";
 import {
     Card,
@@ -21,16 +21,6 @@ import dayjs from "dayjs";
 const { RangePicker } = DatePicker;
 const { Option } = Select;
 
-const decodePayload = (payload) => {
-    try {
-        if (!payload) return null;
-        const decoded = atob(payload);
-        return JSON.parse(decoded);
-    } catch (e) {
-        return null;
-    }
-};
-
 const DiffLine = ({label, oldValue, newValue}) => {
     const oldStr = oldValue === null || oldValue === undefined ? "" : String(oldValue);
     const newStr = newValue === null || newValue === undefined ? "" : String(newValue);
@@ -67,7 +57,7 @@ const DiffLine = ({label, oldValue, newValue}) => {
 const ConfigUpdatedPayload = ({decoded}) => (
     <Space direction="vertical" size={12} style={{width: "100%"}}>
         <div>
-            <Tag>env: {decoded.environment || "-"}</Tag>
+            <Tag>env: {decoded.env || "-"}</Tag>
             <Tag>project: {decoded.project || "-"}</Tag>
             <Tag>release: {decoded.release || "-"}</Tag>
         </div>
@@ -106,8 +96,7 @@ const DefaultPayload = ({decoded}) => {
     );
 };
 
-const PrettyPayload = ({payload, action}) => {
-    const decoded = useMemo(() => decodePayload(payload), [payload]);
+const PrettyPayload = ({payload: decoded, action}) => {
     if (!decoded) return <div>Invalid payload</div>;
 
     switch (action) {
@@ -118,9 +107,9 @@ const PrettyPayload = ({payload, action}) => {
     }
 };
 
-const Header = ({onRefresh, actor, setActor, action, setAction, dateRange, setDateRange, loading, actions}) => (
+const Header = ({onRefresh, actor, setActor, project, setProject, configKey, setConfigKey, action, setAction, dateRange, setDateRange, loading, actions}) => (
     <Row gutter={[8, 8]} align="middle" style={{marginBottom: 12}}>
-        <Col xs={24} sm={12} md={8} lg={6}>
+        <Col xs={24} sm={12} md={8} lg={4}>
             <Input
                 placeholder="Search by actor"
                 value={actor}
@@ -130,6 +119,24 @@ const Header = ({onRefresh, actor, setActor, action, setAction, dateRange, setDa
             />
         </Col>
 
+        <Col xs={12} sm={6} md={4} lg={3}>
+            <Input
+                placeholder="Project"
+                value={project}
+                onChange={(e) => setProject(e.target.value)}
+                allowClear
+            />
+        </Col>
+
+        <Col xs={12} sm={6} md={4} lg={3}>
+            <Input
+                placeholder="Config key"
+                value={configKey}
+                onChange={(e) => setConfigKey(e.target.value)}
+                allowClear
+            />
+        </Col>
+
         <Col xs={12} sm={6} md={4} lg={3}>
             <Select
                 value={action}
@@ -187,18 +194,24 @@ const AuditList = () => {
     const [loading, setLoading] = useState(false);
     const [actionsLoading, setActionsLoading] = useState(false);
     const [actor, setActor] = useState('');
+    const [project, setProject] = useState('');
+    const [configKey, setConfigKey] = useState('');
+    const [nextCursor, setNextCursor] = useState('');
     const [action, setAction] = useState('');
     const [actions, setActions] = useState([]);
     const [dateRange, setDateRange] = useState([dayjs().subtract(1, 'day'), dayjs()]);
     const [error, setError] = useState(null);
 
-    const fetchData = useCallback(async () => {
+    const fetchData = useCallback(async (cursor = '') => {
         setLoading(true);
         setError(null);
         try {
             const params = new URLSearchParams();
             if (action) params.set('action', action);
             if (actor) params.set('actor', actor);
+            if (project) params.set('project', project);
+            if (configKey) params.set('key', configKey);
+            if (cursor) params.set('cursor', cursor);
 
             const [from, to] = dateRange;
             params.set('from', from.startOf('day').format('YYYY-MM-DDTHH:mm:ssZ'));
@@ -207,14 +220,17 @@ const AuditList = () => {
             const res = await fetchWithAuth(`/api/v1/audits?${params.toString()}`, {}, navigate);
             if (!res.ok) throw new Error('fetch error');
             const json = await res.json();
-            setAudits(json.data?.audits || []);
+            const page = json.data?.audits || [];
+            setAudits(prev => cursor ? [...prev, ...page] : page);
+            setNextCursor(json.data?.next_cursor || '');
         } catch (e) {
             setError('Failed to load data');
             setAudits([]);
+            setNextCursor('');
         } finally {
             setLoading(false);
         }
-    }, [actor, action, dateRange, navigate]);
+    }, [actor, project, configKey, action, dateRange, navigate]);
 
     const fetchActions = useCallback(async () => {
         setActionsLoading(true);
@@ -245,7 +261,7 @@ const AuditList = () => {
             fetchDataRef.current();
         }, 400);
         return () => clearTimeout(t);
-    }, [actor]);
+    }, [actor, project, configKey]);
 
     useEffect(() => {
         fetchDataRef.current();
@@ -254,9 +270,13 @@ const AuditList = () => {
     return (
         <div>
             <Header
-                onRefresh={fetchData}
+                onRefresh={() => fetchData()}
                 actor={actor}
                 setActor={setActor}
+                project={project}
+                setProject={setProject}
+                configKey={configKey}
+                setConfigKey={setConfigKey}
                 action={action}
                 setAction={setAction}
                 dateRange={dateRange}
@@ -274,6 +294,12 @@ const AuditList = () => {
             {audits.map((row, idx) => (
                 <AuditCard key={row.id || idx} row={row} />
             ))}
+
+            {nextCursor && (
+                <Button block onClick={() => fetchData(nextCursor)} loading={loading}>
+                    Load more
+                </Button>
+            )}
         </div>
     );
 };
@@ -73,6 +73,8 @@ const ConfigsList = () => {
     const [modifiedValues, setModifiedValues] = useState(new Map());
     const [highlighted, setHighlighted] = useState(null);
     const [filter, setFilter] = useState("");
+    const [reason, setReason] = useState("");
+    const [ticket, setTicket] = useState("");
 
     // fetchConfigs — мемоизированная, чтобы не пересоздавать в эффектах
     const fetchConfigs = useCallback(async (env) => {
@@ -123,22 +125,29 @@ const ConfigsList = () => {
 
         if (Object.keys(changes).length === 0) return;
 
+        const query = new URLSearchParams();
+        if (reason) query.set("reason", reason);
+        if (ticket) query.set("ticket", ticket);
+
         try {
-            const resp = await fetchWithAuth(`/api/v1/projects/${project}/envs/${currentEnv}/releases/${release}/configs`, {
+            const resp = await fetchWithAuth(`/api/v1/projects/${project}/envs/${currentEnv}/releases/${release}/configs?${query}`, {
                 method: "PUT",
                 headers: { "Content-Type": "application/json" },
                 body: JSON.stringify(changes),
             }, navigate);
             if (!resp.ok) {
-                api.error({ message: "Failed to update", description: String(resp.status) });
+                const errorData = await resp.json().catch(() => ({}));
+                api.error({ message: "Failed to update", description: errorData.error || String(resp.status) });
                 return;
             }
             api.success({ message: "Updated successfully" });
+            setReason("");
+            setTicket("");
             await fetchConfigs(currentEnv);
         } catch (err) {
             api.error({ message: "Save error", description: String(err) });
         }
-    }, [modifiedValues, project, currentEnv, release, api, fetchConfigs]);
+    }, [modifiedValues, reason, ticket, project, currentEnv, release, api, fetchConfigs]);
 
     const grouped = useMemo(() => {
         return configs.reduce((acc, cfg) => {
@@ -229,6 +238,20 @@ const ConfigsList = () => {
                     onEnvChange={(env) => fetchConfigs(env)}
                 />
                 <div style={{ marginLeft: "auto", display: "flex", alignItems: "center", gap: 8 }}>
+                    <Input
+                        placeholder="reason of change"
+                        value={reason}
+                        onChange={(e) => setReason(e.target.value)}
+                        style={{ width: 240 }}
+                        allowClear
+                    />
+                    <Input
+                        placeholder="ticket"
+                        value={ticket}
+                        onChange={(e) => setTicket(e.target.value)}
+                        style={{ width: 120 }}
+                        allowClear
+                    />
                     <Button type="primary" onClick={handleSave} disabled={modifiedValues.size === 0}>
                         Save ({modifiedValues.size})
                     </Button>
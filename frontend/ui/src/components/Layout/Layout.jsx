@@ -14,8 +14,18 @@ export const LayoutWithNav = () => {
         token: { colorBgContainer, borderRadiusLG },
     } = theme.useToken();
 
-    const handleLogout = () => {
+    const handleLogout = async () => {
+        const refreshToken = localStorage.getItem('refresh_token');
+        if (refreshToken) {
+            await fetch('/api/v1/logout', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ refresh_token: refreshToken }),
+            }).catch(() => {});
+        }
+
         localStorage.removeItem('token')
+        localStorage.removeItem('refresh_token')
         navigate('/login')
     }
 
@@ -1,19 +1,98 @@
-import { Button, Checkbox, Form, Input, message, Card, Typography } from 'antd';
+import { Alert, Button, Checkbox, Form, Input, message, Card, Typography } from 'antd';
 import {useEffect, useState} from "react";
 import { useNavigate } from "react-router-dom";
 import { jwtDecode } from "jwt-decode";
 
-const { Title } = Typography;
+const { Title, Paragraph } = Typography;
 
 
 const Login = () => {
     const [loading, setLoading] = useState(false);
+    const [mustChangePassword, setMustChangePassword] = useState(false);
+    const [otpRequired, setOtpRequired] = useState(false);
+    // enrollment of two-factor authentication required before login
+    const [enrollment, setEnrollment] = useState(null);
+    const [recoveryCodes, setRecoveryCodes] = useState(null);
+    const [oidcEnabled, setOidcEnabled] = useState(false);
     const [messageApi, contextHolder] = message.useMessage();
     const navigate = useNavigate();
 
+    const saveToken = (token, refreshToken) => {
+        localStorage.setItem('token', token);
+        if (refreshToken) {
+            localStorage.setItem('refresh_token', refreshToken);
+        }
+
+        const decoded = jwtDecode(token)
+        localStorage.setItem('jwt', JSON.stringify(decoded))
+    };
+
+    useEffect(() => {
+        // token from OpenID Connect callback
+        const params = new URLSearchParams(window.location.hash.slice(1));
+        const token = params.get('token');
+        if (token) {
+            window.history.replaceState(null, '', window.location.pathname);
+            saveToken(token, params.get('refresh_token'));
+            navigate("/");
+            return;
+        }
+
+        fetch('/api/v1/login/methods')
+            .then((response) => response.ok ? response.json() : null)
+            .then((data) => setOidcEnabled(Boolean(data?.data?.oidc)))
+            .catch(() => setOidcEnabled(false));
+    }, [navigate]);
+
+    const postJSON = (url, payload) => fetch(url, {
+        method: "POST",
+        headers: {
+            "Content-Type": "application/json",
+        },
+        body: JSON.stringify(payload),
+    });
+
+    const enrollTOTP = async (values) => {
+        const response = await postJSON('/api/v1/login/totp', {
+            username: values.username,
+            password: values.password,
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            messageApi.error(data.error);
+            return;
+        }
+
+        setEnrollment(data.data);
+        messageApi.warning('Two-factor authentication is required. Add the secret to your authenticator app and enter the code.');
+    };
+
+    const confirmTOTP = async (values) => {
+        const response = await postJSON('/api/v1/login/totp/confirm', {
+            username: values.username,
+            password: values.password,
+            otp: values.otp,
+        });
+        const data = await response.json();
+        if (!response.ok) {
+            messageApi.error(data.error);
+            return;
+        }
+
+        setEnrollment(null);
+        setRecoveryCodes(data.data.recovery_codes);
+        setOtpRequired(true);
+        messageApi.success('Two-factor authentication enabled. Sign in with a new code.');
+    };
+
     const onFinish = async (values) => {
         setLoading(true);
         try {
+            if (enrollment) {
+                await confirmTOTP(values);
+                return;
+            }
+
             const response = await fetch('/api/v1/login', {
                 method: "POST",
                 headers: {
@@ -21,20 +100,35 @@ const Login = () => {
                 },
                 body: JSON.stringify({
                     username: values.username,
-                    password: values.password
+                    password: values.password,
+                    new_password: values.new_password,
+                    otp: values.otp
                 }),
             });
 
             if (response.ok) {
                 const data = await response.json();
-                localStorage.setItem('token', data.data.token);
-
-                const decoded = jwtDecode(data.data.token)
-                localStorage.setItem('jwt', JSON.stringify(decoded))
+                saveToken(data.data.token, data.data.refresh_token);
                 messageApi.success('Login successful!');
                 navigate("/")
             } else if (response.status === 401) {
                 messageApi.error('Username or password is incorrect');
+            } else if (response.status === 429) {
+                messageApi.error('Too many failed attempts. Please try again later.');
+            } else if (response.status === 403) {
+                const data = await response.json();
+                if (data.error === 'two-factor code required') {
+                    setOtpRequired(true);
+                    messageApi.warning('Please enter the code from your authenticator app or a recovery code.');
+                } else if (data.error === 'two-factor enrollment required') {
+                    await enrollTOTP(values);
+                } else {
+                    setMustChangePassword(true);
+                    messageApi.warning('Password must be changed. Please enter a new password.');
+                }
+            } else if (response.status === 400) {
+                const data = await response.json();
+                messageApi.error(data.error);
             } else {
                 messageApi.error('Login failed. Please try again.');
             }
@@ -96,6 +190,49 @@ const Login = () => {
                         <Input.Password size="large" placeholder="Enter your password" />
                     </Form.Item>
 
+                    {(otpRequired || enrollment) && (
+                        <Form.Item
+                            label="Authentication code"
+                            name="otp"
+                            rules={[{ required: true, message: 'Please input your authentication code!' }]}
+                        >
+                            <Input size="large" placeholder="123456" autoComplete="one-time-code" />
+                        </Form.Item>
+                    )}
+
+                    {enrollment && (
+                        <Alert
+                            type="info"
+                            style={{ marginBottom: 24 }}
+                            message="Add to authenticator app"
+                            description={
+                                <>
+                                    <Paragraph copyable={{ text: enrollment.secret }}>Secret: <code>{enrollment.secret}</code></Paragraph>
+                                    <Paragraph copyable={{ text: enrollment.uri }} ellipsis>{enrollment.uri}</Paragraph>
+                                </>
+                            }
+                        />
+                    )}
+
+                    {recoveryCodes && (
+                        <Alert
+                            type="warning"
+                            style={{ marginBottom: 24 }}
+                            message="Save your recovery codes, they are shown only once"
+                            description={<Paragraph copyable={{ text: recoveryCodes.join('\n') }}><code>{recoveryCodes.join(' ')}</code></Paragraph>}
+                        />
+                    )}
+
+                    {mustChangePassword && (
+                        <Form.Item
+                            label="New password"
+                            name="new_password"
+                            rules={[{ required: true, message: 'Please input your new password!' }]}
+                        >
+                            <Input.Password size="large" placeholder="Enter your new password" />
+                        </Form.Item>
+                    )}
+
                     <Form.Item name="remember" valuePropName="checked">
                         <Checkbox>Remember me</Checkbox>
                     </Form.Item>
@@ -111,6 +248,14 @@ const Login = () => {
                             Sign In
                         </Button>
                     </Form.Item>
+
+                    {oidcEnabled && (
+                        <Form.Item>
+                            <Button size="large" block href="/api/v1/oidc/login">
+                                Sign In with SSO
+                            </Button>
+                        </Form.Item>
+                    )}
                 </Form>
             </Card>
         </div>
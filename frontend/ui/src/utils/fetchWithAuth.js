@@ -1,4 +1,28 @@
-export const fetchWithAuth = async (url, options = {}, navigate) => {
+const refreshSession = async () => {
+    const refreshToken = localStorage.getItem('refresh_token');
+    if (!refreshToken) {
+        return false;
+    }
+
+    const response = await fetch('/api/v1/refresh', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ refresh_token: refreshToken }),
+    });
+
+    if (!response.ok) {
+        localStorage.removeItem('refresh_token');
+        return false;
+    }
+
+    const data = await response.json();
+    localStorage.setItem('token', data.data.token);
+    localStorage.setItem('refresh_token', data.data.refresh_token);
+
+    return true;
+};
+
+const doFetch = (url, options) => {
     const token = localStorage.getItem('token');
 
     const headers = {
@@ -6,7 +30,16 @@ export const fetchWithAuth = async (url, options = {}, navigate) => {
         'Authorization': `jwt ${token}`
     };
 
-    const response = await fetch(url, { ...options, headers });
+    return fetch(url, { ...options, headers });
+};
+
+export const fetchWithAuth = async (url, options = {}, navigate) => {
+    let response = await doFetch(url, options);
+
+    // access token is short-lived, try to refresh it once
+    if (response.status === 401 && await refreshSession()) {
+        response = await doFetch(url, options);
+    }
 
     if (response.status === 401) {
         if (navigate) {
@@ -17,4 +50,4 @@ export const fetchWithAuth = async (url, options = {}, navigate) => {
     }
 
     return response;
-};
\ No newline at end of file
+};
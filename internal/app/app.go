@@ -3,26 +3,183 @@ package app
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/DesSolo/rtc/internal/models"
 )
 
 // App ...
-type App struct{}
+type App struct {
+	di *container
+}
 
 // New ...
-func New() *App {
-	return &App{}
+func New(configFilePath string) *App {
+	return &App{
+		di: newContainer(configFilePath),
+	}
 }
 
 // Run ...
 func (app *App) Run(ctx context.Context) error {
-	di := newContainer()
+	configureLogger(app.di)
+
+	if app.di.Config().Storage.AutoMigrate {
+		if err := app.MigrateUp(ctx); err != nil {
+			return fmt.Errorf("failed to auto migrate: %w", err)
+		}
+	}
+
+	expired, err := app.di.Provider().ExpireDefaultAdminPassword(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check default admin: %w", err)
+	}
 
-	configureLogger(di)
+	if expired {
+		slog.ErrorContext(ctx, "SECURITY: user admin has default password rtc, the password must be changed on next login "+
+			"and its sessions and API tokens are rejected until then")
+	}
+
+	reloader := app.di.Reloader()
+
+	go func() {
+		if err := reloader.Run(ctx); err != nil {
+			slog.ErrorContext(ctx, "reloader stopped", "err", err)
+		}
+	}()
+
+	go func() {
+		if err := app.di.Provider().RunAuditCheckpoints(ctx); err != nil {
+			slog.ErrorContext(ctx, "audit checkpoints stopped", "err", err)
+		}
+	}()
+
+	go func() {
+		if err := app.di.Provider().RunAuditSinks(ctx); err != nil {
+			slog.ErrorContext(ctx, "audit sinks stopped", "err", err)
+		}
+	}()
+
+	go func() {
+		if err := app.di.Provider().RunAuditRetention(ctx); err != nil {
+			slog.ErrorContext(ctx, "audit retention stopped", "err", err)
+		}
+	}()
 
 	// nolint:contextcheck
-	if err := di.Server().Run(ctx); err != nil {
+	if err := app.di.Server().Run(ctx); err != nil {
 		return fmt.Errorf("failed to run server: %w", err)
 	}
 
 	return nil
 }
+
+// MigrateUp apply all pending migrations
+func (app *App) MigrateUp(ctx context.Context) error {
+	results, err := app.di.Migrator().Up(ctx)
+	if err != nil {
+		return fmt.Errorf("migrator.Up: %w", err)
+	}
+
+	for _, result := range results {
+		slog.InfoContext(ctx, "migration applied", "source", result.Source.Path, "duration", result.Duration)
+	}
+
+	slog.InfoContext(ctx, "migrations is up to date", "applied", len(results))
+
+	return nil
+}
+
+// MigrateDown rollback last applied migration
+func (app *App) MigrateDown(ctx context.Context) error {
+	result, err := app.di.Migrator().Down(ctx)
+	if err != nil {
+		return fmt.Errorf("migrator.Down: %w", err)
+	}
+
+	slog.InfoContext(ctx, "migration rolled back", "source", result.Source.Path, "duration", result.Duration)
+
+	return nil
+}
+
+// MigrationStatus ...
+type MigrationStatus struct {
+	Source    string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// MigrateStatus returns migrations status
+func (app *App) MigrateStatus(ctx context.Context) ([]*MigrationStatus, error) {
+	statuses, err := app.di.Migrator().Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("migrator.Status: %w", err)
+	}
+
+	result := make([]*MigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, &MigrationStatus{
+			Source:    status.Source.Path,
+			Applied:   status.State == goose.StateApplied,
+			AppliedAt: status.AppliedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// CreateUser creates local user (for bootstrap admin)
+func (app *App) CreateUser(ctx context.Context, user *models.User, password string) error {
+	if err := app.di.Provider().CreateUser(ctx, user, password); err != nil {
+		return fmt.Errorf("provider.CreateUser: %w", err)
+	}
+
+	return nil
+}
+
+// CheckConfig loads config and all dependencies without external connections
+func (app *App) CheckConfig(ctx context.Context) error {
+	conf, err := loadConfig(app.di.configFilePath)
+	if err != nil {
+		return fmt.Errorf("loadConfig: %w", err)
+	}
+
+	if _, err := newJWTAuth(conf); err != nil {
+		return fmt.Errorf("newJWTAuth: %w", err)
+	}
+
+	if _, err := newAuthorizer(ctx, conf); err != nil {
+		return fmt.Errorf("newAuthorizer: %w", err)
+	}
+
+	if _, err := loadTLSConfig(conf.Server.TLS); err != nil {
+		return fmt.Errorf("loadTLSConfig: %w", err)
+	}
+
+	if _, err := parseTrustedProxies(conf.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("parseTrustedProxies: %w", err)
+	}
+
+	if options := conf.Server.Auth.ClientCert; options.Enabled {
+		if _, err := parseCertRules(options.Rules); err != nil {
+			return fmt.Errorf("parseCertRules: %w", err)
+		}
+	}
+
+	if _, err := newTwoFactorPolicy(conf); err != nil {
+		return fmt.Errorf("newTwoFactorPolicy: %w", err)
+	}
+
+	if _, err := newAuditCheckpoints(conf); err != nil {
+		return fmt.Errorf("newAuditCheckpoints: %w", err)
+	}
+
+	if _, err := newAuditSinks(conf); err != nil {
+		return fmt.Errorf("newAuditSinks: %w", err)
+	}
+
+	return nil
+}
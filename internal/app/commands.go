@@ -0,0 +1,186 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+// Version application version (overridden on build)
+var Version = "dev"
+
+// NewCommand returns root rtcserver command
+func NewCommand() *cobra.Command {
+	var configFilePath string
+
+	cmd := &cobra.Command{
+		Use:           "rtcserver",
+		Short:         "rtcserver is a RTC api and ui server",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return New(configFilePath).Run(cmd.Context())
+		},
+	}
+
+	defaultConfigFilePath := os.Getenv("CONFIG_FILE_PATH")
+	if defaultConfigFilePath == "" {
+		defaultConfigFilePath = "config.yaml"
+	}
+
+	cmd.PersistentFlags().StringVarP(&configFilePath, "config", "c", defaultConfigFilePath, "config file path (env CONFIG_FILE_PATH)")
+
+	cmd.AddCommand(
+		newMigrateCommand(&configFilePath),
+		newCreateUserCommand(&configFilePath),
+		newCheckConfigCommand(&configFilePath),
+		newVersionCommand(),
+	)
+
+	return cmd
+}
+
+func newMigrateCommand(configFilePath *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "manage database migrations",
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "apply all pending migrations",
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				application := New(*configFilePath)
+				configureLogger(application.di)
+
+				return application.MigrateUp(cmd.Context())
+			},
+		},
+		&cobra.Command{
+			Use:   "down",
+			Short: "rollback last applied migration",
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				application := New(*configFilePath)
+				configureLogger(application.di)
+
+				return application.MigrateDown(cmd.Context())
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "show migrations status",
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				statuses, err := New(*configFilePath).MigrateStatus(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "APPLIED AT\tMIGRATION")
+
+				for _, status := range statuses {
+					appliedAt := "pending"
+					if status.Applied {
+						appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+					}
+
+					fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Source)
+				}
+
+				return w.Flush() // nolint:wrapcheck
+			},
+		},
+	)
+
+	return cmd
+}
+
+func newCreateUserCommand(configFilePath *string) *cobra.Command {
+	var (
+		username string
+		password string
+		roles    []string
+		disabled bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-user",
+		Short: "create local user (password is read from stdin if flag is not set)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if username == "" {
+				return errors.New("username is required")
+			}
+
+			if password == "" {
+				fmt.Fprint(cmd.ErrOrStderr(), "password: ")
+
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && line == "" {
+					return fmt.Errorf("read password: %w", err)
+				}
+
+				password = strings.TrimRight(line, "\r\n")
+			}
+
+			if password == "" {
+				return errors.New("password is required")
+			}
+
+			application := New(*configFilePath)
+			configureLogger(application.di)
+
+			if err := application.CreateUser(cmd.Context(), &models.User{
+				Username:  username,
+				IsEnabled: !disabled,
+				Roles:     roles,
+			}, password); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "user %q created\n", username)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", "", "username")
+	cmd.Flags().StringVar(&password, "password", "", "password")
+	cmd.Flags().StringSliceVar(&roles, "roles", nil, "user roles (comma separated)")
+	cmd.Flags().BoolVar(&disabled, "disabled", false, "create disabled user")
+
+	return cmd
+}
+
+func newCheckConfigCommand(configFilePath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-config",
+		Short: "validate config file, keys and authorization policy",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := New(*configFilePath).CheckConfig(cmd.Context()); err != nil {
+				return fmt.Errorf("config %s is invalid: %w", *configFilePath, err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "config %s is valid\n", *configFilePath)
+
+			return nil
+		},
+	}
+}
+
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "print version",
+		Run: func(cmd *cobra.Command, _ []string) {
+			fmt.Fprintf(cmd.OutOrStdout(), "rtcserver %s\n", Version)
+		},
+	}
+}
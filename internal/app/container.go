@@ -2,14 +2,12 @@ package app
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"os"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/jackc/pgx/v5/stdlib"
 	clientv3 "go.etcd.io/etcd/client/v3"
 
 	"github.com/DesSolo/rtc/internal/auth"
@@ -23,33 +21,39 @@ import (
 )
 
 const (
-	healthCheckTimeout = time.Second * 5
+	healthCheckTimeout  = time.Second * 5
+	oidcDiscoverTimeout = time.Second * 10
 )
 
 type container struct {
+	configFilePath string
+
 	config        *config.Config
+	pool          *pgxpool.Pool
 	storage       storage.Storage
+	migrator      *postgres.Migrator
 	valuesStorage storage.ValuesStorage
 	provider      *provider.Provider
 	jwtAuth       *auth.JWT
+	tokenAuth     *auth.Token
+	oidcAuth      *auth.OIDC
+	regoAuth      *auth.Rego
 	authorizer    auth.Authorizer
+	reloader      *reloader
 	server        *server.Server
 }
 
-func newContainer() *container {
-	return &container{}
+func newContainer(configFilePath string) *container {
+	return &container{
+		configFilePath: configFilePath,
+	}
 }
 
 func (c *container) Config() *config.Config {
 	if c.config == nil {
-		configFilePath := os.Getenv("CONFIG_FILE_PATH")
-		if configFilePath == "" {
-			configFilePath = "config.yaml"
-		}
-
-		conf, err := config.NewConfigFromFile(configFilePath)
+		conf, err := loadConfig(c.configFilePath)
 		if err != nil {
-			fatal("failed to load config from file", err)
+			fatal("failed to load config", err)
 		}
 
 		c.config = conf
@@ -58,8 +62,8 @@ func (c *container) Config() *config.Config {
 	return c.config
 }
 
-func (c *container) Storage() storage.Storage {
-	if c.storage == nil {
+func (c *container) PostgresPool() *pgxpool.Pool {
+	if c.pool == nil {
 		options := c.Config().Storage
 
 		poolConfig, err := pgxpool.ParseConfig(options.DSN)
@@ -79,7 +83,15 @@ func (c *container) Storage() storage.Storage {
 			fatal("failed to ping postgres", err)
 		}
 
-		pgStorage := postgres.NewStorage(pool)
+		c.pool = pool
+	}
+
+	return c.pool
+}
+
+func (c *container) Storage() storage.Storage {
+	if c.storage == nil {
+		pgStorage := postgres.NewStorage(c.PostgresPool())
 
 		closer.Add(pgStorage.Close)
 
@@ -89,6 +101,21 @@ func (c *container) Storage() storage.Storage {
 	return c.storage
 }
 
+func (c *container) Migrator() *postgres.Migrator {
+	if c.migrator == nil {
+		migrator, err := postgres.NewMigrator(stdlib.OpenDBFromPool(c.PostgresPool()))
+		if err != nil {
+			fatal("failed to create migrator", err)
+		}
+
+		closer.Add(migrator.Close)
+
+		c.migrator = migrator
+	}
+
+	return c.migrator
+}
+
 func (c *container) ValuesStorage() storage.ValuesStorage {
 	if c.valuesStorage == nil {
 		options := c.Config().ValuesStorage
@@ -118,7 +145,17 @@ func (c *container) ValuesStorage() storage.ValuesStorage {
 
 func (c *container) Provider() *provider.Provider {
 	if c.provider == nil {
-		c.provider = provider.NewProvider(c.Storage(), c.ValuesStorage())
+		c.provider = provider.NewProvider(c.Storage(), c.ValuesStorage(),
+			loadPasswordPolicy(c),
+			loadLoginBackend(c),
+			loadLoginThrottle(c),
+			loadTwoFactor(c),
+			loadRefreshTokenTTL(c),
+			provider.WithReasonRequiredEnvs(c.Config().Server.Configs.ReasonRequiredEnvs),
+			loadAuditCheckpoints(c),
+			loadAuditSinks(c),
+			loadAuditRetention(c),
+		)
 	}
 
 	return c.provider
@@ -126,19 +163,12 @@ func (c *container) Provider() *provider.Provider {
 
 func (c *container) JWTAuth() *auth.JWT {
 	if c.jwtAuth == nil {
-		options := c.Config().Server.Auth.JWT
-
-		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(options.PrivateKey))
-		if err != nil {
-			fatal("failed to parse private key", err)
-		}
-
-		publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(options.PublicKey))
+		jwtAuth, err := newJWTAuth(c.Config())
 		if err != nil {
-			fatal("failed to parse public key", err)
+			fatal("failed to load jwt keys", err)
 		}
 
-		c.jwtAuth = auth.NewJWT(privateKey, publicKey, options.TTL)
+		c.jwtAuth = jwtAuth
 	}
 
 	return c.jwtAuth
@@ -146,37 +176,80 @@ func (c *container) JWTAuth() *auth.JWT {
 
 func (c *container) Authorizer() auth.Authorizer {
 	if c.authorizer == nil {
-		options := c.Config().Server.Authorizer
-
-		switch options.Kind {
-		case "rego":
-			policy, err := rego.New(
-				rego.Query(options.Rego.Query),
-				rego.Load([]string{options.Rego.PolicyPath}, nil),
-			).PrepareForEval(context.Background())
-			if err != nil {
-				fatal("failed to load OPA policy:", err)
-			}
-
-			c.authorizer = auth.NewRego(&policy)
-		case "noop":
-			c.authorizer = auth.NewNoop()
-		default:
-			fatal(options.Kind, errors.New("unknown authorizer"))
+		authorizer, err := newAuthorizer(context.Background(), c.Config())
+		if err != nil {
+			fatal("failed to load authorizer", err)
+		}
+
+		if regoAuth, ok := authorizer.(*auth.Rego); ok {
+			c.regoAuth = regoAuth
 		}
+
+		c.authorizer = authorizer
 	}
 
 	return c.authorizer
 }
 
+func (c *container) TokenAuth() *auth.Token {
+	if c.tokenAuth == nil {
+		c.tokenAuth = auth.NewToken(convertTokensToAuth(c.Config().Server.Auth.Tokens))
+	}
+
+	return c.tokenAuth
+}
+
+// OIDCAuth returns nil if OpenID Connect is disabled
+func (c *container) OIDCAuth() *auth.OIDC {
+	options := c.Config().Server.Auth.OIDC
+
+	if c.oidcAuth == nil && options.Enabled {
+		ctx, cancel := context.WithTimeout(context.Background(), oidcDiscoverTimeout)
+		defer cancel()
+
+		oidcAuth, err := auth.NewOIDC(ctx, convertOIDCToAuth(options))
+		if err != nil {
+			fatal("failed to discover oidc provider", err)
+		}
+
+		c.oidcAuth = oidcAuth
+	}
+
+	return c.oidcAuth
+}
+
+func (c *container) Reloader() *reloader {
+	if c.reloader == nil {
+		// authorizer must be initialized before reloader
+		c.Authorizer()
+
+		c.reloader = newReloader(c.configFilePath, c.Config(), c.JWTAuth(), c.TokenAuth(), c.regoAuth)
+	}
+
+	return c.reloader
+}
+
 func (c *container) Server() *server.Server {
 	if c.server == nil {
 		options := c.Config().Server
 
+		tlsConfig, err := loadTLSConfig(options.TLS)
+		if err != nil {
+			fatal("failed to load TLS config", err)
+		}
+
+		trustedProxies, err := parseTrustedProxies(options.TrustedProxies)
+		if err != nil {
+			fatal("failed to parse trusted proxies", err)
+		}
+
 		c.server = server.NewServer(c.Provider(), c.JWTAuth(),
+			server.WithTLS(tlsConfig),
 			server.WithAddress(options.Address),
 			server.WithReadHeaderTimeout(options.ReadHeaderTimeout),
+			server.WithTrustedProxies(trustedProxies),
 			server.WithAuthorizer(c.Authorizer()),
+			server.WithOIDC(c.OIDCAuth()),
 			loadServerAuth(c),
 		)
 	}
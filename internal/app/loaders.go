@@ -1,13 +1,45 @@
 package app
 
 import (
+	"cmp"
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/netip"
 	"os"
+	"regexp"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/DesSolo/rtc/internal/auditsink"
 	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/config"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/provider"
 	"github.com/DesSolo/rtc/internal/server"
 )
 
+const (
+	defaultOIDCUsernameClaim = "preferred_username"
+
+	defaultLDAPTimeout           = time.Second * 5
+	defaultLDAPGroupAttribute    = "memberOf"
+	defaultLDAPUsernameAttribute = "uid"
+
+	defaultTOTPIssuer = "RTC"
+
+	defaultAuditCheckpointsInterval = time.Hour
+	defaultAuditSinksPollInterval   = time.Second
+	defaultAuditRetentionInterval   = 24 * time.Hour
+)
+
 func configureLogger(di *container) {
 	options := di.Config().Logging
 
@@ -17,23 +49,556 @@ func configureLogger(di *container) {
 }
 
 func loadServerAuth(di *container) server.OptionFunc {
-	options := di.Config().Server.Auth
+	// token authenticator is always enabled because tokens can be added on reload
+	authenticators := map[string]auth.Authenticator{
+		"jwt":   newRevocationCheck(di.JWTAuth(), di.Provider()),
+		"token": auth.NewChain(di.TokenAuth(), newAPITokenAuth(di.Provider())),
+	}
+
+	if oidcAuth := di.OIDCAuth(); oidcAuth != nil {
+		authenticators["bearer"] = newRevocationCheck(newOIDCBearerAuth(oidcAuth, di.Provider()), di.Provider())
+	}
 
-	if len(options.Tokens) == 0 {
-		return server.Noop()
+	if options := di.Config().Server.Auth.ClientCert; options.Enabled {
+		rules, err := parseCertRules(options.Rules)
+		if err != nil {
+			fatal("invalid client certificate rules", err)
+		}
+
+		authenticators["cert"] = auth.NewClientCert(rules)
 	}
 
-	tokenItems := make(map[string]*auth.Payload, len(options.Tokens))
+	return server.WithAuth(authenticators)
+}
+
+// newRevocationCheck rejects tokens of disabled users and tokens issued before user changes
+func newRevocationCheck(authenticator auth.Authenticator, p *provider.Provider) auth.Authenticator {
+	return auth.AuthenticatorFunc(func(ctx context.Context, token string) (*auth.Payload, error) {
+		payload, err := authenticator.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err // nolint:wrapcheck
+		}
+
+		revoked, err := p.IsTokenRevoked(ctx, payload.Username, payload.IssuedAt)
+		if err != nil {
+			return nil, fmt.Errorf("provider.IsTokenRevoked: %w", err)
+		}
 
-	for token, payload := range options.Tokens {
-		tokenItems[token] = &auth.Payload{
+		if revoked {
+			return nil, fmt.Errorf("%w: token is revoked", auth.ErrAuthFailed)
+		}
+
+		return payload, nil
+	})
+}
+
+// newOIDCBearerAuth username of IdP token is allowed only for users provisioned from OIDC,
+// so IdP account can't act as local or LDAP user with the same name
+func newOIDCBearerAuth(authenticator auth.Authenticator, p *provider.Provider) auth.Authenticator {
+	return auth.AuthenticatorFunc(func(ctx context.Context, token string) (*auth.Payload, error) {
+		payload, err := authenticator.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err // nolint:wrapcheck
+		}
+
+		_, err = p.EnsureExternalUser(ctx, &models.User{
 			Username: payload.Username,
 			Roles:    payload.Roles,
+			Source:   models.UserSourceOIDC,
+		})
+		if err != nil {
+			if errors.Is(err, provider.ErrNotFound) || errors.Is(err, provider.ErrAlreadyExists) {
+				return nil, fmt.Errorf("%w: user %q is not allowed for bearer token", auth.ErrAuthFailed, payload.Username)
+			}
+
+			return nil, fmt.Errorf("provider.EnsureExternalUser: %w", err)
+		}
+
+		return payload, nil
+	})
+}
+
+func newAPITokenAuth(p *provider.Provider) auth.Authenticator {
+	return auth.AuthenticatorFunc(func(ctx context.Context, token string) (*auth.Payload, error) {
+		user, apiToken, err := p.AuthenticateAPIToken(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("provider.AuthenticateAPIToken: %w", err)
+		}
+
+		return &auth.Payload{
+			Username: user.Username,
+			Roles:    user.Roles,
+			Scopes:   apiToken.Scopes,
+		}, nil
+	})
+}
+
+func loadPasswordPolicy(di *container) provider.OptionFunc {
+	options := di.Config().Server.Auth.PasswordPolicy
+
+	return provider.WithPasswordPolicy(provider.PasswordPolicy{
+		MinLength:     options.MinLength,
+		RequireUpper:  options.RequireUpper,
+		RequireLower:  options.RequireLower,
+		RequireDigit:  options.RequireDigit,
+		RequireSymbol: options.RequireSymbol,
+	})
+}
+
+func loadRefreshTokenTTL(di *container) provider.OptionFunc {
+	ttl := di.Config().Server.Auth.JWT.RefreshTTL
+	if ttl == 0 {
+		return provider.Noop()
+	}
+
+	return provider.WithRefreshTokenTTL(ttl)
+}
+
+func loadLoginBackend(di *container) provider.OptionFunc {
+	options := di.Config().Server.Auth
+
+	switch options.Login.Backend {
+	case "ldap":
+		return provider.WithLoginBackend(newLDAPLogin(auth.NewLDAP(convertLDAPToAuth(options.LDAP))), options.Login.FallbackLocal)
+	default:
+		return provider.Noop()
+	}
+}
+
+func loadLoginThrottle(di *container) provider.OptionFunc {
+	options := di.Config().Server.Auth.Login.Throttle
+
+	return provider.WithLoginThrottle(provider.LoginThrottle{
+		MaxAttempts:      options.MaxAttempts,
+		MaxAttemptsPerIP: options.MaxAttemptsPerIP,
+		Window:           options.Window,
+		LockoutDuration:  options.LockoutDuration,
+	})
+}
+
+func loadTwoFactor(di *container) provider.OptionFunc {
+	policy, err := newTwoFactorPolicy(di.Config())
+	if err != nil {
+		fatal("invalid totp options", err)
+	}
+
+	if policy == nil {
+		return provider.Noop()
+	}
+
+	return provider.WithTwoFactor(*policy)
+}
+
+// newTwoFactorPolicy returns nil if two-factor authentication is disabled
+func newTwoFactorPolicy(conf *config.Config) (*provider.TwoFactorPolicy, error) {
+	options := conf.Server.Auth.TOTP
+	if options.EncryptionKey == "" {
+		return nil, nil // nolint:nilnil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(options.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("base64.DecodeString: %w", err)
+	}
+
+	cipher, err := auth.NewAESCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("auth.NewAESCipher: %w", err)
+	}
+
+	return &provider.TwoFactorPolicy{
+		Issuer:        cmp.Or(options.Issuer, defaultTOTPIssuer),
+		Cipher:        cipher,
+		RequiredRoles: options.RequiredRoles,
+	}, nil
+}
+
+func loadAuditCheckpoints(di *container) provider.OptionFunc {
+	checkpoints, err := newAuditCheckpoints(di.Config())
+	if err != nil {
+		fatal("invalid audit checkpoints private key", err)
+	}
+
+	if checkpoints == nil {
+		return provider.Noop()
+	}
+
+	return provider.WithAuditCheckpoints(checkpoints)
+}
+
+// newAuditCheckpoints returns nil if checkpoints are disabled
+func newAuditCheckpoints(conf *config.Config) (*provider.AuditCheckpoints, error) {
+	options := conf.Server.Audit.Checkpoints
+	if options.PrivateKey == "" {
+		return nil, nil // nolint:nilnil
+	}
+
+	key, err := parseEd25519PrivateKey(options.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parseEd25519PrivateKey: %w", err)
+	}
+
+	return &provider.AuditCheckpoints{
+		Key:      key,
+		Interval: cmp.Or(options.Interval, defaultAuditCheckpointsInterval),
+	}, nil
+}
+
+func loadAuditSinks(di *container) provider.OptionFunc {
+	sinks, err := newAuditSinks(di.Config())
+	if err != nil {
+		fatal("invalid audit sinks", err)
+	}
+
+	if sinks == nil {
+		return provider.Noop()
+	}
+
+	return provider.WithAuditSinks(sinks)
+}
+
+// newAuditSinks returns nil if no sinks are configured
+func newAuditSinks(conf *config.Config) (*provider.AuditSinks, error) {
+	options := conf.Server.Audit
+	if len(options.Sinks) == 0 {
+		return nil, nil // nolint:nilnil
+	}
+
+	sinks := make([]provider.AuditSink, 0, len(options.Sinks))
+
+	for _, sink := range options.Sinks {
+		switch sink.Kind {
+		case "file":
+			sinks = append(sinks, auditsink.NewFile(sink.Name, sink.Path))
+		case "syslog":
+			syslog, err := auditsink.NewSyslog(sink.Name, auditsink.SyslogOptions{
+				Network:  sink.Network,
+				Address:  sink.Address,
+				Facility: sink.Facility,
+				Timeout:  sink.Timeout,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("sink %s: auditsink.NewSyslog: %w", sink.Name, err)
+			}
+
+			sinks = append(sinks, syslog)
+		case "webhook":
+			sinks = append(sinks, auditsink.NewWebhook(sink.Name, auditsink.WebhookOptions{
+				URL:     sink.URL,
+				Headers: sink.Headers,
+				Timeout: sink.Timeout,
+			}))
+		default:
+			return nil, fmt.Errorf("sink %s: unknown kind %q", sink.Name, sink.Kind)
+		}
+	}
+
+	return &provider.AuditSinks{
+		Sinks:        sinks,
+		PollInterval: cmp.Or(options.SinksPollInterval, defaultAuditSinksPollInterval),
+	}, nil
+}
+
+func loadAuditRetention(di *container) provider.OptionFunc {
+	options := di.Config().Server.Audit.Retention
+	if options.Keep == 0 {
+		return provider.Noop()
+	}
+
+	return provider.WithAuditRetention(&provider.AuditRetention{
+		Keep:       options.Keep,
+		Interval:   cmp.Or(options.Interval, defaultAuditRetentionInterval),
+		ArchiveDir: options.ArchiveDir,
+	})
+}
+
+func loadConfig(configFilePath string) (*config.Config, error) {
+	conf, err := config.NewConfigFromFile(configFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("config.NewConfigFromFile: %w", err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("conf.Validate: %w", err)
+	}
+
+	return conf, nil
+}
+
+func newJWTAuth(conf *config.Config) (*auth.JWT, error) {
+	options := conf.Server.Auth.JWT
+
+	keys, err := parseJWTKeys(options.JWTKey, options.Keys, options.SigningKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("parseJWTKeys: %w", err)
+	}
+
+	return auth.NewJWT(keys, options.TTL), nil
+}
+
+func newAuthorizer(ctx context.Context, conf *config.Config) (auth.Authorizer, error) {
+	options := conf.Server.Authorizer
+
+	switch options.Kind {
+	case "rego":
+		policy, err := auth.PrepareRego(ctx, options.Rego.Query, options.Rego.PolicyPath)
+		if err != nil {
+			return nil, fmt.Errorf("auth.PrepareRego: %w", err)
 		}
+
+		return auth.NewRego(policy), nil
+	case "rbac":
+		adminRoles := options.RBAC.AdminRoles
+		if len(adminRoles) == 0 {
+			adminRoles = []string{"admin"}
+		}
+
+		return auth.NewRBAC(adminRoles), nil
+	case "noop":
+		return auth.NewNoop(), nil
+	default:
+		return nil, fmt.Errorf("unknown authorizer %q", options.Kind)
+	}
+}
+
+func parseEd25519PrivateKey(data string) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("pem block is not found")
 	}
 
-	return server.WithAuth(map[string]auth.Authenticator{
-		"jwt":   di.JWTAuth(),
-		"token": auth.NewToken(tokenItems),
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("x509.ParsePKCS8PrivateKey: %w", err)
+	}
+
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected key type %T, ed25519 is required", key)
+	}
+
+	return privateKey, nil
+}
+
+func newLDAPLogin(ldap *auth.LDAP) provider.LoginBackend {
+	return provider.LoginBackendFunc(func(ctx context.Context, username, password string) (*models.User, error) {
+		payload, err := ldap.AuthenticatePassword(ctx, username, password)
+		if err != nil {
+			if errors.Is(err, auth.ErrAuthFailed) {
+				return nil, provider.ErrNotFound
+			}
+
+			return nil, fmt.Errorf("ldap.AuthenticatePassword: %w", err)
+		}
+
+		return &models.User{
+			Username: payload.Username,
+			Roles:    payload.Roles,
+			Source:   models.UserSourceLDAP,
+		}, nil
 	})
 }
+
+func convertLDAPToAuth(options config.LDAP) auth.LDAPOptions {
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = defaultLDAPTimeout
+	}
+
+	groupAttribute := options.GroupAttribute
+	if groupAttribute == "" {
+		groupAttribute = defaultLDAPGroupAttribute
+	}
+
+	return auth.LDAPOptions{
+		URL:                options.URL,
+		StartTLS:           options.StartTLS,
+		InsecureSkipVerify: options.InsecureSkipVerify,
+		Timeout:            timeout,
+		BindDN:             options.BindDN,
+		BindPassword:       options.BindPassword,
+		UserDNTemplate:     options.UserDNTemplate,
+		UserBaseDN:         options.UserBaseDN,
+		UserFilter:         options.UserFilter,
+		UsernameAttribute:  cmp.Or(options.UsernameAttribute, defaultLDAPUsernameAttribute),
+		GroupAttribute:     groupAttribute,
+		GroupRoles:         options.GroupRoles,
+		DefaultRoles:       options.DefaultRoles,
+	}
+}
+
+func convertOIDCToAuth(options config.OIDC) auth.OIDCOptions {
+	usernameClaim := options.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = defaultOIDCUsernameClaim
+	}
+
+	rules := make([]auth.RoleRule, 0, len(options.RoleRules))
+	for _, rule := range options.RoleRules {
+		rules = append(rules, auth.RoleRule(rule))
+	}
+
+	return auth.OIDCOptions{
+		IssuerURL:    options.IssuerURL,
+		ClientID:     options.ClientID,
+		ClientSecret: options.ClientSecret,
+		RedirectURL:  options.RedirectURL,
+		Scopes:       options.Scopes,
+		Audience:     options.Audience,
+		Mapper: &auth.ClaimsMapper{
+			UsernameClaim: usernameClaim,
+			Rules:         rules,
+			DefaultRoles:  options.DefaultRoles,
+		},
+	}
+}
+
+// parseJWTKeys main key is signing key unless signing_key_id select other one
+func parseJWTKeys(options config.JWTKey, keys []config.JWTKey, signingKeyID string) (*auth.KeySet, error) {
+	signingKeys := make([]*auth.SigningKey, 0, len(keys)+1)
+
+	for _, key := range append([]config.JWTKey{options}, keys...) {
+		signingKey, err := parseJWTKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", key.ID, err)
+		}
+
+		signingKeys = append(signingKeys, signingKey)
+	}
+
+	keySet, err := auth.NewKeySet(signingKeyID, signingKeys...)
+	if err != nil {
+		return nil, fmt.Errorf("auth.NewKeySet: %w", err)
+	}
+
+	return keySet, nil
+}
+
+func parseJWTKey(key config.JWTKey) (*auth.SigningKey, error) {
+	signingKey := &auth.SigningKey{
+		ID: key.ID,
+	}
+
+	if key.PrivateKey != "" {
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("jwt.ParseRSAPrivateKeyFromPEM: %w", err)
+		}
+
+		signingKey.Private = privateKey
+	}
+
+	publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("jwt.ParseRSAPublicKeyFromPEM: %w", err)
+	}
+
+	signingKey.Public = publicKey
+
+	return signingKey, nil
+}
+
+func convertTokensToAuth(tokens map[string]config.Token) map[string]*auth.Payload {
+	result := make(map[string]*auth.Payload, len(tokens))
+
+	for token, payload := range tokens {
+		result[token] = &auth.Payload{
+			Username: payload.Username,
+			Roles:    payload.Roles,
+		}
+	}
+
+	return result
+}
+
+func parseCertRules(rules []config.CertRule) ([]auth.CertRule, error) {
+	result := make([]auth.CertRule, 0, len(rules))
+
+	for i, rule := range rules {
+		if !auth.ValidCertField(rule.Field) {
+			return nil, fmt.Errorf("rules[%d]: unknown field %q", i, rule.Field)
+		}
+
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: regexp.Compile: %w", i, err)
+		}
+
+		result = append(result, auth.CertRule{
+			Field:    rule.Field,
+			Pattern:  pattern,
+			Username: cmp.Or(rule.Username, "$0"),
+			Roles:    rule.Roles,
+		})
+	}
+
+	return result, nil
+}
+
+// parseTrustedProxies accepts addresses and CIDRs
+func parseTrustedProxies(proxies []string) ([]netip.Prefix, error) {
+	result := make([]netip.Prefix, 0, len(proxies))
+
+	for i, proxy := range proxies {
+		if addr, err := netip.ParseAddr(proxy); err == nil {
+			result = append(result, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("trusted_proxies[%d]: %w", i, err)
+		}
+
+		result = append(result, prefix.Masked())
+	}
+
+	return result, nil
+}
+
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// loadTLSConfig returns nil if TLS is not configured
+func loadTLSConfig(options config.TLS) (*tls.Config, error) {
+	if options.CertFile == "" {
+		return nil, nil // nolint:nilnil
+	}
+
+	cert, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls.LoadX509KeyPair: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tlsVersions[options.MinVersion],
+	}
+
+	if options.ClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	data, err := os.ReadFile(options.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates in %s", options.ClientCAFile)
+	}
+
+	tlsConfig.ClientCAs = pool
+	// clients without certificate can use other authentication methods
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+
+	if options.RequireClientCert {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
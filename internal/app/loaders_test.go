@@ -0,0 +1,39 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auth"
+)
+
+func Test_newJWTAuth_KeyID_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	privateKeyPath, publicKeyPath := writeTestJWTKey(t, dir)
+	jwtOptions := "jwt:\n      id: \"2025-10\"\n      private_key_file: " + privateKeyPath + "\n      public_key_file: " + publicKeyPath
+
+	configPath := filepath.Join(dir, "config.yaml")
+	writeTestReloaderConfig(t, configPath, filepath.Join(dir, "authz.rego"), "token", jwtOptions)
+
+	conf, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	jwtAuth, err := newJWTAuth(conf)
+	require.NoError(t, err)
+
+	signed, err := jwtAuth.Encode(&auth.Payload{Username: "test"})
+	require.NoError(t, err)
+
+	token, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	require.NoError(t, err)
+	require.Equal(t, "2025-10", token.Header["kid"])
+
+	require.Len(t, jwtAuth.JWKS().Keys, 1)
+	require.Equal(t, "2025-10", jwtAuth.JWKS().Keys[0].KeyID)
+}
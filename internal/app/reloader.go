@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/config"
+)
+
+const (
+	// editors and kubernetes config maps produce several events on a single change
+	reloadDebounce = time.Second
+)
+
+// reloader reload jwt keys, static tokens and rego policy on SIGHUP or policy file change
+type reloader struct {
+	configFilePath string
+	config         *config.Config
+	jwt            *auth.JWT
+	token          *auth.Token
+	rego           *auth.Rego
+}
+
+func newReloader(configFilePath string, conf *config.Config, jwt *auth.JWT, token *auth.Token, rego *auth.Rego) *reloader {
+	return &reloader{
+		configFilePath: configFilePath,
+		config:         conf,
+		jwt:            jwt,
+		token:          token,
+		rego:           rego,
+	}
+}
+
+// Run blocks until context is done
+func (r *reloader) Run(ctx context.Context) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	var (
+		policyEvents <-chan fsnotify.Event
+		policyErrors <-chan error
+	)
+
+	if r.rego != nil {
+		watcher, err := r.watchPolicy()
+		if err != nil {
+			// reload by signal still works
+			slog.ErrorContext(ctx, "failed to watch policy file, reload on SIGHUP only", "err", err)
+		} else {
+			defer watcher.Close()
+
+			policyEvents = watcher.Events
+			policyErrors = watcher.Errors
+		}
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signals:
+			slog.InfoContext(ctx, "reload signal received")
+			r.reload(ctx)
+		case event := <-policyEvents:
+			if r.isPolicyEvent(event) {
+				debounce.Reset(reloadDebounce)
+			}
+		case err := <-policyErrors:
+			slog.ErrorContext(ctx, "policy watcher", "err", err)
+		case <-debounce.C:
+			slog.InfoContext(ctx, "policy file changed")
+			r.reload(ctx)
+		}
+	}
+}
+
+func (r *reloader) watchPolicy() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("fsnotify.NewWatcher: %w", err)
+	}
+
+	// watch directory to handle atomic file replace
+	if err := watcher.Add(filepath.Dir(r.config.Server.Authorizer.Rego.PolicyPath)); err != nil {
+		_ = watcher.Close()
+
+		return nil, fmt.Errorf("watcher.Add: %w", err)
+	}
+
+	return watcher, nil
+}
+
+func (r *reloader) isPolicyEvent(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod {
+		return false
+	}
+
+	if filepath.Clean(event.Name) == filepath.Clean(r.config.Server.Authorizer.Rego.PolicyPath) {
+		return true
+	}
+
+	// kubernetes config map swaps ..data symlink
+	return strings.HasPrefix(filepath.Base(event.Name), "..")
+}
+
+// reload keeps previous state on any error, config is validated like on startup
+func (r *reloader) reload(ctx context.Context) {
+	conf, err := loadConfig(r.configFilePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reload config, keep previous", "err", err)
+		return
+	}
+
+	r.reloadJWTKeys(ctx, conf)
+
+	r.token.Update(convertTokensToAuth(conf.Server.Auth.Tokens))
+	slog.InfoContext(ctx, "tokens reloaded", "count", len(conf.Server.Auth.Tokens))
+
+	if r.rego == nil {
+		return
+	}
+
+	options := conf.Server.Authorizer
+	if options.Kind != "rego" {
+		slog.WarnContext(ctx, "authorizer kind can't be changed without restart", "kind", options.Kind)
+		return
+	}
+
+	if options.Rego.PolicyPath != r.config.Server.Authorizer.Rego.PolicyPath {
+		slog.WarnContext(ctx, "policy path can't be changed without restart, using previous path",
+			"path", r.config.Server.Authorizer.Rego.PolicyPath,
+		)
+	}
+
+	policy, err := auth.PrepareRego(ctx, options.Rego.Query, r.config.Server.Authorizer.Rego.PolicyPath)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reload policy, keep previous", "err", err)
+		return
+	}
+
+	r.rego.Update(policy)
+	slog.InfoContext(ctx, "policy reloaded", "path", r.config.Server.Authorizer.Rego.PolicyPath)
+}
+
+func (r *reloader) reloadJWTKeys(ctx context.Context, conf *config.Config) {
+	options := conf.Server.Auth.JWT
+
+	keys, err := parseJWTKeys(options.JWTKey, options.Keys, options.SigningKeyID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to reload jwt keys, keep previous", "err", err)
+		return
+	}
+
+	r.jwt.Update(keys)
+	slog.InfoContext(ctx, "jwt keys reloaded", "signing_key_id", keys.SigningKeyID())
+}
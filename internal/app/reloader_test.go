@@ -0,0 +1,240 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auth"
+)
+
+const (
+	testDenyPolicy = `package authz
+
+default allow := false
+`
+	testAllowPolicy = `package authz
+
+default allow := true
+`
+)
+
+type testReloader struct {
+	reloader   *reloader
+	configPath string
+	policyPath string
+	// jwtOptions yaml of server.auth.jwt
+	jwtOptions string
+	token      *auth.Token
+	rego       *auth.Rego
+}
+
+// writeTestJWTKey write PEM encoded RSA key pair to dir, paths of private and public keys are returned
+func writeTestJWTKey(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	public, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	require.NoError(t, err)
+
+	privatePath := filepath.Join(dir, "jwt.key")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(private),
+	}), 0o600))
+
+	publicPath := filepath.Join(dir, "jwt.pub")
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: public,
+	}), 0o600))
+
+	return privatePath, publicPath
+}
+
+func newTestReloader(t *testing.T, policyPath string) *testReloader {
+	t.Helper()
+
+	dir := t.TempDir()
+	if policyPath == "" {
+		policyPath = filepath.Join(dir, "authz.rego")
+		require.NoError(t, os.WriteFile(policyPath, []byte(testDenyPolicy), 0o600))
+	}
+
+	privateKeyPath, publicKeyPath := writeTestJWTKey(t, dir)
+	jwtOptions := "jwt:\n      private_key_file: " + privateKeyPath + "\n      public_key_file: " + publicKeyPath
+
+	configPath := filepath.Join(dir, "config.yaml")
+	writeTestReloaderConfig(t, configPath, policyPath, "old", jwtOptions)
+
+	conf, err := loadConfig(configPath)
+	require.NoError(t, err)
+
+	jwtAuth, err := newJWTAuth(conf)
+	require.NoError(t, err)
+
+	r := &testReloader{
+		configPath: configPath,
+		policyPath: policyPath,
+		jwtOptions: jwtOptions,
+		token:      auth.NewToken(convertTokensToAuth(conf.Server.Auth.Tokens)),
+		rego:       newTestRegoAuth(t, testDenyPolicy),
+	}
+
+	r.reloader = newReloader(configPath, conf, jwtAuth, r.token, r.rego)
+
+	return r
+}
+
+func newTestRegoAuth(t *testing.T, policy string) *auth.Rego {
+	t.Helper()
+
+	policyPath := filepath.Join(t.TempDir(), "authz.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(policy), 0o600))
+
+	query, err := auth.PrepareRego(context.Background(), "data.authz.allow", policyPath)
+	require.NoError(t, err)
+
+	return auth.NewRego(query)
+}
+
+func writeTestReloaderConfig(t *testing.T, configPath, policyPath, token, jwtOptions string) {
+	t.Helper()
+
+	data := `
+server:
+  auth:
+    ` + jwtOptions + `
+    tokens:
+      ` + token + `:
+        username: ci
+  authorizer:
+    kind: rego
+    rego:
+      query: data.authz.allow
+      policy_path: ` + policyPath + `
+storage:
+  dsn: postgres://rtc@localhost/rtc
+values_storage:
+  endpoints: [localhost:2379]
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(data), 0o600))
+}
+
+func Test_reloader_reload_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReloader(t, "")
+
+	writeTestReloaderConfig(t, r.configPath, r.policyPath, "new", r.jwtOptions)
+	require.NoError(t, os.WriteFile(r.policyPath, []byte(testAllowPolicy), 0o600))
+
+	r.reloader.reload(context.Background())
+
+	_, err := r.token.Authenticate(context.Background(), "old")
+	require.ErrorIs(t, err, auth.ErrAuthFailed)
+
+	payload, err := r.token.Authenticate(context.Background(), "new")
+	require.NoError(t, err)
+	require.Equal(t, "ci", payload.Username)
+
+	require.NoError(t, r.rego.Authorize(context.Background(), map[string]any{}))
+}
+
+func Test_reloader_reload_InvalidPolicy_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReloader(t, "")
+
+	writeTestReloaderConfig(t, r.configPath, r.policyPath, "new", r.jwtOptions)
+	require.NoError(t, os.WriteFile(r.policyPath, []byte("package authz\n\nallow := {"), 0o600))
+
+	r.reloader.reload(context.Background())
+
+	// tokens are reloaded, previous policy is kept
+	_, err := r.token.Authenticate(context.Background(), "new")
+	require.NoError(t, err)
+
+	require.Error(t, r.rego.Authorize(context.Background(), map[string]any{}))
+}
+
+func Test_reloader_reload_InvalidConfig_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReloader(t, "")
+
+	require.NoError(t, os.WriteFile(r.configPath, []byte("server: ["), 0o600))
+
+	r.reloader.reload(context.Background())
+
+	_, err := r.token.Authenticate(context.Background(), "old")
+	require.NoError(t, err)
+}
+
+func Test_reloader_reload_InvalidOptions_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReloader(t, "")
+
+	// config is parsed but fails validation, e.g. jwt keys are removed
+	writeTestReloaderConfig(t, r.configPath, r.policyPath, "new", "")
+	require.NoError(t, os.WriteFile(r.policyPath, []byte(testAllowPolicy), 0o600))
+
+	r.reloader.reload(context.Background())
+
+	_, err := r.token.Authenticate(context.Background(), "old")
+	require.NoError(t, err)
+
+	_, err = r.token.Authenticate(context.Background(), "new")
+	require.ErrorIs(t, err, auth.ErrAuthFailed)
+
+	require.Error(t, r.rego.Authorize(context.Background(), map[string]any{}))
+}
+
+func Test_reloader_Run_PolicyChanged_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReloader(t, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.reloader.Run(ctx)
+	}()
+
+	// give watcher time to start
+	time.Sleep(time.Millisecond * 200)
+	require.NoError(t, os.WriteFile(r.policyPath, []byte(testAllowPolicy), 0o600))
+
+	require.Eventually(t, func() bool {
+		return r.rego.Authorize(context.Background(), map[string]any{}) == nil
+	}, reloadDebounce*5, time.Millisecond*100)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func Test_reloader_Run_WatchPolicyError_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReloader(t, filepath.Join(t.TempDir(), "missing", "authz.rego"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	// watcher error is logged, reloader keeps running until context is done
+	require.NoError(t, r.reloader.Run(ctx))
+	require.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
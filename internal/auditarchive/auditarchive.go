@@ -0,0 +1,209 @@
+// Package auditarchive gzip compressed JSON lines archives of audit records.
+package auditarchive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+const (
+	// MaxRecords in single archive
+	MaxRecords = 100_000
+
+	// maxLineSize of single record
+	maxLineSize = 16 * 1024 * 1024
+	// maxSize of decompressed archive
+	maxSize = 1024 * 1024 * 1024
+)
+
+// ErrTooLarge archive exceeds records or size limit
+var ErrTooLarge = errors.New("archive is too large")
+
+type record struct {
+	ID       uint64          `json:"id"`
+	Action   string          `json:"action"`
+	Actor    string          `json:"actor"`
+	Payload  json.RawMessage `json:"payload"`
+	PrevHash string          `json:"prev_hash,omitempty"`
+	Hash     string          `json:"hash,omitempty"`
+	Ts       time.Time       `json:"ts"`
+}
+
+// Writer ...
+type Writer struct {
+	gz      *gzip.Writer
+	digest  hash.Hash
+	encoder *json.Encoder
+}
+
+// NewWriter Close must be called to flush archive
+func NewWriter(w io.Writer) *Writer {
+	gz := gzip.NewWriter(w)
+	digest := sha256.New()
+
+	return &Writer{
+		gz:      gz,
+		digest:  digest,
+		encoder: json.NewEncoder(io.MultiWriter(gz, digest)),
+	}
+}
+
+// Write ...
+func (w *Writer) Write(r *models.AuditRecord) error {
+	if err := w.encoder.Encode(newRecord(r)); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+
+	return nil
+}
+
+// Digest SHA-256 of written records, equals Digest of records read from archive
+func (w *Writer) Digest() []byte {
+	return w.digest.Sum(nil)
+}
+
+// Close does not close underlying writer
+func (w *Writer) Close() error {
+	return w.gz.Close() // nolint:wrapcheck
+}
+
+// Reader ...
+type Reader struct {
+	gz      *gzip.Reader
+	limited *io.LimitedReader
+	scanner *bufio.Scanner
+	records int
+}
+
+// NewReader ...
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("gzip.NewReader: %w", err)
+	}
+
+	// one byte over limit tells truncated archive from archive of exact size
+	limited := &io.LimitedReader{R: gz, N: maxSize + 1}
+
+	scanner := bufio.NewScanner(limited)
+	scanner.Buffer(nil, maxLineSize)
+
+	return &Reader{
+		gz:      gz,
+		limited: limited,
+		scanner: scanner,
+	}, nil
+}
+
+// Read returns io.EOF at the end of archive
+func (r *Reader) Read() (*models.AuditRecord, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return nil, fmt.Errorf("scanner.Scan: %w", err)
+		}
+
+		if r.limited.N == 0 {
+			return nil, fmt.Errorf("%w: more than %d bytes", ErrTooLarge, maxSize)
+		}
+
+		return nil, io.EOF
+	}
+
+	r.records++
+	if r.records > MaxRecords {
+		return nil, fmt.Errorf("%w: more than %d records", ErrTooLarge, MaxRecords)
+	}
+
+	var rec record
+	if err := json.Unmarshal(r.scanner.Bytes(), &rec); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	prevHash, err := decodeHash(rec.PrevHash)
+	if err != nil {
+		return nil, fmt.Errorf("prev_hash of record %d: %w", rec.ID, err)
+	}
+
+	hash, err := decodeHash(rec.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("hash of record %d: %w", rec.ID, err)
+	}
+
+	return &models.AuditRecord{
+		ID:       rec.ID,
+		Action:   rec.Action,
+		Actor:    rec.Actor,
+		Payload:  rec.Payload,
+		PrevHash: prevHash,
+		Hash:     hash,
+		Ts:       rec.Ts,
+	}, nil
+}
+
+// ReadAll ...
+func (r *Reader) ReadAll() ([]*models.AuditRecord, error) {
+	var records []*models.AuditRecord
+
+	for {
+		rec, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+
+			return nil, err
+		}
+
+		records = append(records, rec)
+	}
+}
+
+// Close does not close underlying reader
+func (r *Reader) Close() error {
+	return r.gz.Close() // nolint:wrapcheck
+}
+
+// Digest SHA-256 of records encoded as in archive, verifies records without hash
+func Digest(records []*models.AuditRecord) ([]byte, error) {
+	digest := sha256.New()
+	encoder := json.NewEncoder(digest)
+
+	for _, r := range records {
+		if err := encoder.Encode(newRecord(r)); err != nil {
+			return nil, fmt.Errorf("encoder.Encode: %w", err)
+		}
+	}
+
+	return digest.Sum(nil), nil
+}
+
+func newRecord(r *models.AuditRecord) record {
+	return record{
+		ID:       r.ID,
+		Action:   r.Action,
+		Actor:    r.Actor,
+		Payload:  r.Payload,
+		PrevHash: hex.EncodeToString(r.PrevHash),
+		Hash:     hex.EncodeToString(r.Hash),
+		Ts:       r.Ts,
+	}
+}
+
+// decodeHash empty string is nil hash of legacy records
+func decodeHash(value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	return hex.DecodeString(value) // nolint:wrapcheck
+}
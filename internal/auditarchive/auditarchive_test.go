@@ -0,0 +1,85 @@
+package auditarchive
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+func Test_WriteRead_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	records := []*models.AuditRecord{
+		{
+			ID:      1,
+			Action:  "project_created",
+			Actor:   "admin",
+			Payload: []byte(`{"project":"example"}`),
+			Ts:      time.Date(2025, 10, 29, 10, 0, 0, 123456000, time.UTC),
+		},
+		{
+			ID:       2,
+			Action:   "project_deleted",
+			Actor:    "admin",
+			Payload:  []byte(`{"project":"example"}`),
+			PrevHash: []byte{0x01},
+			Hash:     []byte{0x02},
+			Ts:       time.Date(2025, 10, 29, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+	for _, r := range records {
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+
+	got, err := r.ReadAll()
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, records, got)
+
+	digest, err := Digest(got)
+	require.NoError(t, err)
+	require.Equal(t, w.Digest(), digest)
+
+	got[0].Actor = "intruder"
+
+	digest, err = Digest(got)
+	require.NoError(t, err)
+	require.NotEqual(t, w.Digest(), digest)
+}
+
+func Test_NewReader_NotGzip_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewReader(bytes.NewReader([]byte(`{"id":1}`)))
+	require.Error(t, err)
+}
+
+func Test_ReadAll_TooManyRecords_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w := NewWriter(&buf)
+	for i := range MaxRecords + 1 {
+		require.NoError(t, w.Write(&models.AuditRecord{ID: uint64(i + 1), Payload: []byte(`{}`)})) // nolint:gosec
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(&buf)
+	require.NoError(t, err)
+
+	_, err = r.ReadAll()
+	require.ErrorIs(t, err, ErrTooLarge)
+}
@@ -0,0 +1,58 @@
+// Package auditchain hash chain of audit log records.
+//
+// Every record stores hash of its content and hash of the previous record,
+// so modified, deleted or reordered records break the chain.
+package auditchain
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"time"
+)
+
+const checkpointPrefix = "rtc-audit-checkpoint:v1:"
+
+// Hash of record content chained with hash of previous record, prevHash is nil for first record
+func Hash(prevHash []byte, id uint64, action, actor string, payload []byte, ts time.Time) []byte {
+	h := sha256.New()
+
+	writeField(h, prevHash)
+	writeUint64(h, id)
+	writeField(h, []byte(action))
+	writeField(h, []byte(actor))
+	writeField(h, payload)
+	writeUint64(h, uint64(ts.UnixMicro())) // nolint:gosec
+
+	return h.Sum(nil)
+}
+
+// CheckpointMessage signed content of checkpoint
+func CheckpointMessage(lastID uint64, hash []byte) []byte {
+	message := make([]byte, 0, len(checkpointPrefix)+8+len(hash))
+	message = append(message, checkpointPrefix...)
+	message = binary.BigEndian.AppendUint64(message, lastID)
+
+	return append(message, hash...)
+}
+
+// SignCheckpoint ...
+func SignCheckpoint(key ed25519.PrivateKey, lastID uint64, hash []byte) []byte {
+	return ed25519.Sign(key, CheckpointMessage(lastID, hash))
+}
+
+// VerifyCheckpoint ...
+func VerifyCheckpoint(key ed25519.PublicKey, lastID uint64, hash, signature []byte) bool {
+	return ed25519.Verify(key, CheckpointMessage(lastID, hash), signature)
+}
+
+// writeField length prefix makes fields boundaries unambiguous
+func writeField(h hash.Hash, data []byte) {
+	writeUint64(h, uint64(len(data)))
+	h.Write(data)
+}
+
+func writeUint64(h hash.Hash, value uint64) {
+	h.Write(binary.BigEndian.AppendUint64(nil, value))
+}
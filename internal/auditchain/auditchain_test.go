@@ -0,0 +1,52 @@
+package auditchain
+
+import (
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Hash_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2025, 10, 27, 10, 0, 0, 123456000, time.UTC)
+	payload := []byte(`{"project": "example"}`)
+
+	hash := Hash(nil, 1, "project_created", "admin", payload, ts)
+	require.Len(t, hash, 32)
+	require.Equal(t, hash, Hash(nil, 1, "project_created", "admin", payload, ts))
+
+	require.NotEqual(t, hash, Hash([]byte{1}, 1, "project_created", "admin", payload, ts))
+	require.NotEqual(t, hash, Hash(nil, 2, "project_created", "admin", payload, ts))
+	require.NotEqual(t, hash, Hash(nil, 1, "project_deleted", "admin", payload, ts))
+	require.NotEqual(t, hash, Hash(nil, 1, "project_created", "root", payload, ts))
+	require.NotEqual(t, hash, Hash(nil, 1, "project_created", "admin", []byte(`{}`), ts))
+	require.NotEqual(t, hash, Hash(nil, 1, "project_created", "admin", payload, ts.Add(time.Microsecond)))
+}
+
+func Test_Hash_FieldsBoundaries_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2025, 10, 27, 10, 0, 0, 0, time.UTC)
+
+	require.NotEqual(t,
+		Hash(nil, 1, "ab", "c", nil, ts),
+		Hash(nil, 1, "a", "bc", nil, ts),
+	)
+}
+
+func Test_VerifyCheckpoint_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	hash := Hash(nil, 1, "project_created", "admin", nil, time.Now())
+	signature := SignCheckpoint(privateKey, 1, hash)
+
+	require.True(t, VerifyCheckpoint(publicKey, 1, hash, signature))
+	require.False(t, VerifyCheckpoint(publicKey, 2, hash, signature))
+	require.False(t, VerifyCheckpoint(publicKey, 1, hash[1:], signature))
+}
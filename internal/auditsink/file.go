@@ -0,0 +1,60 @@
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+// File appends records as JSON lines, file is reopened on every batch to support rotation
+type File struct {
+	name string
+	path string
+}
+
+// NewFile ...
+func NewFile(name, path string) *File {
+	return &File{
+		name: name,
+		path: path,
+	}
+}
+
+// Name ...
+func (f *File) Name() string {
+	return f.name
+}
+
+// Send ...
+func (f *File) Send(_ context.Context, records []*models.AuditRecord) error {
+	var buf bytes.Buffer
+
+	for _, r := range records {
+		data, err := encodeRecord(r)
+		if err != nil {
+			return fmt.Errorf("encodeRecord: %w", err)
+		}
+
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("file.Write: %w", err)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("file.Sync: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package auditsink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+func newTestRecord(t *testing.T, id uint64) *models.AuditRecord {
+	t.Helper()
+
+	return &models.AuditRecord{
+		ID:      id,
+		Action:  "project_created",
+		Actor:   "admin",
+		Payload: []byte(`{"project": "example", "request": {"id": "req-1", "ip": "10.0.0.1"}}`),
+		Hash:    []byte{0xab, 0xcd},
+		Ts:      time.Date(2025, 10, 28, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func Test_File_Send_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink := NewFile("file", path)
+
+	require.NoError(t, sink.Send(context.Background(), []*models.AuditRecord{newTestRecord(t, 1)}))
+	require.NoError(t, sink.Send(context.Background(), []*models.AuditRecord{newTestRecord(t, 2)}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"id":1,"action":"project_created","actor":"admin","payload":{"project":"example","request":{"id":"req-1","ip":"10.0.0.1"}},"hash":"abcd","ts":"2025-10-28T10:00:00Z"}`+"\n"+
+			`{"id":2,"action":"project_created","actor":"admin","payload":{"project":"example","request":{"id":"req-1","ip":"10.0.0.1"}},"hash":"abcd","ts":"2025-10-28T10:00:00Z"}`+"\n",
+		string(data),
+	)
+}
@@ -0,0 +1,40 @@
+// Package auditsink exports audit records to external systems e.g. SIEM.
+package auditsink
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+type record struct {
+	ID      uint64          `json:"id"`
+	Action  string          `json:"action"`
+	Actor   string          `json:"actor"`
+	Payload json.RawMessage `json:"payload"`
+	Hash    string          `json:"hash,omitempty"`
+	Ts      time.Time       `json:"ts"`
+}
+
+func convertRecord(r *models.AuditRecord) record {
+	return record{
+		ID:      r.ID,
+		Action:  r.Action,
+		Actor:   r.Actor,
+		Payload: r.Payload,
+		Hash:    hex.EncodeToString(r.Hash),
+		Ts:      r.Ts,
+	}
+}
+
+func encodeRecord(r *models.AuditRecord) ([]byte, error) {
+	data, err := json.Marshal(convertRecord(r))
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,206 @@
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+const (
+	defaultSyslogTimeout  = time.Second * 10
+	defaultSyslogAppName  = "rtc"
+	defaultSyslogFacility = "local0"
+
+	syslogSeverityInfo = 6
+
+	// syslogSDID structured data id, 32473 is enterprise number reserved for documentation by RFC 5612
+	syslogSDID = "rtc@32473"
+
+	syslogTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+var syslogFacilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"authpriv": 10,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+// SyslogOptions ...
+type SyslogOptions struct {
+	// Network udp, tcp or tls
+	Network string
+	Address string
+	// Facility name e.g. local0 or auth
+	Facility string
+	AppName  string
+	Timeout  time.Duration
+}
+
+// Syslog sends records as RFC 5424 messages, stream transports use octet counting framing of RFC 6587
+type Syslog struct {
+	name     string
+	options  SyslogOptions
+	facility int
+	hostname string
+}
+
+// ValidSyslogFacility ...
+func ValidSyslogFacility(facility string) bool {
+	_, ok := syslogFacilities[facility]
+	return ok
+}
+
+// NewSyslog ...
+func NewSyslog(name string, options SyslogOptions) (*Syslog, error) {
+	if options.Facility == "" {
+		options.Facility = defaultSyslogFacility
+	}
+
+	facility, ok := syslogFacilities[options.Facility]
+	if !ok {
+		return nil, fmt.Errorf("unknown facility %q", options.Facility)
+	}
+
+	switch options.Network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, fmt.Errorf("unknown network %q", options.Network)
+	}
+
+	if options.AppName == "" {
+		options.AppName = defaultSyslogAppName
+	}
+
+	if options.Timeout == 0 {
+		options.Timeout = defaultSyslogTimeout
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "-"
+	}
+
+	return &Syslog{
+		name:     name,
+		options:  options,
+		facility: facility,
+		hostname: hostname,
+	}, nil
+}
+
+// Name ...
+func (s *Syslog) Name() string {
+	return s.name
+}
+
+// Send connection is opened for every batch
+func (s *Syslog) Send(ctx context.Context, records []*models.AuditRecord) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(s.options.Timeout)); err != nil {
+		return fmt.Errorf("conn.SetWriteDeadline: %w", err)
+	}
+
+	for _, r := range records {
+		message, err := s.format(r)
+		if err != nil {
+			return fmt.Errorf("format: %w", err)
+		}
+
+		if s.options.Network != "udp" {
+			message = append([]byte(strconv.Itoa(len(message))+" "), message...)
+		}
+
+		if _, err := conn.Write(message); err != nil {
+			return fmt.Errorf("conn.Write: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Syslog) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.options.Timeout}
+
+	if s.options.Network == "tls" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer}
+
+		return tlsDialer.DialContext(ctx, "tcp", s.options.Address) // nolint:wrapcheck
+	}
+
+	return dialer.DialContext(ctx, s.options.Network, s.options.Address) // nolint:wrapcheck
+}
+
+// format <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD] MSG
+func (s *Syslog) format(r *models.AuditRecord) ([]byte, error) {
+	data, err := encodeRecord(r)
+	if err != nil {
+		return nil, fmt.Errorf("encodeRecord: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "<%d>1 %s %s %s %d %s [%s id=\"%d\" actor=\"%s\"] ",
+		s.facility*8+syslogSeverityInfo,
+		r.Ts.UTC().Format(syslogTimeFormat),
+		s.hostname,
+		s.options.AppName,
+		os.Getpid(),
+		syslogMsgID(r.Action),
+		syslogSDID,
+		r.ID,
+		escapeSDParam(r.Actor),
+	)
+
+	buf.Write(data)
+
+	return buf.Bytes(), nil
+}
+
+// syslogMsgID printable ascii up to 32 chars
+func syslogMsgID(action string) string {
+	if action == "" {
+		return "-"
+	}
+
+	if len(action) > 32 {
+		action = action[:32]
+	}
+
+	return strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+
+		return r
+	}, action)
+}
+
+var sdParamReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+func escapeSDParam(value string) string {
+	return sdParamReplacer.Replace(value)
+}
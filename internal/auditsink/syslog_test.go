@@ -0,0 +1,85 @@
+package auditsink
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+func Test_Syslog_Format_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	sink, err := NewSyslog("syslog", SyslogOptions{Network: "udp", Address: "127.0.0.1:514", Facility: "auth"})
+	require.NoError(t, err)
+
+	sink.hostname = "rtc-1"
+
+	r := newTestRecord(t, 42)
+	r.Actor = `ad"min]`
+
+	message, err := sink.format(r)
+	require.NoError(t, err)
+
+	expected := "<38>1 2025-10-28T10:00:00.000000Z rtc-1 rtc " + strconv.Itoa(os.Getpid()) +
+		` project_created [rtc@32473 id="42" actor="ad\"min\]"] {"id":42,`
+	require.True(t, strings.HasPrefix(string(message), expected), string(message))
+}
+
+func Test_NewSyslog_UnknownFacility_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSyslog("syslog", SyslogOptions{Network: "udp", Address: "127.0.0.1:514", Facility: "local9"})
+	require.EqualError(t, err, `unknown facility "local9"`)
+}
+
+func Test_Syslog_Send_TCP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+
+		length, err := reader.ReadString(' ')
+		if err != nil {
+			return
+		}
+
+		size, _ := strconv.Atoi(strings.TrimSpace(length))
+		message := make([]byte, size)
+
+		if _, err := io.ReadFull(reader, message); err != nil {
+			return
+		}
+
+		received <- string(message)
+	}()
+
+	sink, err := NewSyslog("syslog", SyslogOptions{Network: "tcp", Address: listener.Addr().String()})
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), []*models.AuditRecord{newTestRecord(t, 1)}))
+
+	message := <-received
+	require.True(t, strings.HasPrefix(message, "<134>1 "), message)
+	require.True(t, strings.HasSuffix(message, `"ts":"2025-10-28T10:00:00Z"}`), message)
+}
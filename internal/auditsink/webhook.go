@@ -0,0 +1,89 @@
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+const defaultWebhookTimeout = time.Second * 10
+
+// WebhookOptions ...
+type WebhookOptions struct {
+	URL string
+	// Headers e.g. Authorization
+	Headers map[string]string
+	Timeout time.Duration
+}
+
+// Webhook posts batch of records as JSON array, any 2xx status is success
+type Webhook struct {
+	name    string
+	options WebhookOptions
+	client  *http.Client
+}
+
+// NewWebhook ...
+func NewWebhook(name string, options WebhookOptions) *Webhook {
+	if options.Timeout == 0 {
+		options.Timeout = defaultWebhookTimeout
+	}
+
+	return &Webhook{
+		name:    name,
+		options: options,
+		client: &http.Client{
+			Timeout: options.Timeout,
+		},
+	}
+}
+
+// Name ...
+func (w *Webhook) Name() string {
+	return w.name
+}
+
+// Send ...
+func (w *Webhook) Send(ctx context.Context, records []*models.AuditRecord) error {
+	payload := make([]record, 0, len(records))
+	for _, r := range records {
+		payload = append(payload, convertRecord(r))
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for key, value := range w.options.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drain body to reuse connection
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package auditsink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+func Test_Webhook_Send_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	var received []record
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhook("siem", WebhookOptions{
+		URL:     srv.URL,
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	})
+
+	err := sink.Send(context.Background(), []*models.AuditRecord{newTestRecord(t, 1), newTestRecord(t, 2)})
+	require.NoError(t, err)
+	require.Len(t, received, 2)
+	require.Equal(t, uint64(2), received[1].ID)
+}
+
+func Test_Webhook_Send_Status_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhook("siem", WebhookOptions{URL: srv.URL})
+
+	err := sink.Send(context.Background(), []*models.AuditRecord{newTestRecord(t, 1)})
+	require.EqualError(t, err, "unexpected status code 503")
+}
@@ -4,10 +4,34 @@ import "context"
 
 // Authenticator ...
 type Authenticator interface {
-	Authenticate(token string) (*Payload, error)
+	Authenticate(ctx context.Context, token string) (*Payload, error)
+}
+
+// AuthenticatorFunc adapter to use ordinary functions as Authenticator
+type AuthenticatorFunc func(ctx context.Context, token string) (*Payload, error)
+
+// Authenticate ...
+func (f AuthenticatorFunc) Authenticate(ctx context.Context, token string) (*Payload, error) {
+	return f(ctx, token)
 }
 
 // Authorizer ...
 type Authorizer interface {
 	Authorize(ctx context.Context, input map[string]any) error
 }
+
+// InputInspector authorizer which reports input fields it uses,
+// expensive fields are added to input only if used
+type InputInspector interface {
+	UsesInput(field string) bool
+}
+
+// UsesInput authorizers without InputInspector use any field
+func UsesInput(authorizer Authorizer, field string) bool {
+	inspector, ok := authorizer.(InputInspector)
+	if !ok {
+		return true
+	}
+
+	return inspector.UsesInput(field)
+}
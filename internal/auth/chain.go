@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"errors"
+)
+
+// Chain tries authenticators in order and returns first success
+type Chain struct {
+	authenticators []Authenticator
+}
+
+// NewChain ...
+func NewChain(authenticators ...Authenticator) *Chain {
+	return &Chain{
+		authenticators: authenticators,
+	}
+}
+
+// Authenticate ...
+func (c *Chain) Authenticate(ctx context.Context, token string) (*Payload, error) {
+	allErrors := make([]error, 0, len(c.authenticators))
+
+	for _, authenticator := range c.authenticators {
+		payload, err := authenticator.Authenticate(ctx, token)
+		if err == nil {
+			return payload, nil
+		}
+
+		allErrors = append(allErrors, err)
+	}
+
+	return nil, errors.Join(ErrAuthFailed, errors.Join(allErrors...))
+}
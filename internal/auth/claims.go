@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// RoleRule grants roles if claim contains value
+// Claim supports nested objects via dots e.g. realm_access.roles
+type RoleRule struct {
+	Claim string
+	Value string
+	Roles []string
+}
+
+// ClaimsMapper converts external identity claims to payload
+type ClaimsMapper struct {
+	UsernameClaim string
+	Rules         []RoleRule
+	DefaultRoles  []string
+}
+
+// Map ...
+func (m *ClaimsMapper) Map(claims map[string]any) (*Payload, error) {
+	username, ok := claimValue(claims, m.UsernameClaim).(string)
+	if !ok || username == "" {
+		return nil, fmt.Errorf("%w: claim %q is empty", ErrAuthFailed, m.UsernameClaim)
+	}
+
+	roles := slices.Clone(m.DefaultRoles)
+
+	for _, rule := range m.Rules {
+		if !claimContains(claimValue(claims, rule.Claim), rule.Value) {
+			continue
+		}
+
+		roles = append(roles, rule.Roles...)
+	}
+
+	slices.Sort(roles)
+
+	return &Payload{
+		Username: username,
+		Roles:    slices.Compact(roles),
+	}, nil
+}
+
+func claimValue(claims map[string]any, path string) any {
+	var current any = claims
+
+	for part := range strings.SplitSeq(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		current = obj[part]
+	}
+
+	return current
+}
+
+func claimContains(claim any, value string) bool {
+	switch v := claim.(type) {
+	case string:
+		return v == value
+	case bool:
+		return fmt.Sprint(v) == value
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"regexp"
+)
+
+// Client certificate fields for mapping rules
+const (
+	CertFieldSubject    = "subject"
+	CertFieldCommonName = "common_name"
+	CertFieldDNSSAN     = "dns_san"
+	CertFieldURISAN     = "uri_san"
+	CertFieldEmailSAN   = "email_san"
+)
+
+// CertRule maps certificate field matching pattern to username and roles.
+// Username and roles are templates with pattern groups e.g. $1 or ${name}.
+type CertRule struct {
+	Field    string
+	Pattern  *regexp.Regexp
+	Username string
+	Roles    []string
+}
+
+// ClientCert authenticate by verified TLS client certificate, first matching rule is used
+type ClientCert struct {
+	rules []CertRule
+}
+
+// NewClientCert ...
+func NewClientCert(rules []CertRule) *ClientCert {
+	return &ClientCert{
+		rules: rules,
+	}
+}
+
+type ctxKeyCertificate struct{}
+
+// CertificateToContext verified client certificate of request
+func CertificateToContext(ctx context.Context, cert *x509.Certificate) context.Context {
+	return context.WithValue(ctx, ctxKeyCertificate{}, cert)
+}
+
+func certificateFromContext(ctx context.Context) *x509.Certificate {
+	cert, ok := ctx.Value(ctxKeyCertificate{}).(*x509.Certificate)
+	if !ok {
+		return nil
+	}
+
+	return cert
+}
+
+// Authenticate token is not used, certificate is taken from context
+func (c *ClientCert) Authenticate(ctx context.Context, _ string) (*Payload, error) {
+	cert := certificateFromContext(ctx)
+	if cert == nil {
+		return nil, fmt.Errorf("%w: client certificate is missing", ErrAuthFailed)
+	}
+
+	for _, rule := range c.rules {
+		for _, value := range certFieldValues(cert, rule.Field) {
+			match := rule.Pattern.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+
+			payload := &Payload{
+				Username: expandTemplate(rule.Pattern, rule.Username, value, match),
+				Roles:    make([]string, 0, len(rule.Roles)),
+			}
+
+			if payload.Username == "" {
+				return nil, fmt.Errorf("%w: empty username for %s %q", ErrAuthFailed, rule.Field, value)
+			}
+
+			for _, role := range rule.Roles {
+				payload.Roles = append(payload.Roles, expandTemplate(rule.Pattern, role, value, match))
+			}
+
+			return payload, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no rule matches certificate %q", ErrAuthFailed, cert.Subject.String())
+}
+
+// ValidCertField ...
+func ValidCertField(field string) bool {
+	switch field {
+	case CertFieldSubject, CertFieldCommonName, CertFieldDNSSAN, CertFieldURISAN, CertFieldEmailSAN:
+		return true
+	default:
+		return false
+	}
+}
+
+func certFieldValues(cert *x509.Certificate, field string) []string {
+	switch field {
+	case CertFieldSubject:
+		return []string{cert.Subject.String()}
+	case CertFieldCommonName:
+		return []string{cert.Subject.CommonName}
+	case CertFieldDNSSAN:
+		return cert.DNSNames
+	case CertFieldURISAN:
+		values := make([]string, 0, len(cert.URIs))
+		for _, uri := range cert.URIs {
+			values = append(values, uri.String())
+		}
+
+		return values
+	case CertFieldEmailSAN:
+		return cert.EmailAddresses
+	default:
+		return nil
+	}
+}
+
+func expandTemplate(pattern *regexp.Regexp, template, value string, match []int) string {
+	return string(pattern.ExpandString(nil, template, value, match))
+}
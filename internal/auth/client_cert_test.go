@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClientCert() *ClientCert {
+	return NewClientCert([]CertRule{
+		{
+			Field:    CertFieldURISAN,
+			Pattern:  regexp.MustCompile(`^spiffe://cluster\.local/ns/(?P<ns>[^/]+)/sa/(?P<sa>[^/]+)$`),
+			Username: "${ns}-${sa}",
+			Roles:    []string{"service", "team-${ns}"},
+		},
+		{
+			Field:    CertFieldCommonName,
+			Pattern:  regexp.MustCompile(`^[a-z]+\.mesh\.local$`),
+			Username: "$0",
+		},
+	})
+}
+
+func Test_ClientCert_Authenticate_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	uri, err := url.Parse("spiffe://cluster.local/ns/billing/sa/deployer")
+	require.NoError(t, err)
+
+	ctx := CertificateToContext(context.Background(), &x509.Certificate{
+		Subject: pkix.Name{CommonName: "billing.mesh.local"},
+		URIs:    []*url.URL{uri},
+	})
+
+	got, err := newTestClientCert().Authenticate(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, &Payload{Username: "billing-deployer", Roles: []string{"service", "team-billing"}}, got)
+
+	// second rule
+	ctx = CertificateToContext(context.Background(), &x509.Certificate{
+		Subject: pkix.Name{CommonName: "billing.mesh.local"},
+	})
+
+	got, err = newTestClientCert().Authenticate(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, "billing.mesh.local", got.Username)
+}
+
+func Test_ClientCert_Authenticate_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := newTestClientCert().Authenticate(context.Background(), "")
+	require.ErrorIs(t, err, ErrAuthFailed)
+
+	ctx := CertificateToContext(context.Background(), &x509.Certificate{
+		Subject: pkix.Name{CommonName: "unknown"},
+	})
+
+	_, err = newTestClientCert().Authenticate(ctx, "")
+	require.ErrorIs(t, err, ErrAuthFailed)
+}
@@ -5,4 +5,7 @@ import "errors"
 var (
 	// ErrAuthFailed ...
 	ErrAuthFailed = errors.New("auth failed")
+
+	// ErrForbidden ...
+	ErrForbidden = errors.New("forbidden")
 )
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ExplainMode level of decision explanation
+type ExplainMode string
+
+const (
+	// ExplainFull full evaluation trace
+	ExplainFull ExplainMode = "full"
+	// ExplainNotes only trace notes of policy e.g. trace(sprintf(...))
+	ExplainNotes ExplainMode = "notes"
+	// ExplainFails only failed expressions
+	ExplainFails ExplainMode = "fails"
+)
+
+// ParseExplainMode empty is full
+func ParseExplainMode(value string) (ExplainMode, error) {
+	switch mode := ExplainMode(value); mode {
+	case "":
+		return ExplainFull, nil
+	case ExplainFull, ExplainNotes, ExplainFails:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown explain mode %q", value)
+	}
+}
+
+// Decision result of authorization with explanation
+type Decision struct {
+	Allowed bool
+	// Reason why access is denied
+	Reason      string
+	Explanation []string
+}
+
+// Explainer authorizer able to explain decision
+type Explainer interface {
+	Explain(ctx context.Context, input map[string]any, mode ExplainMode) *Decision
+}
+
+// Explain evaluate authorizer, explanation is filled for authorizers implementing Explainer
+func Explain(ctx context.Context, authorizer Authorizer, input map[string]any, mode ExplainMode) *Decision {
+	if explainer, ok := authorizer.(Explainer); ok {
+		return explainer.Explain(ctx, input, mode)
+	}
+
+	if err := authorizer.Authorize(ctx, input); err != nil {
+		return &Decision{Reason: err.Error()}
+	}
+
+	return &Decision{Allowed: true}
+}
+
+func explanationLines(trace string) []string {
+	return strings.Split(strings.TrimRight(trace, "\n"), "\n")
+}
@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRego(t *testing.T, policy string) *Rego {
+	t.Helper()
+
+	policyPath := filepath.Join(t.TempDir(), "authz.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(policy), 0o600))
+
+	query, err := PrepareRego(context.Background(), "data.authz.allow", policyPath)
+	require.NoError(t, err)
+
+	return NewRego(query)
+}
+
+func Test_Rego_Update_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	authorizer := newTestRego(t, `package authz
+
+default allow := false
+`)
+	require.Error(t, authorizer.Authorize(context.Background(), map[string]any{}))
+
+	allowAll := newTestRego(t, `package authz
+
+default allow := true
+`)
+	authorizer.Update(allowAll.policy.Load())
+
+	require.NoError(t, authorizer.Authorize(context.Background(), map[string]any{}))
+}
+
+func Test_Explain_Rego_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	authorizer := newTestRego(t, `package authz
+
+default allow := false
+
+allow if {
+	input.action == "list_configs"
+}
+`)
+
+	decision := Explain(context.Background(), authorizer, map[string]any{"action": "list_configs"}, ExplainFull)
+	require.True(t, decision.Allowed)
+	require.NotEmpty(t, decision.Explanation)
+
+	decision = Explain(context.Background(), authorizer, map[string]any{"action": "delete_project"}, ExplainFull)
+	require.False(t, decision.Allowed)
+	require.Equal(t, "policy result not allowed", decision.Reason)
+	require.Contains(t, decision.Explanation[0], "Enter data.authz.allow")
+}
+
+func Test_Explain_Noop_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	decision := Explain(context.Background(), NewNoop(), map[string]any{}, ExplainFull)
+	require.Equal(t, &Decision{Allowed: true}, decision)
+}
+
+func Test_Rego_UsesInput_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	authorizer := newTestRego(t, `package authz
+
+default allow := false
+
+allow if input.membership.role == "admin"
+
+allow if {
+	input.action == "list_projects"
+	helper
+}
+
+helper if input.user.roles[_] == "viewer"
+`)
+
+	require.True(t, authorizer.UsesInput("membership"))
+	require.True(t, authorizer.UsesInput("user"))
+	require.False(t, authorizer.UsesInput("changes"))
+
+	// policy without membership is updated on reload
+	authorizer.Update(newTestRego(t, `package authz
+
+default allow := false
+
+allow if input.action == "list_projects"
+`).policy.Load())
+
+	require.False(t, authorizer.UsesInput("membership"))
+	require.True(t, UsesInput(authorizer, "action"))
+}
+
+func Test_Rego_UsesInput_DynamicKey_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	authorizer := newTestRego(t, `package authz
+
+default allow := false
+
+allow if input[input.action] == true
+`)
+
+	// dynamic key may reference any field
+	require.True(t, authorizer.UsesInput("membership"))
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// SigningKey RSA key identified by kid, key without private part only verifies tokens
+type SigningKey struct {
+	ID      string
+	Private *rsa.PrivateKey
+	Public  *rsa.PublicKey
+}
+
+// KeySet keys accepted for verification and the one used for signing
+type KeySet struct {
+	signing *SigningKey
+	keys    map[string]*SigningKey
+	ids     []string
+}
+
+// NewKeySet ...
+// Empty key id is replaced by RFC 7638 thumbprint of public key.
+func NewKeySet(signingKeyID string, keys ...*SigningKey) (*KeySet, error) {
+	set := &KeySet{
+		keys: make(map[string]*SigningKey, len(keys)),
+		ids:  make([]string, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		if key.Public == nil && key.Private != nil {
+			key.Public = &key.Private.PublicKey
+		}
+
+		if key.Public == nil {
+			return nil, fmt.Errorf("key %q: public key is required", key.ID)
+		}
+
+		if key.ID == "" {
+			key.ID = KeyThumbprint(key.Public)
+		}
+
+		if _, ok := set.keys[key.ID]; ok {
+			return nil, fmt.Errorf("key %q: duplicate key id", key.ID)
+		}
+
+		set.keys[key.ID] = key
+		set.ids = append(set.ids, key.ID)
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+
+	signing, ok := set.keys[signingKeyID]
+	if signingKeyID == "" {
+		signing, ok = keys[0], true
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("signing key %q not found", signingKeyID)
+	}
+
+	if signing.Private == nil {
+		return nil, fmt.Errorf("signing key %q: private key is required", signing.ID)
+	}
+
+	set.signing = signing
+
+	return set, nil
+}
+
+// SigningKeyID ...
+func (s *KeySet) SigningKeyID() string {
+	return s.signing.ID
+}
+
+// key returns verification key by id, signing key is used for tokens without kid
+func (s *KeySet) key(id string) (*SigningKey, bool) {
+	if id == "" {
+		return s.signing, true
+	}
+
+	key, ok := s.keys[id]
+
+	return key, ok
+}
+
+// JWK JSON Web Key (RFC 7517) of RSA public key
+type JWK struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// JWKS JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns public keys in order of configuration
+func (s *KeySet) JWKS() *JWKS {
+	jwks := &JWKS{
+		Keys: make([]JWK, 0, len(s.ids)),
+	}
+
+	for _, id := range s.ids {
+		key := s.keys[id]
+		jwks.Keys = append(jwks.Keys, JWK{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: "RS256",
+			KeyID:     id,
+			N:         encodeBigInt(key.Public.N),
+			E:         encodeBigInt(big.NewInt(int64(key.Public.E))),
+		})
+	}
+
+	return jwks
+}
+
+// KeyThumbprint RFC 7638 thumbprint of RSA public key
+func KeyThumbprint(public *rsa.PublicKey) string {
+	// members must be in lexicographic order without whitespaces
+	data, _ := json.Marshal(struct { // nolint:errchkjson
+		E       string `json:"e"`
+		KeyType string `json:"kty"`
+		N       string `json:"n"`
+	}{
+		E:       encodeBigInt(big.NewInt(int64(public.E))),
+		KeyType: "RSA",
+		N:       encodeBigInt(public.N),
+	})
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
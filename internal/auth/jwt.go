@@ -1,8 +1,9 @@
 package auth
 
 import (
-	"crypto/rsa"
+	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -10,25 +11,38 @@ import (
 
 // JWT ...
 type JWT struct {
-	private *rsa.PrivateKey
-	keyFunc jwt.Keyfunc
-	ttl     time.Duration
+	keys atomic.Pointer[KeySet]
+	ttl  time.Duration
 }
 
 // NewJWT ...
-func NewJWT(private *rsa.PrivateKey, public *rsa.PublicKey, ttl time.Duration) *JWT {
-	return &JWT{
-		private: private,
-		keyFunc: func(_ *jwt.Token) (any, error) {
-			return public, nil
-		},
+func NewJWT(keys *KeySet, ttl time.Duration) *JWT {
+	j := &JWT{
 		ttl: ttl,
 	}
+	j.Update(keys)
+
+	return j
+}
+
+// Update atomically replace keys, used for key rotation
+func (j *JWT) Update(keys *KeySet) {
+	j.keys.Store(keys)
+}
+
+// JWKS public keys for tokens verification
+func (j *JWT) JWKS() *JWKS {
+	return j.keys.Load().JWKS()
 }
 
 // Encode ...
 func (j *JWT) Encode(p *Payload) (string, error) {
-	signature, err := jwt.NewWithClaims(jwt.SigningMethodRS256, toClaims(p, j.ttl)).SignedString(j.private)
+	signing := j.keys.Load().signing
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, toClaims(p, j.ttl))
+	token.Header["kid"] = signing.ID
+
+	signature, err := token.SignedString(signing.Private)
 	if err != nil {
 		return "", fmt.Errorf("jwt.SignedString: %w", err)
 	}
@@ -36,19 +50,37 @@ func (j *JWT) Encode(p *Payload) (string, error) {
 	return signature, nil
 }
 
+// TTL access token lifetime
+func (j *JWT) TTL() time.Duration {
+	return j.ttl
+}
+
 // Decode ...
 func (j *JWT) Decode(token string) (*Payload, error) {
 	var customClaims claims
 
-	if _, err := jwt.ParseWithClaims(token, &customClaims, j.keyFunc); err != nil {
+	_, err := jwt.ParseWithClaims(token, &customClaims, j.keyFunc, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}))
+	if err != nil {
 		return nil, fmt.Errorf("jwt.ParseWithClaims: %w", err)
 	}
 
 	return fromClaims(customClaims), nil
 }
 
+// keyFunc select verification key by kid header
+func (j *JWT) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := j.keys.Load().key(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	return key.Public, nil
+}
+
 // Authenticate ...
-func (j *JWT) Authenticate(token string) (*Payload, error) {
+func (j *JWT) Authenticate(_ context.Context, token string) (*Payload, error) {
 	return j.Decode(token)
 }
 
@@ -72,8 +104,14 @@ func toClaims(p *Payload, ttl time.Duration) *claims {
 }
 
 func fromClaims(claims claims) *Payload {
-	return &Payload{
+	payload := &Payload{
 		Username: claims.Username,
 		Roles:    claims.Roles,
 	}
+
+	if claims.IssuedAt != nil {
+		payload.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return payload
 }
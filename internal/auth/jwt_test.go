@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigningKey(t *testing.T, id string) *SigningKey {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return &SigningKey{ID: id, Private: private}
+}
+
+func Test_JWT_Rotation_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	oldKey := newTestSigningKey(t, "old")
+	newKey := newTestSigningKey(t, "new")
+
+	keys, err := NewKeySet("old", oldKey)
+	require.NoError(t, err)
+
+	j := NewJWT(keys, time.Minute)
+
+	oldToken, err := j.Encode(&Payload{Username: "test"})
+	require.NoError(t, err)
+
+	// new key becomes active, old one is kept for verification
+	keys, err = NewKeySet("new", oldKey, newKey)
+	require.NoError(t, err)
+	j.Update(keys)
+
+	newToken, err := j.Encode(&Payload{Username: "test"})
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &claims{})
+	require.NoError(t, err)
+	require.Equal(t, "new", parsed.Header["kid"])
+
+	for _, token := range []string{oldToken, newToken} {
+		payload, err := j.Decode(token)
+		require.NoError(t, err)
+		require.Equal(t, "test", payload.Username)
+	}
+
+	// old key is retired
+	keys, err = NewKeySet("new", newKey)
+	require.NoError(t, err)
+	j.Update(keys)
+
+	_, err = j.Decode(oldToken)
+	require.Error(t, err)
+}
+
+func Test_JWT_Decode_WithoutKeyID_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	key := newTestSigningKey(t, "")
+
+	keys, err := NewKeySet("", key)
+	require.NoError(t, err)
+
+	// tokens issued before key ids were introduced
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, toClaims(&Payload{Username: "test"}, time.Minute)).
+		SignedString(key.Private)
+	require.NoError(t, err)
+
+	payload, err := NewJWT(keys, time.Minute).Decode(token)
+	require.NoError(t, err)
+	require.Equal(t, "test", payload.Username)
+}
+
+func Test_NewKeySet_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	key := newTestSigningKey(t, "key")
+
+	_, err := NewKeySet("unknown", key)
+	require.Error(t, err)
+
+	_, err = NewKeySet("key", key, &SigningKey{ID: "key", Public: &key.Private.PublicKey})
+	require.Error(t, err)
+
+	_, err = NewKeySet("public", key, &SigningKey{ID: "public", Public: &key.Private.PublicKey})
+	require.Error(t, err)
+}
+
+func Test_KeySet_JWKS_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	key := newTestSigningKey(t, "")
+
+	keys, err := NewKeySet("", key)
+	require.NoError(t, err)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 1)
+	require.Equal(t, KeyThumbprint(&key.Private.PublicKey), jwks.Keys[0].KeyID)
+	require.Equal(t, keys.SigningKeyID(), jwks.Keys[0].KeyID)
+	require.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	require.Equal(t, "AQAB", jwks.Keys[0].E)
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// LDAPOptions ...
+type LDAPOptions struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+	// BindDN and BindPassword service account used to find user entry
+	BindDN       string
+	BindPassword string
+	// UserDNTemplate direct user bind without search e.g. uid=%s,ou=people,dc=example,dc=org
+	UserDNTemplate string
+	UserBaseDN     string
+	// UserFilter e.g. (uid=%s) or (sAMAccountName=%s) for Active Directory
+	UserFilter string
+	// UsernameAttribute canonical username of user entry e.g. uid or sAMAccountName
+	UsernameAttribute string
+	GroupAttribute    string
+	// GroupRoles maps group DN or CN to roles, DN is matched exactly
+	// and CN matches groups with this CN in any branch of directory
+	GroupRoles   map[string][]string
+	DefaultRoles []string
+}
+
+type ldapConn interface {
+	Bind(username, password string) error
+	Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error)
+	StartTLS(config *tls.Config) error
+	Close() error
+}
+
+// LDAP authenticates users by bind to directory
+type LDAP struct {
+	options LDAPOptions
+	dial    func() (ldapConn, error)
+}
+
+// NewLDAP ...
+func NewLDAP(options LDAPOptions) *LDAP {
+	l := &LDAP{
+		options: options,
+	}
+
+	l.dial = func() (ldapConn, error) {
+		conn, err := ldap.DialURL(options.URL, ldap.DialWithDialer(&net.Dialer{Timeout: options.Timeout}), ldap.DialWithTLSConfig(l.tlsConfig()))
+		if err != nil {
+			return nil, err // nolint:wrapcheck
+		}
+
+		conn.SetTimeout(options.Timeout)
+
+		return conn, nil
+	}
+
+	return l
+}
+
+// AuthenticatePassword bind as user and map its groups to roles.
+// Username is taken from user entry, so case variants of login are the same user.
+// Returns ErrAuthFailed for invalid credentials.
+func (l *LDAP) AuthenticatePassword(_ context.Context, username, password string) (*Payload, error) {
+	// empty password is unauthenticated bind and always succeeds
+	if username == "" || password == "" {
+		return nil, ErrAuthFailed
+	}
+
+	conn, err := l.dial()
+	if err != nil {
+		return nil, fmt.Errorf("ldap.DialURL: %w", err)
+	}
+	defer conn.Close()
+
+	if l.options.StartTLS {
+		if err := conn.StartTLS(l.tlsConfig()); err != nil {
+			return nil, fmt.Errorf("conn.StartTLS: %w", err)
+		}
+	}
+
+	entry, err := l.bindUser(conn, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical := entry.GetAttributeValue(l.options.UsernameAttribute)
+	if canonical == "" {
+		return nil, fmt.Errorf("user entry %s has no %s attribute", entry.DN, l.options.UsernameAttribute)
+	}
+
+	return &Payload{
+		Username: canonical,
+		Roles:    l.mapGroups(entry.GetAttributeValues(l.options.GroupAttribute)),
+	}, nil
+}
+
+func (l *LDAP) bindUser(conn ldapConn, username, password string) (*ldap.Entry, error) {
+	if l.options.UserDNTemplate != "" {
+		userDN := fmt.Sprintf(l.options.UserDNTemplate, ldap.EscapeDN(username))
+
+		if err := conn.Bind(userDN, password); err != nil {
+			return nil, bindError(err)
+		}
+
+		return l.searchOne(conn, userDN, ldap.ScopeBaseObject, "(objectClass=*)")
+	}
+
+	if l.options.BindDN != "" {
+		if err := conn.Bind(l.options.BindDN, l.options.BindPassword); err != nil {
+			return nil, fmt.Errorf("service account bind: %w", err)
+		}
+	}
+
+	entry, err := l.searchOne(conn, l.options.UserBaseDN, ldap.ScopeWholeSubtree, fmt.Sprintf(l.options.UserFilter, ldap.EscapeFilter(username)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		return nil, bindError(err)
+	}
+
+	return entry, nil
+}
+
+func (l *LDAP) searchOne(conn ldapConn, baseDN string, scope int, filter string) (*ldap.Entry, error) {
+	result, err := conn.Search(ldap.NewSearchRequest(
+		baseDN, scope, ldap.NeverDerefAliases, 2, int(l.options.Timeout.Seconds()), false,
+		filter, []string{"dn", l.options.UsernameAttribute, l.options.GroupAttribute}, nil,
+	))
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+			return nil, ErrAuthFailed
+		}
+
+		return nil, fmt.Errorf("conn.Search: %w", err)
+	}
+
+	if len(result.Entries) != 1 {
+		return nil, fmt.Errorf("%w: found %d entries", ErrAuthFailed, len(result.Entries))
+	}
+
+	return result.Entries[0], nil
+}
+
+func (l *LDAP) mapGroups(groups []string) []string {
+	roles := slices.Clone(l.options.DefaultRoles)
+
+	for group, groupRoles := range l.options.GroupRoles {
+		for _, userGroup := range groups {
+			if groupMatches(group, userGroup) {
+				roles = append(roles, groupRoles...)
+				break
+			}
+		}
+	}
+
+	slices.Sort(roles)
+
+	return slices.Compact(roles)
+}
+
+func (l *LDAP) tlsConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: l.options.InsecureSkipVerify, // nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+}
+
+// groupMatches compare group DN exactly, group without DN syntax is compared with CN of user group
+func groupMatches(group, userGroup string) bool {
+	groupDN, err := ldap.ParseDN(group)
+	if err != nil {
+		return strings.EqualFold(group, groupCN(userGroup))
+	}
+
+	userGroupDN, err := ldap.ParseDN(userGroup)
+	if err != nil {
+		return false
+	}
+
+	return groupDN.EqualFold(userGroupDN)
+}
+
+// groupCN returns first RDN value e.g. rtc-admins for cn=rtc-admins,ou=groups,dc=example,dc=org
+func groupCN(groupDN string) string {
+	dn, err := ldap.ParseDN(groupDN)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return ""
+	}
+
+	return dn.RDNs[0].Attributes[0].Value
+}
+
+func bindError(err error) error {
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return ErrAuthFailed
+	}
+
+	return fmt.Errorf("conn.Bind: %w", err)
+}
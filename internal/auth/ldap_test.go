@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLDAPConn struct {
+	passwords map[string]string
+	entries   []*ldap.Entry
+	filters   []string
+}
+
+func (c *fakeLDAPConn) Bind(username, password string) error {
+	if expected, ok := c.passwords[username]; ok && expected == password {
+		return nil
+	}
+
+	return ldap.NewError(ldap.LDAPResultInvalidCredentials, nil)
+}
+
+func (c *fakeLDAPConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	c.filters = append(c.filters, req.Filter)
+
+	var result ldap.SearchResult
+
+	for _, entry := range c.entries {
+		if req.Scope == ldap.ScopeBaseObject && entry.DN != req.BaseDN {
+			continue
+		}
+
+		result.Entries = append(result.Entries, entry)
+	}
+
+	return &result, nil
+}
+
+func (c *fakeLDAPConn) StartTLS(_ *tls.Config) error { return nil }
+
+func (c *fakeLDAPConn) Close() error { return nil }
+
+func newTestLDAP(options LDAPOptions, conn *fakeLDAPConn) *LDAP {
+	l := NewLDAP(options)
+	l.dial = func() (ldapConn, error) {
+		return conn, nil
+	}
+
+	return l
+}
+
+var testLDAPEntry = ldap.NewEntry("uid=john,ou=people,dc=example,dc=org", map[string][]string{
+	"uid": {"john"},
+	"memberOf": {
+		"cn=rtc-admins,ou=groups,dc=example,dc=org",
+		"cn=developers,ou=groups,dc=example,dc=org",
+	},
+})
+
+func Test_LDAP_AuthenticatePassword_Search_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeLDAPConn{
+		passwords: map[string]string{
+			"cn=readonly,dc=example,dc=org":        "readonly",
+			"uid=john,ou=people,dc=example,dc=org": "secret",
+		},
+		entries: []*ldap.Entry{testLDAPEntry},
+	}
+
+	l := newTestLDAP(LDAPOptions{
+		BindDN:            "cn=readonly,dc=example,dc=org",
+		BindPassword:      "readonly",
+		UserBaseDN:        "ou=people,dc=example,dc=org",
+		UserFilter:        "(uid=%s)",
+		UsernameAttribute: "uid",
+		GroupAttribute:    "memberOf",
+		GroupRoles: map[string][]string{
+			"cn=rtc-admins,ou=groups,dc=example,dc=org": {"admin"},
+			"developers": {"editor"},
+			"unknown":    {"other"},
+		},
+		DefaultRoles: []string{"viewer"},
+	}, conn)
+
+	payload, err := l.AuthenticatePassword(context.Background(), "john", "secret")
+
+	require.NoError(t, err)
+	require.Equal(t, &Payload{Username: "john", Roles: []string{"admin", "editor", "viewer"}}, payload)
+	require.Equal(t, []string{"(uid=john)"}, conn.filters)
+}
+
+func Test_LDAP_AuthenticatePassword_DNTemplate_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeLDAPConn{
+		passwords: map[string]string{
+			"uid=john,ou=people,dc=example,dc=org": "secret",
+		},
+		entries: []*ldap.Entry{testLDAPEntry},
+	}
+
+	l := newTestLDAP(LDAPOptions{
+		UserDNTemplate:    "uid=%s,ou=people,dc=example,dc=org",
+		UsernameAttribute: "uid",
+		GroupAttribute:    "memberOf",
+		GroupRoles: map[string][]string{
+			"rtc-admins": {"admin"},
+		},
+	}, conn)
+
+	payload, err := l.AuthenticatePassword(context.Background(), "john", "secret")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin"}, payload.Roles)
+}
+
+func Test_LDAP_AuthenticatePassword_CanonicalUsername_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeLDAPConn{
+		passwords: map[string]string{
+			"uid=john,ou=people,dc=example,dc=org": "secret",
+		},
+		entries: []*ldap.Entry{testLDAPEntry},
+	}
+
+	l := newTestLDAP(LDAPOptions{
+		UserBaseDN:        "ou=people,dc=example,dc=org",
+		UserFilter:        "(uid=%s)",
+		UsernameAttribute: "uid",
+		GroupAttribute:    "memberOf",
+	}, conn)
+
+	// directory matches uid case-insensitively
+	payload, err := l.AuthenticatePassword(context.Background(), "John", "secret")
+
+	require.NoError(t, err)
+	require.Equal(t, "john", payload.Username)
+}
+
+func Test_LDAP_AuthenticatePassword_NoUsernameAttribute_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeLDAPConn{
+		passwords: map[string]string{
+			"uid=john,ou=people,dc=example,dc=org": "secret",
+		},
+		entries: []*ldap.Entry{testLDAPEntry},
+	}
+
+	l := newTestLDAP(LDAPOptions{
+		UserBaseDN:        "ou=people,dc=example,dc=org",
+		UserFilter:        "(uid=%s)",
+		UsernameAttribute: "sAMAccountName",
+		GroupAttribute:    "memberOf",
+	}, conn)
+
+	_, err := l.AuthenticatePassword(context.Background(), "john", "secret")
+	require.EqualError(t, err, "user entry uid=john,ou=people,dc=example,dc=org has no sAMAccountName attribute")
+}
+
+func Test_LDAP_mapGroups_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	l := NewLDAP(LDAPOptions{
+		GroupRoles: map[string][]string{
+			"CN=RTC-Admins, OU=Groups,DC=example,DC=org": {"admin"},
+			"developers": {"editor"},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		groups []string
+		want   []string
+	}{
+		{name: "dn", groups: []string{"cn=rtc-admins,ou=groups,dc=example,dc=org"}, want: []string{"admin"}},
+		{name: "dn of other branch", groups: []string{"cn=rtc-admins,ou=guests,dc=example,dc=org"}},
+		{name: "cn of any branch", groups: []string{"cn=developers,ou=guests,dc=example,dc=org"}, want: []string{"editor"}},
+		{name: "invalid dn", groups: []string{"rtc-admins"}},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, l.mapGroups(tt.groups), tt.name)
+	}
+}
+
+func Test_LDAP_AuthenticatePassword_InvalidPassword_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeLDAPConn{
+		entries: []*ldap.Entry{testLDAPEntry},
+	}
+
+	l := newTestLDAP(LDAPOptions{
+		UserBaseDN:     "ou=people,dc=example,dc=org",
+		UserFilter:     "(uid=%s)",
+		GroupAttribute: "memberOf",
+	}, conn)
+
+	_, err := l.AuthenticatePassword(context.Background(), "john", "wrong")
+	require.ErrorIs(t, err, ErrAuthFailed)
+
+	_, err = l.AuthenticatePassword(context.Background(), "john", "")
+	require.ErrorIs(t, err, ErrAuthFailed)
+}
+
+func Test_LDAP_AuthenticatePassword_FilterInjection_ExpectEscaped(t *testing.T) {
+	t.Parallel()
+
+	conn := &fakeLDAPConn{}
+
+	l := newTestLDAP(LDAPOptions{
+		UserBaseDN: "ou=people,dc=example,dc=org",
+		UserFilter: "(uid=%s)",
+	}, conn)
+
+	_, err := l.AuthenticatePassword(context.Background(), "*)(uid=*", "secret")
+
+	require.ErrorIs(t, err, ErrAuthFailed)
+	require.Equal(t, []string{`(uid=\2a\29\28uid=\2a)`}, conn.filters)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"context"
 	"github.com/DesSolo/rtc/internal/auth"
 
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +39,8 @@ func (_m *MockAuthenticator) EXPECT() *MockAuthenticator_Expecter {
 }
 
 // Authenticate provides a mock function for the type MockAuthenticator
-func (_mock *MockAuthenticator) Authenticate(token string) (*auth.Payload, error) {
-	ret := _mock.Called(token)
+func (_mock *MockAuthenticator) Authenticate(ctx context.Context, token string) (*auth.Payload, error) {
+	ret := _mock.Called(ctx, token)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Authenticate")
@@ -47,18 +48,18 @@ func (_mock *MockAuthenticator) Authenticate(token string) (*auth.Payload, error
 
 	var r0 *auth.Payload
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(string) (*auth.Payload, error)); ok {
-		return returnFunc(token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*auth.Payload, error)); ok {
+		return returnFunc(ctx, token)
 	}
-	if returnFunc, ok := ret.Get(0).(func(string) *auth.Payload); ok {
-		r0 = returnFunc(token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *auth.Payload); ok {
+		r0 = returnFunc(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*auth.Payload)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
-		r1 = returnFunc(token)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -71,19 +72,25 @@ type MockAuthenticator_Authenticate_Call struct {
 }
 
 // Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
 //   - token string
-func (_e *MockAuthenticator_Expecter) Authenticate(token interface{}) *MockAuthenticator_Authenticate_Call {
-	return &MockAuthenticator_Authenticate_Call{Call: _e.mock.On("Authenticate", token)}
+func (_e *MockAuthenticator_Expecter) Authenticate(ctx interface{}, token interface{}) *MockAuthenticator_Authenticate_Call {
+	return &MockAuthenticator_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, token)}
 }
 
-func (_c *MockAuthenticator_Authenticate_Call) Run(run func(token string)) *MockAuthenticator_Authenticate_Call {
+func (_c *MockAuthenticator_Authenticate_Call) Run(run func(ctx context.Context, token string)) *MockAuthenticator_Authenticate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -94,7 +101,7 @@ func (_c *MockAuthenticator_Authenticate_Call) Return(payload *auth.Payload, err
 	return _c
 }
 
-func (_c *MockAuthenticator_Authenticate_Call) RunAndReturn(run func(token string) (*auth.Payload, error)) *MockAuthenticator_Authenticate_Call {
+func (_c *MockAuthenticator_Authenticate_Call) RunAndReturn(run func(ctx context.Context, token string) (*auth.Payload, error)) *MockAuthenticator_Authenticate_Call {
 	_c.Call.Return(run)
 	return _c
 }
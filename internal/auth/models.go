@@ -1,7 +1,13 @@
 package auth
 
+import "time"
+
 // Payload ...
 type Payload struct {
 	Username string
 	Roles    []string
+	// Scopes limit access for api tokens (empty is unlimited)
+	Scopes []string
+	// IssuedAt used for revocation check (zero if unknown)
+	IssuedAt time.Time
 }
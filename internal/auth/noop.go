@@ -16,3 +16,8 @@ func NewNoop() *Noop {
 func (n *Noop) Authorize(_ context.Context, _ map[string]any) error {
 	return nil
 }
+
+// UsesInput ...
+func (n *Noop) UsesInput(_ string) bool {
+	return false
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCOptions ...
+type OIDCOptions struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// Audience expected in bearer tokens (client id if empty)
+	Audience string
+	Mapper   *ClaimsMapper
+}
+
+// OIDC OpenID Connect authorization code flow with PKCE
+// and bearer tokens authenticator (verified by issuer JWKS)
+type OIDC struct {
+	oauth2         *oauth2.Config
+	idTokenVerify  *oidc.IDTokenVerifier
+	bearerVerifier *oidc.IDTokenVerifier
+	mapper         *ClaimsMapper
+}
+
+// NewOIDC discover provider and create OIDC
+func NewOIDC(ctx context.Context, options OIDCOptions) (*OIDC, error) {
+	provider, err := oidc.NewProvider(ctx, options.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc.NewProvider: %w", err)
+	}
+
+	scopes := options.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+
+	audience := options.Audience
+	if audience == "" {
+		audience = options.ClientID
+	}
+
+	return &OIDC{
+		oauth2: &oauth2.Config{
+			ClientID:     options.ClientID,
+			ClientSecret: options.ClientSecret,
+			RedirectURL:  options.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		idTokenVerify:  provider.Verifier(&oidc.Config{ClientID: options.ClientID}),
+		bearerVerifier: provider.Verifier(&oidc.Config{ClientID: audience}),
+		mapper:         options.Mapper,
+	}, nil
+}
+
+// AuthCodeURL returns IdP login url, verifier is PKCE code verifier,
+// nonce is returned back in id token
+func (o *OIDC) AuthCodeURL(state, verifier, nonce string) string {
+	return o.oauth2.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce))
+}
+
+// Exchange code to id token, check its nonce and map its claims
+func (o *OIDC) Exchange(ctx context.Context, code, verifier, nonce string) (*Payload, error) {
+	token, err := o.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("oauth2.Exchange: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.Join(ErrAuthFailed, errors.New("id_token not found in token response"))
+	}
+
+	idToken, err := o.idTokenVerify.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Join(ErrAuthFailed, fmt.Errorf("verifier.Verify: %w", err))
+	}
+
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.Join(ErrAuthFailed, errors.New("id_token nonce mismatch"))
+	}
+
+	return o.mapClaims(idToken)
+}
+
+// Authenticate validate bearer token issued by IdP
+func (o *OIDC) Authenticate(ctx context.Context, token string) (*Payload, error) {
+	idToken, err := o.bearerVerifier.Verify(ctx, token)
+	if err != nil {
+		return nil, errors.Join(ErrAuthFailed, fmt.Errorf("verifier.Verify: %w", err))
+	}
+
+	return o.mapClaims(idToken)
+}
+
+func (o *OIDC) mapClaims(idToken *oidc.IDToken) (*Payload, error) {
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("idToken.Claims: %w", err)
+	}
+
+	payload, err := o.mapper.Map(claims)
+	if err != nil {
+		return nil, fmt.Errorf("mapper.Map: %w", err)
+	}
+
+	payload.IssuedAt = idToken.IssuedAt
+
+	return payload, nil
+}
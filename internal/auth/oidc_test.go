@@ -0,0 +1,269 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIdP minimal OpenID provider with discovery, JWKS and token endpoints
+type mockIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+	// challenge PKCE code challenge received on authorize
+	challenge string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &mockIdP{t: t, key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.handleDiscovery)
+	mux.HandleFunc("/keys", idp.handleKeys)
+	mux.HandleFunc("/token", idp.handleToken)
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *mockIdP) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                idp.server.URL,
+		"authorization_endpoint":                idp.server.URL + "/authorize",
+		"token_endpoint":                        idp.server.URL + "/token",
+		"jwks_uri":                              idp.server.URL + "/keys",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (idp *mockIdP) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]any{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (idp *mockIdP) handleToken(w http.ResponseWriter, r *http.Request) {
+	require.NoError(idp.t, r.ParseForm())
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if r.PostForm.Get("code") != "test-code" || base64.RawURLEncoding.EncodeToString(sum[:]) != idp.challenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     idp.sign(idp.claims),
+	})
+}
+
+func (idp *mockIdP) sign(claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test"
+
+	signed, err := token.SignedString(idp.key)
+	require.NoError(idp.t, err)
+
+	return signed
+}
+
+func (idp *mockIdP) newClaims(audience string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"sub":                "1",
+		"aud":                audience,
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"preferred_username": "sso_user",
+		"groups":             []string{"rtc-admins", "developers"},
+	}
+}
+
+func newTestOIDC(t *testing.T, idp *mockIdP) *OIDC {
+	t.Helper()
+
+	o, err := NewOIDC(context.Background(), OIDCOptions{
+		IssuerURL:   idp.server.URL,
+		ClientID:    "rtc",
+		RedirectURL: "http://localhost:8080/api/v1/oidc/callback",
+		Audience:    "rtc-api",
+		Mapper: &ClaimsMapper{
+			UsernameClaim: "preferred_username",
+			Rules: []RoleRule{
+				{Claim: "groups", Value: "rtc-admins", Roles: []string{"admin"}},
+				{Claim: "groups", Value: "unknown", Roles: []string{"editor"}},
+			},
+			DefaultRoles: []string{"viewer"},
+		},
+	})
+	require.NoError(t, err)
+
+	return o
+}
+
+func Test_OIDC_Exchange_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	idp := newMockIdP(t)
+	o := newTestOIDC(t, idp)
+
+	verifier := "test-verifier-with-enough-length-1234567890"
+
+	authURL, err := url.Parse(o.AuthCodeURL("state", verifier, "nonce"))
+	require.NoError(t, err)
+	require.Equal(t, "S256", authURL.Query().Get("code_challenge_method"))
+	require.Equal(t, "state", authURL.Query().Get("state"))
+	require.Equal(t, "nonce", authURL.Query().Get("nonce"))
+
+	idp.challenge = authURL.Query().Get("code_challenge")
+	idp.claims = idp.newClaims("rtc")
+	idp.claims["nonce"] = authURL.Query().Get("nonce")
+
+	payload, err := o.Exchange(context.Background(), "test-code", verifier, "nonce")
+
+	require.NoError(t, err)
+	require.Equal(t, "sso_user", payload.Username)
+	require.Equal(t, []string{"admin", "viewer"}, payload.Roles)
+	require.False(t, payload.IssuedAt.IsZero())
+}
+
+func Test_OIDC_Exchange_InvalidVerifier_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	idp := newMockIdP(t)
+	o := newTestOIDC(t, idp)
+
+	authURL, err := url.Parse(o.AuthCodeURL("state", "test-verifier-with-enough-length-1234567890", "nonce"))
+	require.NoError(t, err)
+
+	idp.challenge = authURL.Query().Get("code_challenge")
+	idp.claims = idp.newClaims("rtc")
+	idp.claims["nonce"] = "nonce"
+
+	_, err = o.Exchange(context.Background(), "test-code", "other-verifier-with-enough-length-1234567890", "nonce")
+
+	require.Error(t, err)
+}
+
+func Test_OIDC_Exchange_InvalidNonce_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	idp := newMockIdP(t)
+	o := newTestOIDC(t, idp)
+
+	verifier := "test-verifier-with-enough-length-1234567890"
+
+	authURL, err := url.Parse(o.AuthCodeURL("state", verifier, "nonce"))
+	require.NoError(t, err)
+
+	idp.challenge = authURL.Query().Get("code_challenge")
+	idp.claims = idp.newClaims("rtc")
+	idp.claims["nonce"] = "replayed"
+
+	_, err = o.Exchange(context.Background(), "test-code", verifier, "nonce")
+
+	require.ErrorIs(t, err, ErrAuthFailed)
+}
+
+func Test_OIDC_Exchange_NoNonce_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	idp := newMockIdP(t)
+	o := newTestOIDC(t, idp)
+
+	verifier := "test-verifier-with-enough-length-1234567890"
+
+	authURL, err := url.Parse(o.AuthCodeURL("state", verifier, "nonce"))
+	require.NoError(t, err)
+
+	idp.challenge = authURL.Query().Get("code_challenge")
+	idp.claims = idp.newClaims("rtc")
+
+	_, err = o.Exchange(context.Background(), "test-code", verifier, "nonce")
+
+	require.ErrorIs(t, err, ErrAuthFailed)
+}
+
+func Test_OIDC_Authenticate_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	idp := newMockIdP(t)
+	o := newTestOIDC(t, idp)
+
+	payload, err := o.Authenticate(context.Background(), idp.sign(idp.newClaims("rtc-api")))
+
+	require.NoError(t, err)
+	require.Equal(t, "sso_user", payload.Username)
+}
+
+func Test_OIDC_Authenticate_WrongAudience_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	idp := newMockIdP(t)
+	o := newTestOIDC(t, idp)
+
+	_, err := o.Authenticate(context.Background(), idp.sign(idp.newClaims("other")))
+
+	require.ErrorIs(t, err, ErrAuthFailed)
+}
+
+func Test_ClaimsMapper_Map_NestedClaim_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	mapper := &ClaimsMapper{
+		UsernameClaim: "email",
+		Rules: []RoleRule{
+			{Claim: "realm_access.roles", Value: "rtc-admin", Roles: []string{"admin"}},
+		},
+	}
+
+	payload, err := mapper.Map(map[string]any{
+		"email": "user@example.com",
+		"realm_access": map[string]any{
+			"roles": []any{"rtc-admin"},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, &Payload{Username: "user@example.com", Roles: []string{"admin"}}, payload)
+}
+
+func Test_ClaimsMapper_Map_NoUsername_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	mapper := &ClaimsMapper{UsernameClaim: "email"}
+
+	_, err := mapper.Map(map[string]any{})
+
+	require.ErrorIs(t, err, ErrAuthFailed)
+}
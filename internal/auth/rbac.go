@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+)
+
+// Role of project member, greater role includes lower
+type Role string
+
+const (
+	// RoleNone no role in project
+	RoleNone Role = ""
+	// RoleViewer read project configs
+	RoleViewer Role = "viewer"
+	// RoleEditor change config values
+	RoleEditor Role = "editor"
+	// RoleAdmin manage project, releases and members
+	RoleAdmin Role = "admin"
+)
+
+var roleLevels = map[Role]int{
+	RoleNone:   0,
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// ParseRole ...
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := roleLevels[role]; !ok || role == RoleNone {
+		return RoleNone, fmt.Errorf("unknown role %q", value)
+	}
+
+	return role, nil
+}
+
+// Includes role grants access of other role
+func (r Role) Includes(other Role) bool {
+	return roleLevels[r] >= roleLevels[other]
+}
+
+const (
+	// BindingKindUser binding subject is username
+	BindingKindUser = "user"
+	// BindingKindGroup binding subject is user role e.g. mapped from LDAP group
+	BindingKindGroup = "group"
+
+	// AnyProject binding project for all projects
+	AnyProject = "*"
+	// AnyEnv binding environment pattern for all environments
+	AnyEnv = "*"
+)
+
+// RoleBinding grants role to user or group in project environments
+type RoleBinding struct {
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Project string `json:"project"`
+	// Env environment pattern e.g. prod or dev-*
+	Env  string `json:"env"`
+	Role Role   `json:"role"`
+}
+
+// ValidateEnvPattern ...
+func ValidateEnvPattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("path.Match: %w", err)
+	}
+
+	return nil
+}
+
+func (b RoleBinding) matchProject(project string) bool {
+	return b.Project == AnyProject || b.Project == project
+}
+
+func (b RoleBinding) matchEnv(env string) bool {
+	ok, err := path.Match(b.Env, env)
+	return err == nil && ok
+}
+
+// Membership role of user in project and environment of request
+type Membership struct {
+	Project string `json:"project"`
+	Env     string `json:"env"`
+	Role    Role   `json:"role"`
+	// Bindings all bindings of user and its groups
+	Bindings []RoleBinding `json:"bindings"`
+}
+
+// NewMembership resolve greatest role of bindings in project and environment.
+// On routes without environment bindings limited to environments grant viewer role only.
+func NewMembership(bindings []RoleBinding, project, env string) *Membership {
+	membership := &Membership{
+		Project:  project,
+		Env:      env,
+		Role:     RoleNone,
+		Bindings: bindings,
+	}
+
+	for _, binding := range bindings {
+		if !binding.matchProject(project) {
+			continue
+		}
+
+		role := binding.Role
+
+		switch {
+		case binding.Env == AnyEnv:
+		case env != "":
+			if !binding.matchEnv(env) {
+				continue
+			}
+		case project != "":
+			// binding limited to environments
+			role = RoleViewer
+		default:
+			continue
+		}
+
+		if !membership.Role.Includes(role) {
+			membership.Role = role
+		}
+	}
+
+	return membership
+}
+
+// RBAC authorizer based on project roles from request membership
+type RBAC struct {
+	adminRoles []string
+	actions    map[string]Role
+}
+
+// NewRBAC users with one of admin roles are allowed any action
+func NewRBAC(adminRoles []string) *RBAC {
+	return &RBAC{
+		adminRoles: adminRoles,
+		actions:    rbacActions,
+	}
+}
+
+// rbacActions required project role of actions, project actions without project require binding to all projects
+var rbacActions = map[string]Role{
+	"list_projects":   RoleNone,
+	"change_password": RoleNone,
+	"enroll_totp":     RoleNone,
+	"confirm_totp":    RoleNone,
+	"disable_totp":    RoleNone,
+	"list_tokens":     RoleNone,
+	"create_token":    RoleNone,
+	"revoke_token":    RoleNone,
+
+	"list_envs":           RoleViewer,
+	"list_releases":       RoleViewer,
+	"list_configs":        RoleViewer,
+	"list_config_history": RoleViewer,
+	"list_members":        RoleViewer,
+
+	"set_config_values": RoleEditor,
+	"upsert_configs":    RoleEditor,
+
+	"create_project":      RoleAdmin,
+	"update_project":      RoleAdmin,
+	"delete_project":      RoleAdmin,
+	"delete_release":      RoleAdmin,
+	"add_member":          RoleAdmin,
+	"remove_member":       RoleAdmin,
+	"list_audits":         RoleAdmin,
+	"list_audit_actions":  RoleAdmin,
+	"verify_audits":       RoleAdmin,
+	"import_audits":       RoleAdmin,
+	"eval_policy":         RoleAdmin,
+	"list_users":          RoleAdmin,
+	"create_user":         RoleAdmin,
+	"update_user":         RoleAdmin,
+	"reset_user_password": RoleAdmin,
+	"reset_user_totp":     RoleAdmin,
+	"list_user_tokens":    RoleAdmin,
+	"revoke_user_token":   RoleAdmin,
+}
+
+// Authorize ...
+func (r *RBAC) Authorize(ctx context.Context, input map[string]any) error {
+	action, _ := input["action"].(string)
+
+	required, ok := r.actions[action]
+	if !ok {
+		return fmt.Errorf("%w: unknown action %q", ErrForbidden, action)
+	}
+
+	if payload := FromContext(ctx); payload != nil && slices.ContainsFunc(payload.Roles, r.isAdminRole) {
+		return nil
+	}
+
+	membership, ok := input["membership"].(*Membership)
+	if !ok {
+		membership = &Membership{}
+	}
+
+	if !membership.Role.Includes(required) {
+		return fmt.Errorf("%w: action %q requires role %q, user role is %q in project %q env %q",
+			ErrForbidden, action, required, membership.Role, membership.Project, membership.Env,
+		)
+	}
+
+	return nil
+}
+
+// UsesInput membership is the only optional input field used by RBAC
+func (r *RBAC) UsesInput(field string) bool {
+	return field == "membership"
+}
+
+func (r *RBAC) isAdminRole(role string) bool {
+	return slices.Contains(r.adminRoles, role)
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewMembership_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	bindings := []RoleBinding{
+		{Kind: BindingKindGroup, Subject: "developers", Project: AnyProject, Env: AnyEnv, Role: RoleViewer},
+		{Kind: BindingKindUser, Subject: "user", Project: "example", Env: "dev-*", Role: RoleEditor},
+		{Kind: BindingKindUser, Subject: "user", Project: "other", Env: AnyEnv, Role: RoleAdmin},
+	}
+
+	require.Equal(t, RoleEditor, NewMembership(bindings, "example", "dev-1").Role)
+	require.Equal(t, RoleViewer, NewMembership(bindings, "example", "prod").Role)
+	// binding limited to environments
+	require.Equal(t, RoleViewer, NewMembership(bindings, "example", "").Role)
+	require.Equal(t, RoleAdmin, NewMembership(bindings, "other", "").Role)
+	require.Equal(t, RoleViewer, NewMembership(bindings, "", "").Role)
+	require.Equal(t, RoleNone, NewMembership(bindings[1:], "", "").Role)
+}
+
+func Test_RBAC_Authorize_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	rbac := NewRBAC([]string{"admin"})
+	ctx := ToContext(context.Background(), &Payload{Username: "user", Roles: []string{"developers"}})
+
+	require.NoError(t, rbac.Authorize(ctx, map[string]any{
+		"action":     "set_config_values",
+		"membership": &Membership{Role: RoleEditor},
+	}))
+	require.NoError(t, rbac.Authorize(ctx, map[string]any{
+		"action": "list_projects",
+	}))
+
+	adminCtx := ToContext(context.Background(), &Payload{Username: "admin", Roles: []string{"admin"}})
+	require.NoError(t, rbac.Authorize(adminCtx, map[string]any{
+		"action": "create_user",
+	}))
+}
+
+func Test_RBAC_Authorize_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	rbac := NewRBAC([]string{"admin"})
+	ctx := ToContext(context.Background(), &Payload{Username: "user"})
+
+	require.ErrorIs(t, rbac.Authorize(ctx, map[string]any{
+		"action":     "set_config_values",
+		"membership": &Membership{Role: RoleViewer},
+	}), ErrForbidden)
+	require.ErrorIs(t, rbac.Authorize(ctx, map[string]any{
+		"action": "list_configs",
+	}), ErrForbidden)
+	require.ErrorIs(t, rbac.Authorize(ctx, map[string]any{
+		"action":     "unknown",
+		"membership": &Membership{Role: RoleAdmin},
+	}), ErrForbidden)
+}
+
+func Test_UsesInput_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, UsesInput(NewRBAC(nil), "membership"))
+	require.False(t, UsesInput(NewRBAC(nil), "changes"))
+	require.False(t, UsesInput(NewNoop(), "membership"))
+	// authorizers without inspector get all fields
+	require.True(t, UsesInput(struct{ Authorizer }{}, "membership"))
+}
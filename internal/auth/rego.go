@@ -1,25 +1,115 @@
 package auth
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sync/atomic"
 
+	"github.com/open-policy-agent/opa/v1/ast"
 	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/topdown"
+	"github.com/open-policy-agent/opa/v1/topdown/lineage"
 )
 
 // Rego ...
 type Rego struct {
-	policy *rego.PreparedEvalQuery
+	policy atomic.Pointer[RegoPolicy]
+}
+
+// RegoPolicy compiled policy
+type RegoPolicy struct {
+	query rego.PreparedEvalQuery
+	// inputs top level input fields referenced by policy, nil if whole input is referenced
+	inputs map[string]bool
 }
 
 // NewRego ...
-func NewRego(policy *rego.PreparedEvalQuery) *Rego {
-	return &Rego{policy: policy}
+func NewRego(policy *RegoPolicy) *Rego {
+	r := &Rego{}
+	r.Update(policy)
+
+	return r
+}
+
+// PrepareRego compile policy from file
+func PrepareRego(ctx context.Context, query, policyPath string) (*RegoPolicy, error) {
+	compiler := ast.NewCompiler()
+
+	prepared, err := rego.New(
+		rego.Query(query),
+		rego.Load([]string{policyPath}, nil),
+		rego.Compiler(compiler),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rego.PrepareForEval: %w", err)
+	}
+
+	body, err := ast.ParseBody(query)
+	if err != nil {
+		return nil, fmt.Errorf("ast.ParseBody: %w", err)
+	}
+
+	return &RegoPolicy{
+		query:  prepared,
+		inputs: referencedInputs(body, compiler.Modules),
+	}, nil
+}
+
+// referencedInputs returns nil if input is referenced as whole or by dynamic key
+func referencedInputs(query ast.Body, modules map[string]*ast.Module) map[string]bool {
+	inputs := make(map[string]bool)
+	whole := false
+
+	visit := func(ref ast.Ref) bool {
+		if !ref.HasPrefix(ast.InputRootRef) {
+			return false
+		}
+
+		if len(ref) < 2 {
+			whole = true
+			return true
+		}
+
+		field, ok := ref[1].Value.(ast.String)
+		if !ok {
+			whole = true
+			return true
+		}
+
+		inputs[string(field)] = true
+
+		return false
+	}
+
+	ast.WalkRefs(query, visit)
+
+	for _, module := range modules {
+		ast.WalkRefs(module, visit)
+	}
+
+	if whole {
+		return nil
+	}
+
+	return inputs
+}
+
+// Update atomically replace policy
+func (r *Rego) Update(policy *RegoPolicy) {
+	r.policy.Store(policy)
+}
+
+// UsesInput ...
+func (r *Rego) UsesInput(field string) bool {
+	inputs := r.policy.Load().inputs
+
+	return inputs == nil || inputs[field]
 }
 
 // Authorize ...
 func (r *Rego) Authorize(ctx context.Context, input map[string]any) error {
-	result, err := r.policy.Eval(ctx, rego.EvalInput(input))
+	result, err := r.policy.Load().query.Eval(ctx, rego.EvalInput(input))
 	if err != nil {
 		return fmt.Errorf("policy.Eval: %w", err)
 	}
@@ -30,3 +120,41 @@ func (r *Rego) Authorize(ctx context.Context, input map[string]any) error {
 
 	return nil
 }
+
+// Explain evaluate policy with trace filtered by mode
+func (r *Rego) Explain(ctx context.Context, input map[string]any, mode ExplainMode) *Decision {
+	tracer := topdown.NewBufferTracer()
+
+	decision := &Decision{}
+
+	result, err := r.policy.Load().query.Eval(ctx, rego.EvalInput(input), rego.EvalQueryTracer(tracer))
+
+	switch {
+	case err != nil:
+		decision.Reason = fmt.Sprintf("policy.Eval: %s", err)
+	case !result.Allowed():
+		decision.Reason = "policy result not allowed"
+	default:
+		decision.Allowed = true
+	}
+
+	var trace []*topdown.Event
+
+	switch mode {
+	case ExplainNotes:
+		trace = lineage.Notes(*tracer)
+	case ExplainFails:
+		trace = lineage.Fails(*tracer)
+	default:
+		trace = lineage.Full(*tracer)
+	}
+
+	var buf bytes.Buffer
+	topdown.PrettyTraceWithLocation(&buf, trace)
+
+	if buf.Len() > 0 {
+		decision.Explanation = explanationLines(buf.String())
+	}
+
+	return decision
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// ScopeReadOnly allow only read requests
+	ScopeReadOnly = "read"
+	// ScopeProjectPrefix limit access to one project e.g. project:example
+	ScopeProjectPrefix = "project:"
+)
+
+// ValidateScopes ...
+func ValidateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if scope == ScopeReadOnly {
+			continue
+		}
+
+		if projectName, ok := strings.CutPrefix(scope, ScopeProjectPrefix); ok && projectName != "" {
+			continue
+		}
+
+		return fmt.Errorf("unknown scope: %q", scope)
+	}
+
+	return nil
+}
+
+// CheckScopes checks request allowed by scopes, several project scopes allow any of projects
+// projectName is empty for requests not related to project
+func CheckScopes(scopes []string, method, projectName string) error {
+	var projects []string
+
+	for _, scope := range scopes {
+		if scope == ScopeReadOnly {
+			if method != http.MethodGet && method != http.MethodHead {
+				return fmt.Errorf("%w: scope %q", ErrForbidden, scope)
+			}
+
+			continue
+		}
+
+		if scopeProject, ok := strings.CutPrefix(scope, ScopeProjectPrefix); ok {
+			projects = append(projects, scopeProject)
+		}
+	}
+
+	if len(projects) != 0 && !slices.Contains(projects, projectName) {
+		return fmt.Errorf("%w: project %q is out of scopes %q", ErrForbidden, projectName, projects)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckScopes_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CheckScopes(nil, http.MethodPost, "example"))
+	require.NoError(t, CheckScopes([]string{"read"}, http.MethodGet, ""))
+	require.NoError(t, CheckScopes([]string{"read", "project:example"}, http.MethodGet, "example"))
+	require.NoError(t, CheckScopes([]string{"project:example"}, http.MethodPut, "example"))
+	require.NoError(t, CheckScopes([]string{"project:a", "project:b"}, http.MethodPut, "b"))
+}
+
+func Test_CheckScopes_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, CheckScopes([]string{"read"}, http.MethodPut, "example"), ErrForbidden)
+	require.ErrorIs(t, CheckScopes([]string{"project:example"}, http.MethodGet, "other"), ErrForbidden)
+	require.ErrorIs(t, CheckScopes([]string{"project:example"}, http.MethodGet, ""), ErrForbidden)
+	// scoped token can't create tokens
+	require.ErrorIs(t, CheckScopes([]string{"read"}, http.MethodPost, ""), ErrForbidden)
+	require.ErrorIs(t, CheckScopes([]string{"project:example"}, http.MethodPost, ""), ErrForbidden)
+}
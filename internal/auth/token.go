@@ -1,20 +1,31 @@
 package auth
 
+import (
+	"context"
+	"sync/atomic"
+)
+
 // Token ...
 type Token struct {
-	items map[string]*Payload
+	items atomic.Pointer[map[string]*Payload]
 }
 
 // NewToken ...
 func NewToken(items map[string]*Payload) *Token {
-	return &Token{
-		items: items,
-	}
+	t := &Token{}
+	t.Update(items)
+
+	return t
+}
+
+// Update atomically replace tokens
+func (t *Token) Update(items map[string]*Payload) {
+	t.items.Store(&items)
 }
 
 // Authenticate ...
-func (t *Token) Authenticate(token string) (*Payload, error) {
-	p, ok := t.items[token]
+func (t *Token) Authenticate(_ context.Context, token string) (*Payload, error) {
+	p, ok := (*t.items.Load())[token]
 	if !ok {
 		return nil, ErrAuthFailed
 	}
@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Token_Update_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	token := NewToken(map[string]*Payload{
+		"old": {Username: "old"},
+	})
+
+	token.Update(map[string]*Payload{
+		"new": {Username: "new", Roles: []string{"admin"}},
+	})
+
+	_, err := token.Authenticate(context.Background(), "old")
+	require.ErrorIs(t, err, ErrAuthFailed)
+
+	payload, err := token.Authenticate(context.Background(), "new")
+	require.NoError(t, err)
+	require.Equal(t, &Payload{Username: "new", Roles: []string{"admin"}}, payload)
+}
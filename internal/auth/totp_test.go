@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B test vectors (SHA1, last 6 digits)
+func Test_TOTPCode_RFCVectors_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for ts, expected := range tests {
+		got, err := TOTPCode(secret, time.Unix(ts, 0))
+		require.NoError(t, err)
+		require.Equal(t, expected, got)
+	}
+}
+
+func Test_ValidateTOTP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	code, err := TOTPCode(secret, now.Add(-totpPeriod))
+	require.NoError(t, err)
+
+	require.True(t, ValidateTOTP(secret, code, now))
+	require.False(t, ValidateTOTP(secret, code, now.Add(totpPeriod*2)))
+	require.False(t, ValidateTOTP(secret, "12345", now))
+}
+
+func Test_MatchTOTP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	secret, err := GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Unix(1_700_000_000, 0)
+
+	code, err := TOTPCode(secret, now.Add(-totpPeriod))
+	require.NoError(t, err)
+
+	// step of code, not current step
+	step, ok := MatchTOTP(secret, code, now)
+	require.True(t, ok)
+	require.Equal(t, now.Unix()/30-1, step)
+
+	_, ok = MatchTOTP(secret, code, now.Add(totpPeriod*2))
+	require.False(t, ok)
+}
+
+func Test_AESCipher_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewAESCipher(make([]byte, 32))
+	require.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("secret"))
+	require.NoError(t, err)
+	require.NotContains(t, string(ciphertext), "secret")
+
+	plaintext, err := c.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(plaintext))
+
+	ciphertext[len(ciphertext)-1] ^= 1
+
+	_, err = c.Decrypt(ciphertext)
+	require.Error(t, err)
+}
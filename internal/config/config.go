@@ -1,8 +1,11 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -16,16 +19,29 @@ type Config struct {
 	Server struct {
 		Address           string        `yaml:"address"`
 		ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
-		Auth              struct {
+		TLS               TLS           `yaml:"tls"`
+		// TrustedProxies addresses or CIDRs of reverse proxies, client address is taken from their headers
+		TrustedProxies []string `yaml:"trusted_proxies"`
+		Auth           struct {
 			JWT struct {
-				PrivateKey string        `yaml:"private_key"`
-				PublicKey  string        `yaml:"public_key"`
-				TTL        time.Duration `yaml:"ttl"`
+				JWTKey       `yaml:",inline"`
+				Keys         []JWTKey      `yaml:"keys"`
+				SigningKeyID string        `yaml:"signing_key_id"`
+				TTL          time.Duration `yaml:"ttl"`
+				RefreshTTL   time.Duration `yaml:"refresh_ttl"`
 			} `yaml:"jwt"`
-			Tokens map[string]struct {
-				Username string   `yaml:"username"`
-				Roles    []string `yaml:"roles"`
-			} `yaml:"tokens"`
+			Tokens         map[string]Token `yaml:"tokens"`
+			TokensFile     string           `yaml:"tokens_file"`
+			PasswordPolicy PasswordPolicy   `yaml:"password_policy"`
+			OIDC           OIDC             `yaml:"oidc"`
+			Login          struct {
+				Backend       string        `yaml:"backend"`
+				FallbackLocal bool          `yaml:"fallback_local"`
+				Throttle      LoginThrottle `yaml:"throttle"`
+			} `yaml:"login"`
+			LDAP       LDAP       `yaml:"ldap"`
+			TOTP       TOTP       `yaml:"totp"`
+			ClientCert ClientCert `yaml:"client_cert"`
 		} `yaml:"auth"`
 		Authorizer struct {
 			Kind string `yaml:"kind"`
@@ -33,10 +49,27 @@ type Config struct {
 				Query      string `yaml:"query"`
 				PolicyPath string `yaml:"policy_path"`
 			} `yaml:"rego"`
+			RBAC struct {
+				// AdminRoles users with these roles are allowed any action
+				AdminRoles []string `yaml:"admin_roles"`
+			} `yaml:"rbac"`
 		} `yaml:"authorizer"`
+		Configs struct {
+			// ReasonRequiredEnvs environment patterns e.g. prod or prod-* where change reason is mandatory
+			ReasonRequiredEnvs []string `yaml:"reason_required_envs"`
+		} `yaml:"configs"`
+		Audit struct {
+			Checkpoints AuditCheckpoints `yaml:"checkpoints"`
+			Sinks       []AuditSink      `yaml:"sinks"`
+			// SinksPollInterval how often new records are checked for sinks
+			SinksPollInterval time.Duration  `yaml:"sinks_poll_interval"`
+			Retention         AuditRetention `yaml:"retention"`
+		} `yaml:"audit"`
 	} `yaml:"server"`
 	Storage struct {
-		DSN string `yaml:"dsn"`
+		DSN         string `yaml:"dsn"`
+		DSNFile     string `yaml:"dsn_file"`
+		AutoMigrate bool   `yaml:"auto_migrate"`
 	} `yaml:"storage"`
 	ValuesStorage struct {
 		Endpoints   []string      `yaml:"endpoints"`
@@ -45,17 +78,487 @@ type Config struct {
 	} `yaml:"values_storage"`
 }
 
-// NewConfigFromFile ...
+// PasswordPolicy requirements for local users passwords, zero min length is default
+type PasswordPolicy struct {
+	MinLength     int  `yaml:"min_length"`
+	RequireUpper  bool `yaml:"require_upper"`
+	RequireLower  bool `yaml:"require_lower"`
+	RequireDigit  bool `yaml:"require_digit"`
+	RequireSymbol bool `yaml:"require_symbol"`
+}
+
+// LoginThrottle failed login attempts limits, zero fields are default
+type LoginThrottle struct {
+	// MaxAttempts failed attempts per username before lockout
+	MaxAttempts int `yaml:"max_attempts"`
+	// MaxAttemptsPerIP failed attempts per client address before lockout
+	MaxAttemptsPerIP int `yaml:"max_attempts_per_ip"`
+	// Window failed attempts are counted within
+	Window time.Duration `yaml:"window"`
+	// LockoutDuration how long login is locked
+	LockoutDuration time.Duration `yaml:"lockout_duration"`
+}
+
+// TOTP two-factor authentication options, enabled if encryption key is set
+type TOTP struct {
+	Issuer            string   `yaml:"issuer"`
+	EncryptionKey     string   `yaml:"encryption_key"`
+	EncryptionKeyFile string   `yaml:"encryption_key_file"`
+	RequiredRoles     []string `yaml:"required_roles"`
+}
+
+// AuditCheckpoints signed audit chain checkpoints, enabled if private key is set
+type AuditCheckpoints struct {
+	// PrivateKey ed25519 key in PEM
+	PrivateKey     string        `yaml:"private_key"`
+	PrivateKeyFile string        `yaml:"private_key_file"`
+	Interval       time.Duration `yaml:"interval"`
+}
+
+// AuditSink export of committed audit records
+type AuditSink struct {
+	// Name identifies delivery position, renamed sink starts from the newest record
+	Name string `yaml:"name"`
+	// Kind file, syslog or webhook
+	Kind string `yaml:"kind"`
+	// Path of JSON lines file
+	Path string `yaml:"path"`
+	// Network of syslog udp, tcp or tls
+	Network  string `yaml:"network"`
+	Address  string `yaml:"address"`
+	Facility string `yaml:"facility"`
+	// URL of webhook
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
+// AuditRetention archival of expired audit records, enabled if keep is set
+type AuditRetention struct {
+	// Keep records younger than window in database
+	Keep     time.Duration `yaml:"keep"`
+	Interval time.Duration `yaml:"interval"`
+	// ArchiveDir directory of compressed JSON lines archives
+	ArchiveDir string `yaml:"archive_dir"`
+}
+
+// TLS listener options, TLS is enabled if cert file is set
+type TLS struct {
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// ClientCAFile verify client certificates if given
+	ClientCAFile      string `yaml:"client_ca_file"`
+	RequireClientCert bool   `yaml:"require_client_cert"`
+	// MinVersion 1.2 or 1.3
+	MinVersion string `yaml:"min_version"`
+}
+
+// ClientCert authentication by verified TLS client certificate
+type ClientCert struct {
+	Enabled bool       `yaml:"enabled"`
+	Rules   []CertRule `yaml:"rules"`
+}
+
+// CertRule maps certificate field matching pattern to username and roles
+type CertRule struct {
+	// Field subject, common_name, dns_san, uri_san or email_san
+	Field   string `yaml:"field"`
+	Pattern string `yaml:"pattern"`
+	// Username template with pattern groups e.g. $1 (full match by default)
+	Username string   `yaml:"username"`
+	Roles    []string `yaml:"roles"`
+}
+
+// OIDC OpenID Connect options
+type OIDC struct {
+	Enabled          bool       `yaml:"enabled"`
+	IssuerURL        string     `yaml:"issuer_url"`
+	ClientID         string     `yaml:"client_id"`
+	ClientSecret     string     `yaml:"client_secret"`
+	ClientSecretFile string     `yaml:"client_secret_file"`
+	RedirectURL      string     `yaml:"redirect_url"`
+	Scopes           []string   `yaml:"scopes"`
+	Audience         string     `yaml:"audience"`
+	UsernameClaim    string     `yaml:"username_claim"`
+	RoleRules        []RoleRule `yaml:"role_rules"`
+	DefaultRoles     []string   `yaml:"default_roles"`
+}
+
+// LDAP login backend options
+type LDAP struct {
+	URL                string              `yaml:"url"`
+	StartTLS           bool                `yaml:"start_tls"`
+	InsecureSkipVerify bool                `yaml:"insecure_skip_verify"`
+	Timeout            time.Duration       `yaml:"timeout"`
+	BindDN             string              `yaml:"bind_dn"`
+	BindPassword       string              `yaml:"bind_password"`
+	BindPasswordFile   string              `yaml:"bind_password_file"`
+	UserDNTemplate     string              `yaml:"user_dn_template"`
+	UserBaseDN         string              `yaml:"user_base_dn"`
+	UserFilter         string              `yaml:"user_filter"`
+	UsernameAttribute  string              `yaml:"username_attribute"`
+	GroupAttribute     string              `yaml:"group_attribute"`
+	GroupRoles         map[string][]string `yaml:"group_roles"`
+	DefaultRoles       []string            `yaml:"default_roles"`
+}
+
+// RoleRule grants roles if external identity claim contains value
+type RoleRule struct {
+	Claim string   `yaml:"claim"`
+	Value string   `yaml:"value"`
+	Roles []string `yaml:"roles"`
+}
+
+// JWTKey RSA key pair, key id is RFC 7638 thumbprint if empty
+type JWTKey struct {
+	ID             string `yaml:"id"`
+	PrivateKey     string `yaml:"private_key"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+	PublicKey      string `yaml:"public_key"`
+	PublicKeyFile  string `yaml:"public_key_file"`
+}
+
+// Token static token options
+type Token struct {
+	Username string   `yaml:"username"`
+	Roles    []string `yaml:"roles"`
+}
+
+// NewConfigFromFile load config from file
+// ${ENV_VAR} references are expanded, *_file options replace related values by secret files content
+// and RTC_ prefixed environment variables override both
 func NewConfigFromFile(path string) (*Config, error) {
 	data, err := os.ReadFile(path) // nolint:gosec
 	if err != nil {
 		return nil, fmt.Errorf("os.ReadFile: %w", err)
 	}
 
-	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
 		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
 	}
 
+	expandEnv(&document)
+
+	var config Config
+	if err := document.Decode(&config); err != nil {
+		return nil, fmt.Errorf("document.Decode: %w", err)
+	}
+
+	// secret file paths may be set by environment too
+	if err := applyEnvOverrides(&config); err != nil {
+		return nil, fmt.Errorf("applyEnvOverrides: %w", err)
+	}
+
+	if err := config.loadSecretFiles(); err != nil {
+		return nil, fmt.Errorf("loadSecretFiles: %w", err)
+	}
+
+	// explicit environment value takes precedence over secret file of config
+	if err := applyEnvOverrides(&config); err != nil {
+		return nil, fmt.Errorf("applyEnvOverrides: %w", err)
+	}
+
 	return &config, nil
 }
+
+func (c *Config) loadSecretFiles() error {
+	jwtOptions := &c.Server.Auth.JWT
+
+	if err := jwtOptions.loadSecretFiles(); err != nil {
+		return err
+	}
+
+	for i := range jwtOptions.Keys {
+		if err := jwtOptions.Keys[i].loadSecretFiles(); err != nil {
+			return fmt.Errorf("keys[%d]: %w", i, err)
+		}
+	}
+
+	oidcOptions := &c.Server.Auth.OIDC
+
+	if err := readSecretFile(oidcOptions.ClientSecretFile, &oidcOptions.ClientSecret); err != nil {
+		return fmt.Errorf("client_secret_file: %w", err)
+	}
+
+	ldapOptions := &c.Server.Auth.LDAP
+
+	if err := readSecretFile(ldapOptions.BindPasswordFile, &ldapOptions.BindPassword); err != nil {
+		return fmt.Errorf("bind_password_file: %w", err)
+	}
+
+	totpOptions := &c.Server.Auth.TOTP
+
+	if err := readSecretFile(totpOptions.EncryptionKeyFile, &totpOptions.EncryptionKey); err != nil {
+		return fmt.Errorf("encryption_key_file: %w", err)
+	}
+
+	checkpointsOptions := &c.Server.Audit.Checkpoints
+
+	if err := readSecretFile(checkpointsOptions.PrivateKeyFile, &checkpointsOptions.PrivateKey); err != nil {
+		return fmt.Errorf("audit.checkpoints.private_key_file: %w", err)
+	}
+
+	if err := readSecretFile(c.Storage.DSNFile, &c.Storage.DSN); err != nil {
+		return fmt.Errorf("dsn_file: %w", err)
+	}
+
+	if c.Server.Auth.TokensFile != "" {
+		var tokens string
+		if err := readSecretFile(c.Server.Auth.TokensFile, &tokens); err != nil {
+			return fmt.Errorf("tokens_file: %w", err)
+		}
+
+		if err := yaml.Unmarshal([]byte(tokens), &c.Server.Auth.Tokens); err != nil {
+			return fmt.Errorf("tokens_file: yaml.Unmarshal: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (k *JWTKey) loadSecretFiles() error {
+	if err := readSecretFile(k.PrivateKeyFile, &k.PrivateKey); err != nil {
+		return fmt.Errorf("private_key_file: %w", err)
+	}
+
+	if err := readSecretFile(k.PublicKeyFile, &k.PublicKey); err != nil {
+		return fmt.Errorf("public_key_file: %w", err)
+	}
+
+	return nil
+}
+
+func readSecretFile(path string, target *string) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	*target = strings.TrimSpace(string(data))
+
+	return nil
+}
+
+// Validate checks required options
+func (c *Config) Validate() error {
+	if c.Server.Auth.JWT.PrivateKey == "" {
+		return errors.New("server.auth.jwt.private_key is required")
+	}
+
+	if c.Server.Auth.JWT.PublicKey == "" {
+		return errors.New("server.auth.jwt.public_key is required")
+	}
+
+	for i, key := range c.Server.Auth.JWT.Keys {
+		if key.ID == "" {
+			return fmt.Errorf("server.auth.jwt.keys[%d].id is required", i)
+		}
+
+		if key.PublicKey == "" {
+			return fmt.Errorf("server.auth.jwt.keys[%d].public_key is required", i)
+		}
+	}
+
+	if err := c.Server.TLS.validate(); err != nil {
+		return fmt.Errorf("server.tls: %w", err)
+	}
+
+	if err := c.Server.Auth.ClientCert.validate(); err != nil {
+		return fmt.Errorf("server.auth.client_cert: %w", err)
+	}
+
+	if c.Server.Auth.ClientCert.Enabled && c.Server.TLS.ClientCAFile == "" {
+		return errors.New("server.tls.client_ca_file is required for client_cert authentication")
+	}
+
+	if err := c.Server.Auth.OIDC.validate(); err != nil {
+		return fmt.Errorf("server.auth.oidc: %w", err)
+	}
+
+	if len(c.Server.Auth.TOTP.RequiredRoles) > 0 && c.Server.Auth.TOTP.EncryptionKey == "" {
+		return errors.New("server.auth.totp.encryption_key is required for required_roles")
+	}
+
+	switch c.Server.Auth.Login.Backend {
+	case "", "local":
+	case "ldap":
+		if err := c.Server.Auth.LDAP.validate(); err != nil {
+			return fmt.Errorf("server.auth.ldap: %w", err)
+		}
+	default:
+		return fmt.Errorf("server.auth.login.backend: unknown backend %q", c.Server.Auth.Login.Backend)
+	}
+
+	switch c.Server.Authorizer.Kind {
+	case "noop", "rbac":
+	case "rego":
+		if c.Server.Authorizer.Rego.Query == "" {
+			return errors.New("server.authorizer.rego.query is required")
+		}
+
+		if c.Server.Authorizer.Rego.PolicyPath == "" {
+			return errors.New("server.authorizer.rego.policy_path is required")
+		}
+	default:
+		return fmt.Errorf("server.authorizer.kind: unknown authorizer %q", c.Server.Authorizer.Kind)
+	}
+
+	for i, pattern := range c.Server.Configs.ReasonRequiredEnvs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("server.configs.reason_required_envs[%d]: %w", i, err)
+		}
+	}
+
+	names := make(map[string]struct{}, len(c.Server.Audit.Sinks))
+	for i, sink := range c.Server.Audit.Sinks {
+		if err := sink.validate(); err != nil {
+			return fmt.Errorf("server.audit.sinks[%d]: %w", i, err)
+		}
+
+		if _, ok := names[sink.Name]; ok {
+			return fmt.Errorf("server.audit.sinks[%d]: duplicate name %q", i, sink.Name)
+		}
+
+		names[sink.Name] = struct{}{}
+	}
+
+	if c.Server.Audit.Retention.Keep < 0 {
+		return errors.New("server.audit.retention.keep must be positive")
+	}
+
+	if c.Server.Audit.Retention.Keep > 0 && c.Server.Audit.Retention.ArchiveDir == "" {
+		return errors.New("server.audit.retention.archive_dir is required")
+	}
+
+	if c.Storage.DSN == "" {
+		return errors.New("storage.dsn is required")
+	}
+
+	if len(c.ValuesStorage.Endpoints) == 0 {
+		return errors.New("values_storage.endpoints is required")
+	}
+
+	return nil
+}
+
+func (s *AuditSink) validate() error {
+	if s.Name == "" {
+		return errors.New("name is required")
+	}
+
+	switch s.Kind {
+	case "file":
+		if s.Path == "" {
+			return errors.New("path is required")
+		}
+	case "syslog":
+		switch s.Network {
+		case "udp", "tcp", "tls":
+		default:
+			return fmt.Errorf("network: unknown network %q", s.Network)
+		}
+
+		if s.Address == "" {
+			return errors.New("address is required")
+		}
+	case "webhook":
+		if s.URL == "" {
+			return errors.New("url is required")
+		}
+	default:
+		return fmt.Errorf("kind: unknown sink %q", s.Kind)
+	}
+
+	return nil
+}
+
+func (o *OIDC) validate() error {
+	if !o.Enabled {
+		return nil
+	}
+
+	if o.IssuerURL == "" {
+		return errors.New("issuer_url is required")
+	}
+
+	if o.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+
+	if o.RedirectURL == "" {
+		return errors.New("redirect_url is required")
+	}
+
+	for i, rule := range o.RoleRules {
+		if rule.Claim == "" || len(rule.Roles) == 0 {
+			return fmt.Errorf("role_rules[%d]: claim and roles are required", i)
+		}
+	}
+
+	return nil
+}
+
+func (t *TLS) validate() error {
+	if t.CertFile == "" {
+		if t.KeyFile != "" || t.ClientCAFile != "" {
+			return errors.New("cert_file is required")
+		}
+
+		return nil
+	}
+
+	if t.KeyFile == "" {
+		return errors.New("key_file is required")
+	}
+
+	if t.RequireClientCert && t.ClientCAFile == "" {
+		return errors.New("client_ca_file is required for require_client_cert")
+	}
+
+	switch t.MinVersion {
+	case "", "1.2", "1.3":
+	default:
+		return fmt.Errorf("min_version: unsupported version %q", t.MinVersion)
+	}
+
+	return nil
+}
+
+func (c *ClientCert) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if len(c.Rules) == 0 {
+		return errors.New("rules is required")
+	}
+
+	for i, rule := range c.Rules {
+		if rule.Field == "" {
+			return fmt.Errorf("rules[%d].field is required", i)
+		}
+
+		if rule.Pattern == "" {
+			return fmt.Errorf("rules[%d].pattern is required", i)
+		}
+	}
+
+	return nil
+}
+
+func (l *LDAP) validate() error {
+	if l.URL == "" {
+		return errors.New("url is required")
+	}
+
+	if l.UserDNTemplate == "" && (l.UserBaseDN == "" || l.UserFilter == "") {
+		return errors.New("user_dn_template or user_base_dn with user_filter are required")
+	}
+
+	return nil
+}
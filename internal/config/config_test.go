@@ -0,0 +1,152 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func Test_NewConfigFromFile_ExpandEnv_ExpectOk(t *testing.T) {
+	t.Setenv("TEST_RTC_DSN", "postgres://from-env")
+
+	path := writeFile(t, "config.yaml", `
+server:
+  address: ${TEST_RTC_ADDRESS:-:9090}
+storage:
+  dsn: ${TEST_RTC_DSN}
+`)
+
+	got, err := NewConfigFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, ":9090", got.Server.Address)
+	require.Equal(t, "postgres://from-env", got.Storage.DSN)
+}
+
+func Test_NewConfigFromFile_ExpandEnvMultiline_ExpectOk(t *testing.T) {
+	t.Setenv("TEST_RTC_PRIVATE_KEY", "-----BEGIN KEY-----\nkey: value # not a comment\n-----END KEY-----\n")
+	t.Setenv("TEST_RTC_LEVEL", "4")
+
+	path := writeFile(t, "config.yaml", `
+# ${TEST_RTC_PRIVATE_KEY} in comment is ignored
+logging:
+  level: ${TEST_RTC_LEVEL}
+server:
+  auth:
+    jwt:
+      private_key: ${TEST_RTC_PRIVATE_KEY}
+      public_key: "${TEST_RTC_MISSING:-a: b}"
+`)
+
+	got, err := NewConfigFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 4, got.Logging.Level)
+	require.Equal(t, "-----BEGIN KEY-----\nkey: value # not a comment\n-----END KEY-----\n", got.Server.Auth.JWT.PrivateKey)
+	require.Equal(t, "a: b", got.Server.Auth.JWT.PublicKey)
+}
+
+func Test_NewConfigFromFile_EnvOverrides_ExpectOk(t *testing.T) {
+	t.Setenv("RTC_LOGGING_LEVEL", "8")
+	t.Setenv("RTC_SERVER_READ_HEADER_TIMEOUT", "10s")
+	t.Setenv("RTC_SERVER_AUTH_JWT_PRIVATE_KEY", "private")
+	t.Setenv("RTC_SERVER_AUTH_TOKENS", "{secret: {username: ci, roles: [admin]}}")
+	t.Setenv("RTC_STORAGE_AUTO_MIGRATE", "true")
+	t.Setenv("RTC_VALUES_STORAGE_ENDPOINTS", "etcd-1:2379, etcd-2:2379")
+
+	path := writeFile(t, "config.yaml", `
+logging:
+  level: -4
+server:
+  read_header_timeout: 3s
+values_storage:
+  endpoints:
+    - 127.0.0.1:2379
+`)
+
+	got, err := NewConfigFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, 8, got.Logging.Level)
+	require.Equal(t, 10*time.Second, got.Server.ReadHeaderTimeout)
+	require.Equal(t, "private", got.Server.Auth.JWT.PrivateKey)
+	require.Equal(t, map[string]Token{"secret": {Username: "ci", Roles: []string{"admin"}}}, got.Server.Auth.Tokens)
+	require.True(t, got.Storage.AutoMigrate)
+	require.Equal(t, []string{"etcd-1:2379", "etcd-2:2379"}, got.ValuesStorage.Endpoints)
+}
+
+func Test_NewConfigFromFile_InvalidEnvOverride_ExpectErr(t *testing.T) {
+	t.Setenv("RTC_SERVER_READ_HEADER_TIMEOUT", "soon")
+
+	path := writeFile(t, "config.yaml", "server: {}")
+
+	got, err := NewConfigFromFile(path)
+	require.Nil(t, got)
+	require.ErrorContains(t, err, "RTC_SERVER_READ_HEADER_TIMEOUT")
+}
+
+func Test_NewConfigFromFile_SecretFiles_ExpectOk(t *testing.T) {
+	privateKeyPath := writeFile(t, "private.pem", "private\n")
+	publicKeyPath := writeFile(t, "public.pem", "public\n")
+	dsnPath := writeFile(t, "dsn", "postgres://from-file\n")
+	tokensPath := writeFile(t, "tokens.yaml", "secret:\n  username: ci\n")
+
+	t.Setenv("RTC_STORAGE_DSN_FILE", dsnPath)
+
+	path := writeFile(t, "config.yaml", `
+server:
+  auth:
+    jwt:
+      private_key_file: `+privateKeyPath+`
+      keys:
+        - id: next
+          public_key_file: `+publicKeyPath+`
+    tokens_file: `+tokensPath+`
+storage:
+  dsn: postgres://from-config
+`)
+
+	got, err := NewConfigFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "private", got.Server.Auth.JWT.PrivateKey)
+	require.Equal(t, []JWTKey{{ID: "next", PublicKey: "public", PublicKeyFile: publicKeyPath}}, got.Server.Auth.JWT.Keys)
+	require.Equal(t, "postgres://from-file", got.Storage.DSN)
+	require.Equal(t, map[string]Token{"secret": {Username: "ci"}}, got.Server.Auth.Tokens)
+}
+
+func Test_NewConfigFromFile_EnvOverridesSecretFile_ExpectOk(t *testing.T) {
+	dsnPath := writeFile(t, "dsn", "postgres://from-file\n")
+
+	t.Setenv("RTC_STORAGE_DSN", "postgres://from-env")
+
+	path := writeFile(t, "config.yaml", `
+storage:
+  dsn_file: `+dsnPath+`
+`)
+
+	got, err := NewConfigFromFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "postgres://from-env", got.Storage.DSN)
+}
+
+func Test_Validate_InvalidReasonRequiredEnvs_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	var config Config
+	config.Server.Auth.JWT.PrivateKey = "private"
+	config.Server.Auth.JWT.PublicKey = "public"
+	config.Server.Authorizer.Kind = "noop"
+	config.Server.Configs.ReasonRequiredEnvs = []string{"prod", "prod-["}
+
+	err := config.Validate()
+	require.ErrorContains(t, err, "server.configs.reason_required_envs[1]")
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const envPrefix = "RTC"
+
+// matches ${ENV_VAR} and ${ENV_VAR:-default}
+var envReferenceRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replace ${ENV_VAR} references in string scalars of parsed document,
+// so values may contain newlines or YAML syntax and comments are left as is
+func expandEnv(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str" {
+		expanded := envReferenceRegexp.ReplaceAllStringFunc(node.Value, func(match string) string {
+			groups := envReferenceRegexp.FindStringSubmatch(match)
+
+			value, ok := os.LookupEnv(groups[1])
+			if !ok || value == "" {
+				return groups[2]
+			}
+
+			return value
+		})
+
+		if expanded != node.Value {
+			node.Value = expanded
+			// plain scalars are resolved again e.g. port: ${PORT} is decoded as int
+			if node.Style == 0 {
+				node.Tag = ""
+			}
+		}
+	}
+
+	for _, child := range node.Content {
+		expandEnv(child)
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnvOverrides set config fields from RTC_ prefixed environment variables
+// name is built from yaml path e.g. server.auth.jwt.ttl -> RTC_SERVER_AUTH_JWT_TTL
+func applyEnvOverrides(target any) error {
+	return applyEnvOverridesValue(reflect.ValueOf(target).Elem(), envPrefix)
+}
+
+func applyEnvOverridesValue(value reflect.Value, prefix string) error {
+	valueType := value.Type()
+
+	for i := range valueType.NumField() {
+		field := valueType.Field(i)
+
+		tag, tagOptions, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		fieldValue := value.Field(i)
+
+		// inline struct fields share parent prefix
+		if tagOptions == "inline" && field.Type.Kind() == reflect.Struct {
+			if err := applyEnvOverridesValue(fieldValue, prefix); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		envName := prefix + "_" + strings.ToUpper(tag)
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnvOverridesValue(fieldValue, envName); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		raw, ok := os.LookupEnv(envName)
+		if !ok {
+			continue
+		}
+
+		if err := setFromString(fieldValue, raw); err != nil {
+			return fmt.Errorf("%s: %w", envName, err)
+		}
+	}
+
+	return nil
+}
+
+func setFromString(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("time.ParseDuration: %w", err)
+		}
+
+		value.SetInt(int64(duration))
+
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		boolValue, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("strconv.ParseBool: %w", err)
+		}
+
+		value.SetBool(boolValue)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		intValue, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("strconv.ParseInt: %w", err)
+		}
+
+		value.SetInt(intValue)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return unmarshalYAML(value, raw)
+		}
+
+		items := strings.Split(raw, ",")
+		slice := reflect.MakeSlice(value.Type(), 0, len(items))
+
+		for _, item := range items {
+			if item = strings.TrimSpace(item); item != "" {
+				slice = reflect.Append(slice, reflect.ValueOf(item).Convert(value.Type().Elem()))
+			}
+		}
+
+		value.Set(slice)
+	default:
+		return unmarshalYAML(value, raw)
+	}
+
+	return nil
+}
+
+// unmarshalYAML used for complex values like maps e.g. RTC_SERVER_AUTH_TOKENS='{token: {username: ci}}'
+func unmarshalYAML(value reflect.Value, raw string) error {
+	if err := yaml.Unmarshal([]byte(raw), value.Addr().Interface()); err != nil {
+		return fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	return nil
+}
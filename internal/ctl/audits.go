@@ -0,0 +1,257 @@
+package ctl
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DesSolo/rtc/internal/auditchain"
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func newAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "audits",
+		Aliases: []string{"audit"},
+		Short:   "audit log",
+	}
+
+	cmd.AddCommand(
+		newListAuditsCommand(),
+		newVerifyAuditCommand(),
+		newImportAuditCommand(),
+	)
+
+	return cmd
+}
+
+func newListAuditsCommand() *cobra.Command {
+	var (
+		filter   client.AuditFilter
+		from, to string
+	)
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "search audit records, newest first",
+		Annotations: map[string]string{skipContextDefaults: ""},
+		Example: "  rtcctl audits list --project example --key max_conns --from 2025-10-01T00:00:00Z\n" +
+			"  rtcctl audits list --action user_login_failed -o json",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			var err error
+
+			if filter.From, err = parseOptionalTime(from); err != nil {
+				return fmt.Errorf("invalid --from: %w", err)
+			}
+
+			if filter.To, err = parseOptionalTime(to); err != nil {
+				return fmt.Errorf("invalid --to: %w", err)
+			}
+
+			page, err := clientFromContext(ctx).ListAudits(ctx, &filter)
+			if err != nil {
+				return fmt.Errorf("client.ListAudits: %w", err)
+			}
+
+			if err := render(ctx, cmd.OutOrStdout(), page, func(w io.Writer) {
+				fmt.Fprintln(w, "ID\tTS\tACTION\tACTOR\tPAYLOAD")
+
+				for _, audit := range page.Audits {
+					payload, _ := json.Marshal(audit.Payload)
+
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+						audit.ID,
+						audit.Ts.Format("2006-01-02 15:04:05"),
+						audit.Action,
+						audit.Actor,
+						payload,
+					)
+				}
+
+			}); err != nil {
+				return err
+			}
+
+			if page.NextCursor != "" {
+				fmt.Fprintf(os.Stderr, "more records, use --cursor %s for next page\n", page.NextCursor)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&filter.Action, "action", "", "Action e.g. config_updated")
+	cmd.Flags().StringVar(&filter.Actor, "actor", "", "Actor username")
+	cmd.Flags().StringVarP(&filter.Project, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&filter.Env, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&filter.Release, "release", "r", "", "Release")
+	cmd.Flags().StringVar(&filter.Key, "key", "", "Config key")
+	cmd.Flags().StringVar(&from, "from", "", "Records since RFC3339 time (last day by default)")
+	cmd.Flags().StringVar(&to, "to", "", "Records until RFC3339 time (now by default)")
+	cmd.Flags().StringVar(&filter.Cursor, "cursor", "", "Cursor of next page")
+	cmd.Flags().Uint64Var(&filter.Limit, "limit", 0, "Max records to show (server default if not set)")
+
+	return cmd
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value) // nolint:wrapcheck
+}
+
+func newVerifyAuditCommand() *cobra.Command {
+	var publicKeyPath string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "verify audit log hash chain and checkpoints",
+		Example: "  rtcctl audits verify\n" +
+			"  rtcctl audits verify --public-key audit.pub",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			result, err := clientFromContext(ctx).VerifyAudits(ctx)
+			if err != nil {
+				return fmt.Errorf("client.VerifyAudits: %w", err)
+			}
+
+			if err := render(ctx, cmd.OutOrStdout(), result, func(w io.Writer) {
+				printAuditChainVerification(w, result)
+			}); err != nil {
+				return err
+			}
+
+			if publicKeyPath != "" {
+				if err := verifyLatestCheckpoint(publicKeyPath, result.LatestCheckpoint); err != nil {
+					return fmt.Errorf("latest checkpoint: %w", err)
+				}
+
+				fmt.Fprintf(os.Stderr, "latest checkpoint signature is valid\n")
+			}
+
+			if !result.Valid {
+				return fmt.Errorf("audit log is broken, %d problems found", len(result.Problems))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&publicKeyPath, "public-key", "", "Path to ed25519 public key PEM to verify latest checkpoint locally")
+
+	return cmd
+}
+
+func newImportAuditCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "import <archive.jsonl.gz>",
+		Short:   "load archived records back to audit log",
+		Example: "  rtcctl audits import /var/lib/rtc/audit/audit-0000000001-0000100000.jsonl.gz",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("os.Open: %w", err)
+			}
+			defer file.Close()
+
+			imported, err := clientFromContext(ctx).ImportAudits(ctx, file, filepath.Base(args[0]))
+			if err != nil {
+				return fmt.Errorf("client.ImportAudits: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), imported, func(w io.Writer) {
+				fmt.Fprintf(w, "imported %d records of %d-%d\n", imported.Records, imported.FirstID, imported.LastID)
+			})
+		},
+	}
+}
+
+func printAuditChainVerification(w io.Writer, result *client.AuditChainVerification) {
+	status := "valid"
+	if !result.Valid {
+		status = "broken"
+	}
+
+	fmt.Fprintf(w, "status:      %s\n", status)
+	fmt.Fprintf(w, "checked:     %d\n", result.Checked)
+	fmt.Fprintf(w, "legacy:      %d\n", result.Legacy)
+	fmt.Fprintf(w, "archived:    %d\n", result.Archived)
+	fmt.Fprintf(w, "last id:     %d\n", result.LastID)
+	fmt.Fprintf(w, "last hash:   %s\n", result.LastHash)
+	fmt.Fprintf(w, "checkpoints: %d (signatures verified: %t)\n", result.Checkpoints, result.SignaturesVerified)
+
+	if checkpoint := result.LatestCheckpoint; checkpoint != nil {
+		fmt.Fprintf(w, "latest checkpoint: id %d, record %d at %s\n", checkpoint.ID, checkpoint.LastID, checkpoint.Ts.Format("2006-01-02 15:04:05"))
+	}
+
+	for _, problem := range result.Problems {
+		if problem.CheckpointID != 0 {
+			fmt.Fprintf(w, "    checkpoint %d: %s: %s\n", problem.CheckpointID, problem.Kind, problem.Message)
+			continue
+		}
+
+		fmt.Fprintf(w, "    record %d: %s: %s\n", problem.ID, problem.Kind, problem.Message)
+	}
+}
+
+// verifyLatestCheckpoint check signature without trusting server
+func verifyLatestCheckpoint(publicKeyPath string, checkpoint *client.AuditCheckpoint) error {
+	if checkpoint == nil {
+		return errors.New("no checkpoints")
+	}
+
+	data, err := os.ReadFile(publicKeyPath) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return errors.New("pem block is not found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("x509.ParsePKIXPublicKey: %w", err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return fmt.Errorf("unexpected key type %T, ed25519 is required", key)
+	}
+
+	hash, err := hex.DecodeString(checkpoint.Hash)
+	if err != nil {
+		return fmt.Errorf("hex.DecodeString: %w", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(checkpoint.Signature)
+	if err != nil {
+		return fmt.Errorf("base64.DecodeString: %w", err)
+	}
+
+	if !auditchain.VerifyCheckpoint(publicKey, checkpoint.LastID, hash, signature) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Audit ...
+type Audit struct {
+	ID      uint64 `json:"id"`
+	Action  string `json:"action"`
+	Actor   string `json:"actor"`
+	Payload any    `json:"payload"`
+	Request *struct {
+		ID        string `json:"id,omitempty"`
+		IP        string `json:"ip,omitempty"`
+		UserAgent string `json:"user_agent,omitempty"`
+	} `json:"request,omitempty"`
+	Ts time.Time `json:"ts"`
+}
+
+// AuditFilter empty fields are not filtered, dates are last day on server by default
+type AuditFilter struct {
+	Action  string
+	Actor   string
+	Project string
+	Env     string
+	Release string
+	Key     string
+	From    time.Time
+	To      time.Time
+	Cursor  string
+	Limit   uint64
+}
+
+// AuditsPage ...
+type AuditsPage struct {
+	Audits []*Audit `json:"audits"`
+	// NextCursor is empty on last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListAudits search audit records, newest first
+func (c *Client) ListAudits(ctx context.Context, filter *AuditFilter) (*AuditsPage, error) {
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"action":  filter.Action,
+		"actor":   filter.Actor,
+		"project": filter.Project,
+		"env":     filter.Env,
+		"release": filter.Release,
+		"key":     filter.Key,
+		"cursor":  filter.Cursor,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	if !filter.From.IsZero() {
+		query.Set("from", filter.From.Format(time.RFC3339))
+	}
+
+	if !filter.To.IsZero() {
+		query.Set("to", filter.To.Format(time.RFC3339))
+	}
+
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.FormatUint(filter.Limit, 10))
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodGet, "/audits?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *AuditsPage `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// AuditChainProblem ...
+type AuditChainProblem struct {
+	ID           uint64 `json:"id"`
+	CheckpointID uint64 `json:"checkpoint_id"`
+	Kind         string `json:"kind"`
+	Message      string `json:"message"`
+}
+
+// AuditCheckpoint signed hash of audit chain record
+type AuditCheckpoint struct {
+	ID     uint64 `json:"id"`
+	LastID uint64 `json:"last_id"`
+	// Hash hex encoded
+	Hash string `json:"hash"`
+	// Signature base64 encoded
+	Signature string    `json:"signature"`
+	Ts        time.Time `json:"ts"`
+}
+
+// AuditChainVerification ...
+type AuditChainVerification struct {
+	Valid              bool                 `json:"valid"`
+	Checked            uint64               `json:"checked"`
+	Legacy             uint64               `json:"legacy"`
+	Archived           uint64               `json:"archived"`
+	LastID             uint64               `json:"last_id"`
+	LastHash           string               `json:"last_hash"`
+	SignaturesVerified bool                 `json:"signatures_verified"`
+	Checkpoints        uint64               `json:"checkpoints"`
+	LatestCheckpoint   *AuditCheckpoint     `json:"latest_checkpoint"`
+	Problems           []*AuditChainProblem `json:"problems"`
+}
+
+// VerifyAudits verify audit log hash chain on server
+func (c *Client) VerifyAudits(ctx context.Context) (*AuditChainVerification, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodGet, "/audits/verify", nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *AuditChainVerification `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// AuditArchive range of archived records
+type AuditArchive struct {
+	FirstID  uint64 `json:"first_id"`
+	LastID   uint64 `json:"last_id"`
+	LastHash string `json:"last_hash"`
+	Records  uint64 `json:"records"`
+	File     string `json:"file"`
+}
+
+// ImportAudits load gzip compressed archive back to audit log
+func (c *Client) ImportAudits(ctx context.Context, archive io.Reader, file string) (*AuditArchive, error) {
+	query := url.Values{}
+	query.Set("file", file)
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/audits/import?"+query.Encode(), archive)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/gzip")
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *AuditArchive `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
@@ -11,19 +11,29 @@ type httpClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// Kinds of Authorization header
+const (
+	// AuthToken API token
+	AuthToken = "token"
+	// AuthJWT access token of login session
+	AuthJWT = "jwt"
+)
+
 // Client ...
 type Client struct {
-	url    string
-	token  string
-	client httpClient
+	url      string
+	authKind string
+	token    string
+	client   httpClient
 }
 
-// NewClient ...
-func NewClient(url, token string) *Client {
+// NewClient requests are not authorized if token is empty
+func NewClient(url, authKind, token string) *Client {
 	return &Client{
-		url:    url,
-		token:  token,
-		client: http.DefaultClient,
+		url:      url,
+		authKind: authKind,
+		token:    token,
+		client:   http.DefaultClient,
 	}
 }
 
@@ -33,7 +43,9 @@ func (c *Client) newRequest(ctx context.Context, method, uri string, body io.Rea
 		return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("token %s", c.token))
+	if c.token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("%s %s", c.authKind, c.token))
+	}
 
 	return req, nil
 }
@@ -0,0 +1,236 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Client_Requests_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, c *Client) error
+		method   string
+		uri      string
+		body     string
+		status   int
+		response string
+	}{
+		{
+			name: "list projects",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ListProjects(ctx, "ex", 10, 20)
+				return err
+			},
+			method:   http.MethodGet,
+			uri:      "/api/v1/projects?limit=10&offset=20&q=ex",
+			status:   http.StatusOK,
+			response: `{"data":{"projects":[],"total":0}}`,
+		},
+		{
+			name: "create project",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.CreateProject(ctx, &CreateProjectRequest{Name: "example", Description: "example service"})
+				return err
+			},
+			method:   http.MethodPost,
+			uri:      "/api/v1/projects",
+			body:     `{"name":"example","description":"example service"}`,
+			status:   http.StatusCreated,
+			response: `{"data":{"project":{"name":"example"}}}`,
+		},
+		{
+			name: "update project",
+			call: func(ctx context.Context, c *Client) error {
+				return c.UpdateProject(ctx, "example", "new description")
+			},
+			method: http.MethodPut,
+			uri:    "/api/v1/projects/example",
+			body:   `{"description":"new description"}`,
+			status: http.StatusNoContent,
+		},
+		{
+			name: "delete project",
+			call: func(ctx context.Context, c *Client) error {
+				return c.DeleteProject(ctx, "example")
+			},
+			method: http.MethodDelete,
+			uri:    "/api/v1/projects/example",
+			status: http.StatusNoContent,
+		},
+		{
+			name: "list releases",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ListReleases(ctx, "example", "prod")
+				return err
+			},
+			method:   http.MethodGet,
+			uri:      "/api/v1/projects/example/envs/prod/releases",
+			status:   http.StatusOK,
+			response: `{"data":{"releases":[]}}`,
+		},
+		{
+			name: "delete release",
+			call: func(ctx context.Context, c *Client) error {
+				return c.DeleteRelease(ctx, "example", "prod", "v1")
+			},
+			method: http.MethodDelete,
+			uri:    "/api/v1/projects/example/envs/prod/releases/v1",
+			status: http.StatusNoContent,
+		},
+		{
+			name: "list users",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ListUsers(ctx, "", 50, 0)
+				return err
+			},
+			method:   http.MethodGet,
+			uri:      "/api/v1/users?limit=50&offset=0&q=",
+			status:   http.StatusOK,
+			response: `{"data":{"users":[],"total":0}}`,
+		},
+		{
+			name: "create user",
+			call: func(ctx context.Context, c *Client) error {
+				return c.CreateUser(ctx, &CreateUserRequest{Username: "bob", Password: "secret", IsEnabled: true, Roles: []string{"viewer"}})
+			},
+			method: http.MethodPost,
+			uri:    "/api/v1/users",
+			body:   `{"username":"bob","password":"secret","is_enabled":true,"roles":["viewer"]}`,
+			status: http.StatusCreated,
+		},
+		{
+			name: "update user",
+			call: func(ctx context.Context, c *Client) error {
+				return c.UpdateUser(ctx, "bob", &UpdateUserRequest{Roles: []string{"admin"}})
+			},
+			method: http.MethodPatch,
+			uri:    "/api/v1/users/bob",
+			body:   `{"roles":["admin"]}`,
+			status: http.StatusOK,
+		},
+		{
+			name: "reset password",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ResetPassword(ctx, "bob")
+				return err
+			},
+			method:   http.MethodPost,
+			uri:      "/api/v1/users/bob/password/reset",
+			status:   http.StatusOK,
+			response: `{"data":{"password":"one-time"}}`,
+		},
+		{
+			name: "reset totp",
+			call: func(ctx context.Context, c *Client) error {
+				return c.ResetTOTP(ctx, "bob")
+			},
+			method: http.MethodDelete,
+			uri:    "/api/v1/users/bob/totp",
+			status: http.StatusNoContent,
+		},
+		{
+			name: "upsert configs",
+			call: func(ctx context.Context, c *Client) error {
+				return c.UpsertConfigs(ctx, "example", "prod", "v1", []*UpsertConfigRequest{}, false, ChangeReason{Reason: "release v1", Ticket: "OPS-42"})
+			},
+			method: http.MethodPost,
+			uri:    "/api/v1/projects/example/envs/prod/releases/v1/configs?prune=false&reason=release+v1&ticket=OPS-42",
+			body:   `[]`,
+			status: http.StatusCreated,
+		},
+		{
+			name: "plan configs",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.PlanConfigs(ctx, "example", "prod", "v1", []*UpsertConfigRequest{}, true)
+				return err
+			},
+			method:   http.MethodPost,
+			uri:      "/api/v1/projects/example/envs/prod/releases/v1/configs?dry_run=true",
+			body:     `[]`,
+			status:   http.StatusOK,
+			response: `{"data":{"added":[]}}`,
+		},
+		{
+			name: "set config values",
+			call: func(ctx context.Context, c *Client) error {
+				return c.SetConfigValues(ctx, "example", "prod", "v1", map[string]string{"max_conns": "20"}, ChangeReason{})
+			},
+			method: http.MethodPut,
+			uri:    "/api/v1/projects/example/envs/prod/releases/v1/configs",
+			body:   `{"max_conns":"20"}`,
+			status: http.StatusCreated,
+		},
+		{
+			name: "config history",
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ConfigHistory(ctx, "example", "prod", "v1", "a/b", 5)
+				return err
+			},
+			method:   http.MethodGet,
+			uri:      "/api/v1/projects/example/envs/prod/releases/v1/configs/a%2Fb/history?limit=5",
+			status:   http.StatusOK,
+			response: `{"data":{"history":[]}}`,
+		},
+		{
+			name: "revoke token",
+			call: func(ctx context.Context, c *Client) error {
+				return c.RevokeToken(ctx, 42)
+			},
+			method: http.MethodDelete,
+			uri:    "/api/v1/tokens/42",
+			status: http.StatusNoContent,
+		},
+		{
+			name: "remove member",
+			call: func(ctx context.Context, c *Client) error {
+				return c.RemoveMember(ctx, "example", 7)
+			},
+			method: http.MethodDelete,
+			uri:    "/api/v1/projects/example/members/7",
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err, tt.name)
+
+			require.Equal(t, tt.method, r.Method, tt.name)
+			require.Equal(t, tt.uri, r.RequestURI, tt.name)
+			require.Equal(t, "token secret", r.Header.Get("Authorization"), tt.name)
+
+			if tt.body != "" {
+				require.JSONEq(t, tt.body, string(body), tt.name)
+			}
+
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte(tt.response))
+		}))
+
+		err := tt.call(context.Background(), NewClient(srv.URL+"/api/v1", AuthToken, "secret"))
+		require.NoError(t, err, tt.name)
+
+		srv.Close()
+	}
+}
+
+func Test_Client_UnexpectedStatus_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":"forbidden"}`))
+	}))
+	defer srv.Close()
+
+	err := NewClient(srv.URL, AuthToken, "").DeleteProject(context.Background(), "example")
+	require.EqualError(t, err, `do: client.Do: invalid status code: 403 message: {"error":"forbidden"}`)
+}
@@ -2,10 +2,56 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 )
 
+// Config ...
+type Config struct {
+	Key       string `json:"key"`
+	ValueType string `json:"value_type"`
+	Value     string `json:"value"`
+	Group     string `json:"group"`
+	Usage     string `json:"usage"`
+	Writable  bool   `json:"writable"`
+	View      struct {
+		Enum string `json:"enum,omitempty"`
+	} `json:"view"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ListConfigs configs of release
+func (c *Client) ListConfigs(ctx context.Context, projectName, envName, releaseName string) ([]*Config, error) {
+	uri := fmt.Sprintf("/projects/%s/envs/%s/releases/%s/configs", projectName, envName, releaseName)
+
+	httpReq, err := c.newRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Configs []*Config `json:"configs"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Configs, nil
+}
+
 // UpsertConfigRequest ...
 type UpsertConfigRequest struct {
 	Key       string `json:"key"`
@@ -16,13 +62,202 @@ type UpsertConfigRequest struct {
 	Writable  bool   `json:"writable"`
 }
 
-// UpsertConfigs ...
-func (c *Client) UpsertConfigs(ctx context.Context, projectName, envName, releaseName string, req []*UpsertConfigRequest) error {
+// UpsertConfigs keys missing in request are deleted unless prune is disabled
+func (c *Client) UpsertConfigs(ctx context.Context, projectName, envName, releaseName string, req []*UpsertConfigRequest, prune bool, reason ChangeReason) error {
+	httpReq, err := c.newUpsertConfigsRequest(ctx, projectName, envName, releaseName, req, prune, false, reason)
+	if err != nil {
+		return fmt.Errorf("newUpsertConfigsRequest: %w", err)
+	}
+
+	if _, err := c.do(httpReq, http.StatusCreated); err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+
+	return nil
+}
+
+// ConfigPlanMetadata ...
+type ConfigPlanMetadata struct {
+	Group    string `json:"group"`
+	Usage    string `json:"usage"`
+	Writable bool   `json:"writable"`
+	View     struct {
+		Enum string `json:"enum,omitempty"`
+	} `json:"view"`
+}
+
+// ConfigPlanItem planned change of key, old fields are empty for added keys and new for removed
+type ConfigPlanItem struct {
+	Key         string              `json:"key"`
+	OldType     string              `json:"old_type,omitempty"`
+	NewType     string              `json:"new_type,omitempty"`
+	OldMetadata *ConfigPlanMetadata `json:"old_metadata,omitempty"`
+	NewMetadata *ConfigPlanMetadata `json:"new_metadata,omitempty"`
+	ValueReset  bool                `json:"value_reset"`
+	Value       string              `json:"value,omitempty"`
+}
+
+// ConfigsPlan changes of upsert, kept are keys missing in request which are not pruned
+type ConfigsPlan struct {
+	Added     []*ConfigPlanItem `json:"added"`
+	Changed   []*ConfigPlanItem `json:"changed"`
+	Removed   []*ConfigPlanItem `json:"removed"`
+	Kept      []*ConfigPlanItem `json:"kept"`
+	Unchanged []*ConfigPlanItem `json:"unchanged"`
+}
+
+// PlanConfigs dry run of upsert, nothing is changed
+func (c *Client) PlanConfigs(ctx context.Context, projectName, envName, releaseName string, req []*UpsertConfigRequest, prune bool) (*ConfigsPlan, error) {
+	httpReq, err := c.newUpsertConfigsRequest(ctx, projectName, envName, releaseName, req, prune, true, ChangeReason{})
+	if err != nil {
+		return nil, fmt.Errorf("newUpsertConfigsRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *ConfigsPlan `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+func (c *Client) newUpsertConfigsRequest(ctx context.Context, projectName, envName, releaseName string, req []*UpsertConfigRequest, prune, dryRun bool, reason ChangeReason) (*http.Request, error) {
+	query := url.Values{}
+	if !prune {
+		query.Set("prune", "false")
+	}
+
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+
+	if reason.Reason != "" {
+		query.Set("reason", reason.Reason)
+	}
+
+	if reason.Ticket != "" {
+		query.Set("ticket", reason.Ticket)
+	}
+
+	uri := fmt.Sprintf("/projects/%s/envs/%s/releases/%s/configs", projectName, envName, releaseName)
+	if len(query) != 0 {
+		uri += "?" + query.Encode()
+	}
+
+	body, err := encodePayload(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling upsert configs: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, uri, body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	return httpReq, nil
+}
+
+// ChangeReason why config values are changed, reason may be mandatory for environment
+type ChangeReason struct {
+	Reason string `json:"reason,omitempty"`
+	Ticket string `json:"ticket,omitempty"`
+}
+
+// SetConfigValues ...
+func (c *Client) SetConfigValues(ctx context.Context, projectName, envName, releaseName string, values map[string]string, reason ChangeReason) error {
+	query := url.Values{}
+	if reason.Reason != "" {
+		query.Set("reason", reason.Reason)
+	}
+
+	if reason.Ticket != "" {
+		query.Set("ticket", reason.Ticket)
+	}
+
 	uri := fmt.Sprintf("/projects/%s/envs/%s/releases/%s/configs", projectName, envName, releaseName)
+	if len(query) != 0 {
+		uri += "?" + query.Encode()
+	}
+
+	body, err := encodePayload(values)
+	if err != nil {
+		return fmt.Errorf("marshalling config values: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPut, uri, body)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	if _, err := c.do(httpReq, http.StatusCreated); err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+
+	return nil
+}
+
+// ConfigHistoryItem change of config value
+type ConfigHistoryItem struct {
+	ID       uint64    `json:"id"`
+	Actor    string    `json:"actor"`
+	OldValue string    `json:"old_value"`
+	NewValue string    `json:"new_value"`
+	Reason   string    `json:"reason"`
+	Ticket   string    `json:"ticket"`
+	Ts       time.Time `json:"ts"`
+}
+
+// ConfigHistory value changes of key, newest first
+func (c *Client) ConfigHistory(ctx context.Context, projectName, envName, releaseName, key string, limit uint64) ([]*ConfigHistoryItem, error) {
+	uri := fmt.Sprintf("/projects/%s/envs/%s/releases/%s/configs/%s/history?limit=%d", projectName, envName, releaseName, url.PathEscape(key), limit)
+
+	httpReq, err := c.newRequest(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			History []*ConfigHistoryItem `json:"history"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.History, nil
+}
+
+// RevertConfigValueRequest ...
+type RevertConfigValueRequest struct {
+	ID     uint64 `json:"id"`
+	Before bool   `json:"before"`
+	ChangeReason
+}
+
+// RevertConfigValue restore value of key from history record
+func (c *Client) RevertConfigValue(ctx context.Context, projectName, envName, releaseName, key string, req *RevertConfigValueRequest) error {
+	uri := fmt.Sprintf("/projects/%s/envs/%s/releases/%s/configs/%s/revert", projectName, envName, releaseName, url.PathEscape(key))
 
 	body, err := encodePayload(req)
 	if err != nil {
-		return fmt.Errorf("marshalling upsert configs: %w", err)
+		return fmt.Errorf("marshalling revert config value: %w", err)
 	}
 
 	httpReq, err := c.newRequest(ctx, http.MethodPost, uri, body)
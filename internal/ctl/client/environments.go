@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Environment ...
+type Environment struct {
+	Name string `json:"name"`
+}
+
+// ListEnvironments environments of project
+func (c *Client) ListEnvironments(ctx context.Context, projectName string) ([]*Environment, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/envs", projectName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Environments []*Environment `json:"environments"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Environments, nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Member role binding of user or group in project
+type Member struct {
+	ID        uint64    `json:"id"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	Env       string    `json:"env"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddMemberRequest ...
+type AddMemberRequest struct {
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Env     string `json:"env,omitempty"`
+	Role    string `json:"role"`
+}
+
+// ListMembers list role bindings of project, project * for bindings to all projects
+func (c *Client) ListMembers(ctx context.Context, projectName string) ([]*Member, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/members", projectName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Members []*Member `json:"members"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Members, nil
+}
+
+// AddMember ...
+func (c *Client) AddMember(ctx context.Context, projectName string, req *AddMemberRequest) (*Member, error) {
+	body, err := encodePayload(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling add member: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/projects/%s/members", projectName), body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusCreated)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *Member `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// RemoveMember ...
+func (c *Client) RemoveMember(ctx context.Context, projectName string, id uint64) error {
+	httpReq, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/projects/%s/members/%d", projectName, id), nil)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
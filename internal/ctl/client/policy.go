@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// EvalPolicyRequest ...
+type EvalPolicyRequest struct {
+	Username string `json:"username"`
+	// Roles of user, roles of stored user are used if empty
+	Roles   []string        `json:"roles,omitempty"`
+	Method  string          `json:"method"`
+	Path    string          `json:"path"`
+	Body    json.RawMessage `json:"body,omitempty"`
+	Explain string          `json:"explain,omitempty"`
+}
+
+// PolicyDecision ...
+type PolicyDecision struct {
+	Allowed     bool           `json:"allowed"`
+	Reason      string         `json:"reason"`
+	Action      string         `json:"action"`
+	Input       map[string]any `json:"input"`
+	Explanation []string       `json:"explanation"`
+}
+
+// EvalPolicy evaluate server authorizer for user request
+func (c *Client) EvalPolicy(ctx context.Context, req *EvalPolicyRequest) (*PolicyDecision, error) {
+	body, err := encodePayload(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling eval policy: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/policy/eval", body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *PolicyDecision `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
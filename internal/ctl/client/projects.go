@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Project ...
+type Project struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ProjectsPage ...
+type ProjectsPage struct {
+	Projects []*Project `json:"projects"`
+	Total    uint64     `json:"total"`
+}
+
+// ListProjects projects matching q
+func (c *Client) ListProjects(ctx context.Context, q string, limit, offset uint64) (*ProjectsPage, error) {
+	query := url.Values{}
+	query.Set("q", q)
+	query.Set("limit", strconv.FormatUint(limit, 10))
+	query.Set("offset", strconv.FormatUint(offset, 10))
+
+	httpReq, err := c.newRequest(ctx, http.MethodGet, "/projects?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *ProjectsPage `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// CreateProjectRequest ...
+type CreateProjectRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CreateProject ...
+func (c *Client) CreateProject(ctx context.Context, req *CreateProjectRequest) (*Project, error) {
+	body, err := encodePayload(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling create project: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/projects", body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusCreated)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Project *Project `json:"project"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Project, nil
+}
+
+// UpdateProject change project description
+func (c *Client) UpdateProject(ctx context.Context, name, description string) error {
+	body, err := encodePayload(struct {
+		Description string `json:"description"`
+	}{
+		Description: description,
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling update project: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPut, "/projects/"+url.PathEscape(name), body)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// DeleteProject ...
+func (c *Client) DeleteProject(ctx context.Context, name string) error {
+	httpReq, err := c.newRequest(ctx, http.MethodDelete, "/projects/"+url.PathEscape(name), nil)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Release ...
+type Release struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListReleases releases of project environment
+func (c *Client) ListReleases(ctx context.Context, projectName, envName string) ([]*Release, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodGet, fmt.Sprintf("/projects/%s/envs/%s/releases", projectName, envName), nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Releases []*Release `json:"releases"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Releases, nil
+}
+
+// DeleteRelease ...
+func (c *Client) DeleteRelease(ctx context.Context, projectName, envName, releaseName string) error {
+	uri := fmt.Sprintf("/projects/%s/envs/%s/releases/%s", projectName, envName, releaseName)
+
+	httpReq, err := c.newRequest(ctx, http.MethodDelete, uri, nil)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// LoginRequest ...
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// NewPassword required if one-time password is set by admin
+	NewPassword string `json:"new_password,omitempty"`
+	// OTP required if two-factor authentication is enabled
+	OTP string `json:"otp,omitempty"`
+}
+
+// Session JWT of logged in user
+type Session struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn seconds of token lifetime
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// Login by username and password
+func (c *Client) Login(ctx context.Context, req *LoginRequest) (*Session, error) {
+	body, err := encodePayload(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling login: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/login", body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	return c.doSession(httpReq)
+}
+
+// Refresh issue new session by refresh token, refresh token is rotated
+func (c *Client) Refresh(ctx context.Context, refreshToken string) (*Session, error) {
+	body, err := encodePayload(struct {
+		RefreshToken string `json:"refresh_token"`
+	}{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling refresh: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/refresh", body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	return c.doSession(httpReq)
+}
+
+func (c *Client) doSession(httpReq *http.Request) (*Session, error) {
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *Session `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// Logout revoke session of refresh token
+func (c *Client) Logout(ctx context.Context, refreshToken string) error {
+	body, err := encodePayload(struct {
+		RefreshToken string `json:"refresh_token"`
+	}{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		return fmt.Errorf("marshalling logout: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/logout", body)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
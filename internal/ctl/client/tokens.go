@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIToken ...
+type APIToken struct {
+	ID         uint64     `json:"id"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	// Token secret value, filled only on create
+	Token string `json:"token,omitempty"`
+}
+
+// CreateTokenRequest ...
+type CreateTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ListTokens ...
+func (c *Client) ListTokens(ctx context.Context) ([]*APIToken, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodGet, "/tokens", nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Tokens []*APIToken `json:"tokens"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Tokens, nil
+}
+
+// CreateToken ...
+func (c *Client) CreateToken(ctx context.Context, req *CreateTokenRequest) (*APIToken, error) {
+	body, err := encodePayload(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling create token: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/tokens", body)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusCreated)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *APIToken `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// RevokeToken ...
+func (c *Client) RevokeToken(ctx context.Context, id uint64) error {
+	httpReq, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/tokens/%d", id), nil)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// User ...
+type User struct {
+	Username           string    `json:"username"`
+	IsEnabled          bool      `json:"is_enabled"`
+	Roles              []string  `json:"roles"`
+	MustChangePassword bool      `json:"must_change_password"`
+	Source             string    `json:"source"`
+	TwoFactorEnabled   bool      `json:"two_factor_enabled"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// UsersPage ...
+type UsersPage struct {
+	Users []*User `json:"users"`
+	Total uint64  `json:"total"`
+}
+
+// ListUsers users matching q
+func (c *Client) ListUsers(ctx context.Context, q string, limit, offset uint64) (*UsersPage, error) {
+	query := url.Values{}
+	query.Set("q", q)
+	query.Set("limit", strconv.FormatUint(limit, 10))
+	query.Set("offset", strconv.FormatUint(offset, 10))
+
+	httpReq, err := c.newRequest(ctx, http.MethodGet, "/users?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return nil, fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data *UsersPage `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data, nil
+}
+
+// CreateUserRequest ...
+type CreateUserRequest struct {
+	Username  string   `json:"username"`
+	Password  string   `json:"password"`
+	IsEnabled bool     `json:"is_enabled"`
+	Roles     []string `json:"roles"`
+}
+
+// CreateUser ...
+func (c *Client) CreateUser(ctx context.Context, req *CreateUserRequest) error {
+	body, err := encodePayload(req)
+	if err != nil {
+		return fmt.Errorf("marshalling create user: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPost, "/users", body)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusCreated)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// UpdateUserRequest nil fields are not changed
+type UpdateUserRequest struct {
+	IsEnabled *bool    `json:"is_enabled,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+// UpdateUser ...
+func (c *Client) UpdateUser(ctx context.Context, username string, req *UpdateUserRequest) error {
+	body, err := encodePayload(req)
+	if err != nil {
+		return fmt.Errorf("marshalling update user: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPatch, "/users/"+url.PathEscape(username), body)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ChangePasswordRequest ...
+type ChangePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+// ChangePassword change password of current user
+func (c *Client) ChangePassword(ctx context.Context, req *ChangePasswordRequest) error {
+	body, err := encodePayload(req)
+	if err != nil {
+		return fmt.Errorf("marshalling change password: %w", err)
+	}
+
+	httpReq, err := c.newRequest(ctx, http.MethodPut, "/me/password", body)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// ResetPassword set one-time password for user and return it
+func (c *Client) ResetPassword(ctx context.Context, username string) (string, error) {
+	httpReq, err := c.newRequest(ctx, http.MethodPost, fmt.Sprintf("/users/%s/password/reset", username), nil)
+	if err != nil {
+		return "", fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusOK)
+	if err != nil {
+		return "", fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var payload struct {
+		Data struct {
+			Password string `json:"password"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return payload.Data.Password, nil
+}
+
+// ResetTOTP reset two-factor authentication enrollment of user
+func (c *Client) ResetTOTP(ctx context.Context, username string) error {
+	httpReq, err := c.newRequest(ctx, http.MethodDelete, fmt.Sprintf("/users/%s/totp", username), nil)
+	if err != nil {
+		return fmt.Errorf("newRequest: %w", err)
+	}
+
+	resp, err := c.do(httpReq, http.StatusNoContent)
+	if err != nil {
+		return fmt.Errorf("do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
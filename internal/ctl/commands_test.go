@@ -0,0 +1,158 @@
+package ctl
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// executeCommand run rtcctl with config file, stdout is returned
+func executeCommand(t *testing.T, configPath string, args ...string) (string, error) {
+	t.Helper()
+
+	return executeCommandWithInput(t, configPath, "", args...)
+}
+
+// executeCommandWithInput run rtcctl with stdin
+func executeCommandWithInput(t *testing.T, configPath, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	var stdout bytes.Buffer
+
+	cmd := newRootCommand()
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(append([]string{"--config", configPath}, args...))
+
+	err := cmd.Execute()
+
+	return stdout.String(), err
+}
+
+// newTestServer respond with body to request with method and uri
+func newTestServer(t *testing.T, method, uri string, status int, body string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method || r.RequestURI != uri {
+			http.Error(w, "unexpected request "+r.Method+" "+r.RequestURI, http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func Test_Commands_Render_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		method   string
+		uri      string
+		status   int
+		response string
+		want     string
+	}{
+		{
+			name:     "projects list table",
+			args:     []string{"projects", "list"},
+			method:   http.MethodGet,
+			uri:      "/api/v1/projects?limit=50&offset=0&q=",
+			status:   http.StatusOK,
+			response: `{"data":{"projects":[{"name":"example","description":"example service","created_at":"2025-10-28T10:00:00Z"}],"total":1}}`,
+			want:     "NAME     DESCRIPTION      CREATED\nexample  example service  2025-10-28 10:00:00\n",
+		},
+		{
+			name:     "projects list json",
+			args:     []string{"projects", "list", "-o", "json"},
+			method:   http.MethodGet,
+			uri:      "/api/v1/projects?limit=50&offset=0&q=",
+			status:   http.StatusOK,
+			response: `{"data":{"projects":[],"total":0}}`,
+			want:     "{\n  \"projects\": [],\n  \"total\": 0\n}\n",
+		},
+		{
+			name:     "users list table",
+			args:     []string{"users", "list", "-q", "bob"},
+			method:   http.MethodGet,
+			uri:      "/api/v1/users?limit=50&offset=0&q=bob",
+			status:   http.StatusOK,
+			response: `{"data":{"users":[{"username":"bob","is_enabled":true,"roles":["viewer","editor"],"source":"local","created_at":"2025-10-28T10:00:00Z"}],"total":1}}`,
+			want: "USERNAME  ENABLED  ROLES          SOURCE  2FA    CREATED\n" +
+				"bob       true     viewer,editor  local   false  2025-10-28 10:00:00\n",
+		},
+		{
+			name:     "releases list yaml",
+			args:     []string{"releases", "list", "-p", "example", "-e", "prod", "-o", "yaml"},
+			method:   http.MethodGet,
+			uri:      "/api/v1/projects/example/envs/prod/releases",
+			status:   http.StatusOK,
+			response: `{"data":{"releases":[{"name":"v1","created_at":"2025-10-28T10:00:00Z"}]}}`,
+			want:     "- name: v1\n  created_at: \"2025-10-28T10:00:00Z\"\n",
+		},
+		{
+			name:     "projects create table",
+			args:     []string{"projects", "create", "example", "-d", "example service"},
+			method:   http.MethodPost,
+			uri:      "/api/v1/projects",
+			status:   http.StatusCreated,
+			response: `{"data":{"project":{"name":"example","description":"example service"}}}`,
+			want:     "project example created\n",
+		},
+		{
+			name:     "tokens create table",
+			args:     []string{"tokens", "create", "-n", "ci"},
+			method:   http.MethodPost,
+			uri:      "/api/v1/tokens",
+			status:   http.StatusCreated,
+			response: `{"data":{"id":7,"name":"ci","owner":"bob","scopes":null,"created_at":"2025-10-28T10:00:00Z","token":"rtc_secret"}}`,
+			want:     "rtc_secret\n",
+		},
+		{
+			name:     "tokens create json",
+			args:     []string{"tokens", "create", "-n", "ci", "-o", "json"},
+			method:   http.MethodPost,
+			uri:      "/api/v1/tokens",
+			status:   http.StatusCreated,
+			response: `{"data":{"id":7,"name":"ci","owner":"bob","scopes":null,"created_at":"2025-10-28T10:00:00Z","token":"rtc_secret"}}`,
+			want: "{\n  \"id\": 7,\n  \"name\": \"ci\",\n  \"owner\": \"bob\",\n  \"scopes\": null,\n  \"expires_at\": null,\n" +
+				"  \"last_used_at\": null,\n  \"revoked_at\": null,\n  \"created_at\": \"2025-10-28T10:00:00Z\",\n  \"token\": \"rtc_secret\"\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		srv := newTestServer(t, tt.method, tt.uri, tt.status, tt.response)
+
+		got, err := executeCommand(t, filepath.Join(t.TempDir(), "config.yaml"), append(tt.args, "--url", srv.URL+"/api/v1")...)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+func Test_Commands_ServerError_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, http.MethodDelete, "/api/v1/projects/example", http.StatusForbidden, `{"error":"forbidden"}`)
+
+	_, err := executeCommand(t, filepath.Join(t.TempDir(), "config.yaml"), "projects", "delete", "example", "--url", srv.URL+"/api/v1")
+	require.ErrorContains(t, err, "invalid status code: 403")
+}
+
+func Test_Commands_InvalidOutput_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := executeCommand(t, filepath.Join(t.TempDir(), "config.yaml"), "projects", "list", "-o", "xml")
+	require.EqualError(t, err, `invalid output "xml", expected table, json or yaml`)
+}
@@ -1,12 +1,20 @@
 package ctl
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
 )
 
 func newConfigsCommand() *cobra.Command {
@@ -15,7 +23,110 @@ func newConfigsCommand() *cobra.Command {
 		Short: "manage configs",
 	}
 
-	cmd.AddCommand(newUpsertConfigCommand())
+	cmd.AddCommand(
+		newListConfigsCommand(),
+		newGetConfigCommand(),
+		newUpsertConfigCommand(),
+		newPlanConfigsCommand(),
+		newSetConfigCommand(),
+		newConfigHistoryCommand(),
+		newRevertConfigCommand(),
+	)
+
+	return cmd
+}
+
+func newListConfigsCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+		releaseName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list configs of release",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			configs, err := clientFromContext(ctx).ListConfigs(ctx, projectName, envName, releaseName)
+			if err != nil {
+				return fmt.Errorf("client.ListConfigs: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), configs, func(w io.Writer) {
+				fmt.Fprintln(w, "KEY\tTYPE\tVALUE\tGROUP\tWRITABLE\tUPDATED")
+
+				for _, config := range configs {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\t%s\n",
+						config.Key,
+						config.ValueType,
+						config.Value,
+						config.Group,
+						config.Writable,
+						formatOptionalTime(config.UpdatedAt),
+					)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
+
+	return cmd
+}
+
+func newGetConfigCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+		releaseName string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "show config of release",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			configs, err := clientFromContext(ctx).ListConfigs(ctx, projectName, envName, releaseName)
+			if err != nil {
+				return fmt.Errorf("client.ListConfigs: %w", err)
+			}
+
+			idx := slices.IndexFunc(configs, func(config *client.Config) bool {
+				return config.Key == args[0]
+			})
+			if idx == -1 {
+				return fmt.Errorf("config %q not found", args[0])
+			}
+
+			config := configs[idx]
+
+			return render(ctx, cmd.OutOrStdout(), config, func(w io.Writer) {
+				fmt.Fprintf(w, "key:\t%s\n", config.Key)
+				fmt.Fprintf(w, "type:\t%s\n", config.ValueType)
+				fmt.Fprintf(w, "value:\t%s\n", config.Value)
+				fmt.Fprintf(w, "group:\t%s\n", config.Group)
+				fmt.Fprintf(w, "usage:\t%s\n", config.Usage)
+				fmt.Fprintf(w, "writable:\t%t\n", config.Writable)
+
+				if config.View.Enum != "" {
+					fmt.Fprintf(w, "enum:\t%s\n", config.View.Enum)
+				}
+
+				fmt.Fprintf(w, "created:\t%s\n", config.CreatedAt.Format(time.RFC3339))
+				fmt.Fprintf(w, "updated:\t%s\n", formatOptionalTime(config.UpdatedAt))
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
 
 	return cmd
 }
@@ -34,28 +145,30 @@ func newUpsertConfigCommand() *cobra.Command {
 		envName        string
 		releaseName    string
 		valuesFilePath string
+		dryRun         bool
+		noPrune        bool
+		reason         client.ChangeReason
 	)
 
 	cmd := &cobra.Command{
 		Use:   "upsert",
-		Short: "upsert configs",
+		Short: "upsert configs, keys missing in values file are deleted unless --no-prune",
+		Example: "  rtcctl configs upsert -p example -e prod -r v1 -v configs.yaml --dry-run\n" +
+			"  rtcctl configs upsert -p example -e prod -r v1 -v configs.yaml --no-prune --reason \"release v1\" --ticket OPS-42",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := cmd.Context()
 
-			slog.DebugContext(ctx, "reading configs", "valuesFilePath", valuesFilePath)
-			data, err := os.ReadFile(valuesFilePath) // nolint:gosec
+			configs, err := readUpsertConfigs(ctx, valuesFilePath)
 			if err != nil {
-				return fmt.Errorf("os.ReadFile: %w", err)
+				return fmt.Errorf("readUpsertConfigs: %w", err)
 			}
 
-			slog.DebugContext(ctx, "parsing configs")
-			var configs upsertConfigYaml
-			if err := yaml.Unmarshal(data, &configs); err != nil {
-				return fmt.Errorf("yaml.Unmarshal: %w", err)
+			if dryRun {
+				return planConfigs(cmd, projectName, envName, releaseName, configs, !noPrune)
 			}
 
-			slog.DebugContext(ctx, "upserting configs", "projectName", projectName, "envName", envName, "releaseName", releaseName, "configs", len(configs))
-			if err := clientFromContext(ctx).UpsertConfigs(ctx, projectName, envName, releaseName, convertConfigsToReq(configs)); err != nil {
+			slog.DebugContext(ctx, "upserting configs", "projectName", projectName, "envName", envName, "releaseName", releaseName, "configs", len(configs), "noPrune", noPrune)
+			if err := clientFromContext(ctx).UpsertConfigs(ctx, projectName, envName, releaseName, configs, !noPrune, reason); err != nil {
 				return fmt.Errorf("client.UpsertConfigs: %w", err)
 			}
 
@@ -69,6 +182,274 @@ func newUpsertConfigCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
 	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
 	cmd.Flags().StringVarP(&valuesFilePath, "values", "v", "", "Values file path")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print plan of changes without applying it")
+	cmd.Flags().BoolVar(&noPrune, "no-prune", false, "Keep keys missing in values file")
+	cmd.Flags().StringVar(&reason.Reason, "reason", "", "Reason of change, may be required for environment")
+	cmd.Flags().StringVar(&reason.Ticket, "ticket", "", "Ticket reference e.g. OPS-42")
+
+	return cmd
+}
+
+func newPlanConfigsCommand() *cobra.Command {
+	var (
+		projectName    string
+		envName        string
+		releaseName    string
+		valuesFilePath string
+		noPrune        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "plan",
+		Short:   "print changes of upsert without applying them",
+		Example: "  rtcctl configs plan -p example -e prod -r v1 -v configs.yaml",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configs, err := readUpsertConfigs(cmd.Context(), valuesFilePath)
+			if err != nil {
+				return fmt.Errorf("readUpsertConfigs: %w", err)
+			}
+
+			return planConfigs(cmd, projectName, envName, releaseName, configs, !noPrune)
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
+	cmd.Flags().StringVarP(&valuesFilePath, "values", "v", "", "Values file path")
+	cmd.Flags().BoolVar(&noPrune, "no-prune", false, "Keep keys missing in values file")
+
+	return cmd
+}
+
+func readUpsertConfigs(ctx context.Context, valuesFilePath string) ([]*client.UpsertConfigRequest, error) {
+	slog.DebugContext(ctx, "reading configs", "valuesFilePath", valuesFilePath)
+	data, err := os.ReadFile(valuesFilePath) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	slog.DebugContext(ctx, "parsing configs")
+	var configs upsertConfigYaml
+	if err := yaml.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	return convertConfigsToReq(configs), nil
+}
+
+func planConfigs(cmd *cobra.Command, projectName, envName, releaseName string, configs []*client.UpsertConfigRequest, prune bool) error {
+	ctx := cmd.Context()
+
+	plan, err := clientFromContext(ctx).PlanConfigs(ctx, projectName, envName, releaseName, configs, prune)
+	if err != nil {
+		return fmt.Errorf("client.PlanConfigs: %w", err)
+	}
+
+	return render(ctx, cmd.OutOrStdout(), plan, func(w io.Writer) {
+		printConfigsPlan(w, plan)
+	})
+}
+
+// printConfigsPlan diff of upsert, unchanged keys are printed only if value is reset
+func printConfigsPlan(w io.Writer, plan *client.ConfigsPlan) {
+	for _, item := range plan.Added {
+		fmt.Fprintf(w, "+ %s\t%s\t%s\n", item.Key, item.NewType, formatPlanValue(item))
+	}
+
+	for _, item := range plan.Changed {
+		fmt.Fprintf(w, "~ %s\t%s\t%s\n", item.Key, strings.Join(configPlanChanges(item), ", "), formatPlanValue(item))
+	}
+
+	for _, item := range plan.Unchanged {
+		if item.ValueReset {
+			fmt.Fprintf(w, "~ %s\t%s\t%s\n", item.Key, item.NewType, formatPlanValue(item))
+		}
+	}
+
+	for _, item := range plan.Removed {
+		fmt.Fprintf(w, "- %s\t%s\tvalue deleted\n", item.Key, item.OldType)
+	}
+
+	for _, item := range plan.Kept {
+		fmt.Fprintf(w, "  %s\t%s\tnot in values file, kept\n", item.Key, item.OldType)
+	}
+
+	fmt.Fprintf(w, "\nplan: %d to add, %d to change, %d to remove, %d kept, %d unchanged\n",
+		len(plan.Added), len(plan.Changed), len(plan.Removed), len(plan.Kept), len(plan.Unchanged),
+	)
+}
+
+func configPlanChanges(item *client.ConfigPlanItem) []string {
+	var changes []string
+
+	if item.OldType != item.NewType {
+		changes = append(changes, fmt.Sprintf("type %s -> %s", item.OldType, item.NewType))
+	}
+
+	if item.OldMetadata == nil || item.NewMetadata == nil {
+		return changes
+	}
+
+	oldMeta, newMeta := item.OldMetadata, item.NewMetadata
+
+	if oldMeta.Group != newMeta.Group {
+		changes = append(changes, fmt.Sprintf("group %q -> %q", oldMeta.Group, newMeta.Group))
+	}
+
+	if oldMeta.Usage != newMeta.Usage {
+		changes = append(changes, fmt.Sprintf("usage %q -> %q", oldMeta.Usage, newMeta.Usage))
+	}
+
+	if oldMeta.Writable != newMeta.Writable {
+		changes = append(changes, fmt.Sprintf("writable %t -> %t", oldMeta.Writable, newMeta.Writable))
+	}
+
+	if oldMeta.View.Enum != newMeta.View.Enum {
+		changes = append(changes, fmt.Sprintf("enum %q -> %q", oldMeta.View.Enum, newMeta.View.Enum))
+	}
+
+	return changes
+}
+
+func formatPlanValue(item *client.ConfigPlanItem) string {
+	if item.ValueReset {
+		return fmt.Sprintf("value set to %q", item.Value)
+	}
+
+	return "value kept"
+}
+
+func newSetConfigCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+		releaseName string
+		reason      client.ChangeReason
+	)
+
+	cmd := &cobra.Command{
+		Use:     "set <key=value>...",
+		Short:   "set config values",
+		Example: "  rtcctl configs set -p example -e prod -r v1 max_conns=20 --reason \"raise pool\" --ticket OPS-42",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			values := make(map[string]string, len(args))
+			for _, arg := range args {
+				key, value, ok := strings.Cut(arg, "=")
+				if !ok || key == "" {
+					return fmt.Errorf("invalid value %q, expected key=value", arg)
+				}
+
+				values[key] = value
+			}
+
+			if err := clientFromContext(ctx).SetConfigValues(ctx, projectName, envName, releaseName, values, reason); err != nil {
+				return fmt.Errorf("client.SetConfigValues: %w", err)
+			}
+
+			slog.InfoContext(ctx, "set config values", "projectName", projectName, "envName", envName, "releaseName", releaseName, "values", len(values))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
+	cmd.Flags().StringVar(&reason.Reason, "reason", "", "Reason of change, may be required for environment")
+	cmd.Flags().StringVar(&reason.Ticket, "ticket", "", "Ticket reference e.g. OPS-42")
+
+	return cmd
+}
+
+func newConfigHistoryCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+		releaseName string
+		limit       uint64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history <key>",
+		Short: "list value changes of config key, newest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			history, err := clientFromContext(ctx).ConfigHistory(ctx, projectName, envName, releaseName, args[0], limit)
+			if err != nil {
+				return fmt.Errorf("client.ConfigHistory: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), history, func(w io.Writer) {
+				fmt.Fprintln(w, "ID\tTS\tACTOR\tOLD\tNEW\tREASON\tTICKET")
+
+				for _, item := range history {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						item.ID,
+						item.Ts.Format("2006-01-02 15:04:05"),
+						item.Actor,
+						item.OldValue,
+						item.NewValue,
+						item.Reason,
+						item.Ticket,
+					)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
+	cmd.Flags().Uint64Var(&limit, "limit", 20, "Max changes to show")
+
+	return cmd
+}
+
+func newRevertConfigCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+		releaseName string
+		req         client.RevertConfigValueRequest
+	)
+
+	cmd := &cobra.Command{
+		Use:     "revert <key> <history id>",
+		Short:   "restore config value from history record",
+		Example: "  rtcctl configs revert -p example -e prod -r v1 max_conns 42 --before --reason \"bad rollout\"",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			id, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid history id: %w", err)
+			}
+
+			req.ID = id
+
+			if err := clientFromContext(ctx).RevertConfigValue(ctx, projectName, envName, releaseName, args[0], &req); err != nil {
+				return fmt.Errorf("client.RevertConfigValue: %w", err)
+			}
+
+			slog.InfoContext(ctx, "reverted config value", "key", args[0], "id", id, "before", req.Before)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+	cmd.Flags().StringVarP(&releaseName, "release", "r", "", "Release")
+	cmd.Flags().BoolVar(&req.Before, "before", false, "Restore value before the change instead of after")
+	cmd.Flags().StringVar(&req.Reason, "reason", "", "Reason of change, may be required for environment")
+	cmd.Flags().StringVar(&req.Ticket, "ticket", "", "Ticket reference e.g. OPS-42")
 
 	return cmd
 }
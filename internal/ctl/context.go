@@ -14,3 +14,11 @@ func clientToContext(ctx context.Context, client *client.Client) context.Context
 func clientFromContext(ctx context.Context) *client.Client {
 	return ctx.Value(contextKeyClient{}).(*client.Client)
 }
+
+func configToContext(ctx context.Context, config *ctlConfig) context.Context {
+	return context.WithValue(ctx, contextKeyConfig{}, config)
+}
+
+func configFromContext(ctx context.Context) *ctlConfig {
+	return ctx.Value(contextKeyConfig{}).(*ctlConfig) // nolint:forcetypeassert
+}
@@ -0,0 +1,375 @@
+package ctl
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+// ctlConfig kubeconfig-style file of named server contexts
+type ctlConfig struct {
+	CurrentContext string        `yaml:"current_context,omitempty"`
+	Contexts       []*ctlContext `yaml:"contexts"`
+
+	path string
+}
+
+// ctlContext server with credentials and defaults of project flags
+type ctlContext struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Auth kind of credentials token or jwt
+	Auth string `yaml:"auth,omitempty"`
+	// Token API token for token auth
+	Token string `yaml:"token,omitempty"`
+	// Username, JWT, RefreshToken and ExpiresAt are saved by rtcctl login for jwt auth
+	Username     string     `yaml:"username,omitempty"`
+	JWT          string     `yaml:"jwt,omitempty"`
+	RefreshToken string     `yaml:"refresh_token,omitempty"`
+	ExpiresAt    *time.Time `yaml:"expires_at,omitempty"`
+	// Project and Env are used if --project and --env flags are not set
+	Project string `yaml:"project,omitempty"`
+	Env     string `yaml:"env,omitempty"`
+}
+
+// defaultConfigPath $XDG_CONFIG_HOME/rtcctl/config.yaml or ~/.config/rtcctl/config.yaml
+func defaultConfigPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "rtcctl", "config.yaml")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".config", "rtcctl", "config.yaml")
+	}
+
+	return filepath.Join(home, ".config", "rtcctl", "config.yaml")
+}
+
+// loadConfig missing file is empty config
+func loadConfig(path string) (*ctlConfig, error) {
+	config := &ctlConfig{path: path}
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return config, nil
+		}
+
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	return config, nil
+}
+
+// save file is readable only by owner, it contains credentials
+func (c *ctlConfig) save() error {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("yaml.Encode: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
+
+func (c *ctlConfig) context(name string) *ctlContext {
+	for _, item := range c.Contexts {
+		if item.Name == name {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// selected context by name or current context, nil if config has no contexts
+func (c *ctlConfig) selected(name string) (*ctlContext, error) {
+	name = cmp.Or(name, c.CurrentContext)
+	if name == "" {
+		return nil, nil // nolint:nilnil
+	}
+
+	item := c.context(name)
+	if item == nil {
+		return nil, fmt.Errorf("context %q not found in %s", name, c.path)
+	}
+
+	return item, nil
+}
+
+// credentials Authorization kind and token
+func (c *ctlContext) credentials() (string, string) {
+	if c.Auth == client.AuthJWT {
+		return client.AuthJWT, c.JWT
+	}
+
+	return client.AuthToken, c.Token
+}
+
+// expired JWT is refreshed a bit before expiration
+func (c *ctlContext) expired(now time.Time) bool {
+	return c.Auth == client.AuthJWT && c.ExpiresAt != nil && now.Add(30*time.Second).After(*c.ExpiresAt)
+}
+
+func (c *ctlContext) setSession(session *client.Session, now time.Time) {
+	expiresAt := now.Add(time.Duration(session.ExpiresIn) * time.Second).UTC().Truncate(time.Second)
+
+	c.Auth = client.AuthJWT
+	c.JWT = session.Token
+	c.RefreshToken = session.RefreshToken
+	c.ExpiresAt = &expiresAt
+}
+
+type contextKeyConfig struct{}
+
+// refreshSession expired JWT of context is refreshed and saved
+func refreshSession(ctx context.Context, config *ctlConfig, selected *ctlContext) error {
+	now := time.Now()
+	if !selected.expired(now) {
+		return nil
+	}
+
+	if selected.RefreshToken == "" {
+		return errors.New("session is expired")
+	}
+
+	session, err := client.NewClient(selected.URL, "", "").Refresh(ctx, selected.RefreshToken)
+	if err != nil {
+		return fmt.Errorf("client.Refresh: %w", err)
+	}
+
+	selected.setSession(session, now)
+
+	if err := config.save(); err != nil {
+		return fmt.Errorf("config.save: %w", err)
+	}
+
+	return nil
+}
+
+func newContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "manage server contexts of config file",
+		// config file is changed without requests to server
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return setup(cmd)
+		},
+	}
+
+	cmd.AddCommand(
+		newListContextsCommand(),
+		newUseContextCommand(),
+		newSetContextCommand(),
+		newDeleteContextCommand(),
+	)
+
+	return cmd
+}
+
+func newListContextsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "list contexts, current is marked by *",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			config := configFromContext(ctx)
+
+			// credentials are not printed
+			type contextView struct {
+				Name     string `json:"name"`
+				Current  bool   `json:"current"`
+				URL      string `json:"url"`
+				Auth     string `json:"auth"`
+				Username string `json:"username,omitempty"`
+				Project  string `json:"project,omitempty"`
+				Env      string `json:"env,omitempty"`
+			}
+
+			views := make([]contextView, 0, len(config.Contexts))
+			for _, item := range config.Contexts {
+				views = append(views, contextView{
+					Name:     item.Name,
+					Current:  item.Name == config.CurrentContext,
+					URL:      item.URL,
+					Auth:     cmp.Or(item.Auth, client.AuthToken),
+					Username: item.Username,
+					Project:  item.Project,
+					Env:      item.Env,
+				})
+			}
+
+			return render(ctx, cmd.OutOrStdout(), views, func(w io.Writer) {
+				fmt.Fprintln(w, "CURRENT\tNAME\tURL\tAUTH\tUSERNAME\tPROJECT\tENV")
+
+				for _, view := range views {
+					current := ""
+					if view.Current {
+						current = "*"
+					}
+
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+						current,
+						view.Name,
+						view.URL,
+						view.Auth,
+						view.Username,
+						view.Project,
+						view.Env,
+					)
+				}
+			})
+		},
+	}
+}
+
+func newUseContextCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use <name>",
+		Short: "set current context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := configFromContext(cmd.Context())
+
+			if config.context(args[0]) == nil {
+				return fmt.Errorf("context %q not found", args[0])
+			}
+
+			config.CurrentContext = args[0]
+
+			if err := config.save(); err != nil {
+				return fmt.Errorf("config.save: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "switched to context %s\n", args[0])
+
+			return nil
+		},
+	}
+}
+
+func newSetContextCommand() *cobra.Command {
+	var values ctlContext
+
+	cmd := &cobra.Command{
+		Use:   "set <name>",
+		Short: "create or change item, only given flags are changed",
+		Example: "  rtcctl context set prod --url https://rtc.example.com/api/v1 --auth jwt --username admin --project example --env prod\n" +
+			"  rtcctl context set ci --url https://rtc.example.com/api/v1 --auth token --token $RTC_TOKEN",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := configFromContext(cmd.Context())
+
+			item := config.context(args[0])
+			if item == nil {
+				item = &ctlContext{Name: args[0], Auth: client.AuthToken}
+				config.Contexts = append(config.Contexts, item)
+			}
+
+			flags := cmd.Flags()
+
+			if flags.Changed("auth") {
+				if values.Auth != client.AuthToken && values.Auth != client.AuthJWT {
+					return fmt.Errorf("invalid auth %q, expected token or jwt", values.Auth)
+				}
+
+				item.Auth = values.Auth
+			}
+
+			for flag, target := range map[string]*string{
+				"url":      &item.URL,
+				"token":    &item.Token,
+				"username": &item.Username,
+				"project":  &item.Project,
+				"env":      &item.Env,
+			} {
+				if flags.Changed(flag) {
+					*target = flags.Lookup(flag).Value.String()
+				}
+			}
+
+			if item.URL == "" {
+				return errors.New("--url is required for new context")
+			}
+
+			if config.CurrentContext == "" {
+				config.CurrentContext = item.Name
+			}
+
+			if err := config.save(); err != nil {
+				return fmt.Errorf("config.save: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	// url and token shadow global flags, they are values of item
+	cmd.Flags().StringVar(&values.URL, "url", "", "RTC server url e.g. https://rtc.example.com/api/v1")
+	cmd.Flags().StringVar(&values.Auth, "auth", "", "Credentials kind token or jwt (set by rtcctl login)")
+	cmd.Flags().StringVar(&values.Token, "token", "", "API token for token auth")
+	cmd.Flags().StringVar(&values.Username, "username", "", "Default username of rtcctl login")
+	cmd.Flags().StringVarP(&values.Project, "project", "p", "", "Default project")
+	cmd.Flags().StringVarP(&values.Env, "env", "e", "", "Default environment")
+
+	return cmd
+}
+
+func newDeleteContextCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "delete context with its credentials",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := configFromContext(cmd.Context())
+
+			idx := slices.IndexFunc(config.Contexts, func(item *ctlContext) bool {
+				return item.Name == args[0]
+			})
+			if idx == -1 {
+				return fmt.Errorf("context %q not found", args[0])
+			}
+
+			config.Contexts = slices.Delete(config.Contexts, idx, idx+1)
+
+			if config.CurrentContext == args[0] {
+				config.CurrentContext = ""
+			}
+
+			if err := config.save(); err != nil {
+				return fmt.Errorf("config.save: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,324 @@
+package ctl
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+// writeTestConfig save config with contexts to temp dir, path is returned
+func writeTestConfig(t *testing.T, config *ctlConfig) string {
+	t.Helper()
+
+	config.path = filepath.Join(t.TempDir(), "rtcctl", "config.yaml")
+	require.NoError(t, config.save())
+
+	return config.path
+}
+
+func Test_loadConfig_Missing_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, &ctlConfig{path: path}, config)
+}
+
+func Test_loadConfig_Save_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Date(2025, 10, 28, 10, 0, 0, 0, time.UTC)
+
+	want := &ctlConfig{
+		CurrentContext: "prod",
+		Contexts: []*ctlContext{
+			{Name: "prod", URL: "https://rtc.example.com/api/v1", Auth: client.AuthJWT, Username: "admin", JWT: "jwt", RefreshToken: "refresh", ExpiresAt: &expiresAt, Project: "example", Env: "prod"},
+			{Name: "ci", URL: "https://rtc.example.com/api/v1", Auth: client.AuthToken, Token: "secret"},
+		},
+	}
+
+	path := writeTestConfig(t, want)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	got, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+}
+
+func Test_loadConfig_Invalid_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("contexts: {"), 0o600))
+
+	_, err := loadConfig(path)
+	require.ErrorContains(t, err, "yaml.Unmarshal")
+}
+
+func Test_ctlConfig_selected_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	prod := &ctlContext{Name: "prod"}
+	stage := &ctlContext{Name: "stage"}
+
+	tests := []struct {
+		name    string
+		current string
+		context string
+		want    *ctlContext
+	}{
+		{name: "by name", current: "prod", context: "stage", want: stage},
+		{name: "current", current: "prod", want: prod},
+		{name: "none"},
+	}
+
+	for _, tt := range tests {
+		config := &ctlConfig{CurrentContext: tt.current, Contexts: []*ctlContext{prod, stage}}
+
+		got, err := config.selected(tt.context)
+		require.NoError(t, err, tt.name)
+		require.Same(t, tt.want, got, tt.name)
+	}
+}
+
+func Test_ctlConfig_selected_NotFound_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	config := &ctlConfig{CurrentContext: "prod", Contexts: []*ctlContext{{Name: "prod"}}, path: "config.yaml"}
+
+	_, err := config.selected("dev")
+	require.EqualError(t, err, `context "dev" not found in config.yaml`)
+}
+
+func Test_ctlContext_expired_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 10, 28, 10, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		value := now.Add(d)
+		return &value
+	}
+
+	tests := []struct {
+		name    string
+		context *ctlContext
+		want    bool
+	}{
+		{name: "token auth", context: &ctlContext{Auth: client.AuthToken, ExpiresAt: at(-time.Hour)}, want: false},
+		{name: "no expiration", context: &ctlContext{Auth: client.AuthJWT}, want: false},
+		{name: "expired", context: &ctlContext{Auth: client.AuthJWT, ExpiresAt: at(-time.Second)}, want: true},
+		{name: "expires soon", context: &ctlContext{Auth: client.AuthJWT, ExpiresAt: at(10 * time.Second)}, want: true},
+		{name: "valid", context: &ctlContext{Auth: client.AuthJWT, ExpiresAt: at(time.Minute)}, want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, tt.context.expired(now), tt.name)
+	}
+}
+
+func Test_ctlContext_setSession_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 10, 28, 10, 0, 0, 500, time.UTC)
+	expiresAt := time.Date(2025, 10, 28, 10, 15, 0, 0, time.UTC)
+
+	item := &ctlContext{Name: "prod", Auth: client.AuthToken, Token: "secret"}
+	item.setSession(&client.Session{Token: "jwt", RefreshToken: "refresh", ExpiresIn: 900}, now)
+
+	require.Equal(t, &ctlContext{
+		Name:         "prod",
+		Auth:         client.AuthJWT,
+		Token:        "secret",
+		JWT:          "jwt",
+		RefreshToken: "refresh",
+		ExpiresAt:    &expiresAt,
+	}, item)
+
+	kind, token := item.credentials()
+	require.Equal(t, client.AuthJWT, kind)
+	require.Equal(t, "jwt", token)
+}
+
+func Test_refreshSession_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/refresh", r.RequestURI)
+		require.JSONEq(t, `{"refresh_token":"old_refresh"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"data":{"token":"new_jwt","refresh_token":"new_refresh","expires_in":900}}`))
+	}))
+	defer srv.Close()
+
+	expiresAt := time.Now().Add(-time.Minute)
+
+	config := &ctlConfig{
+		CurrentContext: "prod",
+		Contexts: []*ctlContext{{
+			Name:         "prod",
+			URL:          srv.URL + "/api/v1",
+			Auth:         client.AuthJWT,
+			JWT:          "old_jwt",
+			RefreshToken: "old_refresh",
+			ExpiresAt:    &expiresAt,
+		}},
+	}
+	path := writeTestConfig(t, config)
+
+	err := refreshSession(context.Background(), config, config.Contexts[0])
+	require.NoError(t, err)
+
+	saved, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "new_jwt", saved.Contexts[0].JWT)
+	require.Equal(t, "new_refresh", saved.Contexts[0].RefreshToken)
+	require.True(t, saved.Contexts[0].ExpiresAt.After(time.Now()))
+}
+
+func Test_refreshSession_NotExpired_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	// url is not used, request is not sent
+	selected := &ctlContext{Name: "prod", Auth: client.AuthJWT, JWT: "jwt", RefreshToken: "refresh", ExpiresAt: &expiresAt}
+
+	err := refreshSession(context.Background(), &ctlConfig{Contexts: []*ctlContext{selected}}, selected)
+	require.NoError(t, err)
+	require.Equal(t, "jwt", selected.JWT)
+}
+
+func Test_refreshSession_NoRefreshToken_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(-time.Minute)
+	selected := &ctlContext{Name: "prod", Auth: client.AuthJWT, JWT: "jwt", ExpiresAt: &expiresAt}
+
+	err := refreshSession(context.Background(), &ctlConfig{Contexts: []*ctlContext{selected}}, selected)
+	require.EqualError(t, err, "session is expired")
+}
+
+func Test_Commands_ExpiredSession_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v1/refresh":
+			_, _ = w.Write([]byte(`{"data":{"token":"new_jwt","refresh_token":"new_refresh","expires_in":900}}`))
+		case "/api/v1/projects?limit=50&offset=0&q=":
+			require.Equal(t, "jwt new_jwt", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"data":{"projects":[],"total":0}}`))
+		default:
+			http.Error(w, "unexpected request "+r.RequestURI, http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	expiresAt := time.Now().Add(-time.Minute)
+
+	path := writeTestConfig(t, &ctlConfig{
+		CurrentContext: "prod",
+		Contexts: []*ctlContext{{
+			Name:         "prod",
+			URL:          srv.URL + "/api/v1",
+			Auth:         client.AuthJWT,
+			JWT:          "old_jwt",
+			RefreshToken: "old_refresh",
+			ExpiresAt:    &expiresAt,
+		}},
+	})
+
+	_, err := executeCommand(t, path, "projects", "list")
+	require.NoError(t, err)
+
+	saved, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "new_jwt", saved.Contexts[0].JWT)
+	require.Equal(t, "new_refresh", saved.Contexts[0].RefreshToken)
+}
+
+func Test_ContextCommands_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	_, err := executeCommand(t, path, "context", "set", "prod", "--url", "https://rtc.example.com/api/v1", "-p", "example", "-e", "prod")
+	require.NoError(t, err)
+
+	_, err = executeCommand(t, path, "context", "set", "ci", "--url", "https://ci.example.com/api/v1", "--token", "secret")
+	require.NoError(t, err)
+
+	// first context is current
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "prod", config.CurrentContext)
+	require.Equal(t, &ctlContext{Name: "ci", URL: "https://ci.example.com/api/v1", Auth: client.AuthToken, Token: "secret"}, config.context("ci"))
+
+	_, err = executeCommand(t, path, "context", "use", "ci")
+	require.NoError(t, err)
+
+	got, err := executeCommand(t, path, "context", "list")
+	require.NoError(t, err)
+	require.Equal(t, "CURRENT  NAME  URL                             AUTH   USERNAME  PROJECT  ENV\n"+
+		"         prod  https://rtc.example.com/api/v1  token            example  prod\n"+
+		"*        ci    https://ci.example.com/api/v1   token                     \n", got)
+
+	_, err = executeCommand(t, path, "context", "delete", "ci")
+	require.NoError(t, err)
+
+	config, err = loadConfig(path)
+	require.NoError(t, err)
+	require.Empty(t, config.CurrentContext)
+	require.Len(t, config.Contexts, 1)
+	require.Equal(t, "prod", config.Contexts[0].Name)
+}
+
+func Test_ContextCommands_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	path := writeTestConfig(t, &ctlConfig{
+		CurrentContext: "prod",
+		Contexts:       []*ctlContext{{Name: "prod", URL: "https://rtc.example.com/api/v1"}},
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "use unknown", args: []string{"context", "use", "dev"}, want: `context "dev" not found`},
+		{name: "delete unknown", args: []string{"context", "delete", "dev"}, want: `context "dev" not found`},
+		{name: "set without url", args: []string{"context", "set", "dev"}, want: "--url is required for new context"},
+		{name: "set invalid auth", args: []string{"context", "set", "prod", "--auth", "basic"}, want: `invalid auth "basic", expected token or jwt`},
+		{name: "unknown context flag", args: []string{"projects", "list", "--context", "dev"}, want: `context "dev" not found in ` + path},
+	}
+
+	for _, tt := range tests {
+		_, err := executeCommand(t, path, tt.args...)
+		require.EqualError(t, err, tt.want, tt.name)
+	}
+
+	// config is not changed by failed commands
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "prod", config.CurrentContext)
+	require.Len(t, config.Contexts, 1)
+}
@@ -1,10 +1,16 @@
 package ctl
 
-import "github.com/DesSolo/rtc/internal/ctl/client"
+import (
+	"maps"
+	"slices"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
 
 func convertConfigsToReq(configs upsertConfigYaml) []*client.UpsertConfigRequest {
 	result := make([]*client.UpsertConfigRequest, 0, len(configs))
-	for key, options := range configs {
+	for _, key := range slices.Sorted(maps.Keys(configs)) {
+		options := configs[key]
 		result = append(result, &client.UpsertConfigRequest{
 			Key:       key,
 			Value:     options.Value,
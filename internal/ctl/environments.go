@@ -0,0 +1,50 @@
+package ctl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+func newEnvsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "envs",
+		Short: "manage project environments",
+	}
+
+	cmd.AddCommand(newListEnvsCommand())
+
+	return cmd
+}
+
+func newListEnvsCommand() *cobra.Command {
+	var projectName string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list project environments",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			envs, err := clientFromContext(ctx).ListEnvironments(ctx, projectName)
+			if err != nil {
+				return fmt.Errorf("client.ListEnvironments: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), envs, func(w io.Writer) {
+				fmt.Fprintln(w, "NAME")
+
+				for _, env := range envs {
+					fmt.Fprintln(w, env.Name)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
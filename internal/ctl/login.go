@@ -0,0 +1,128 @@
+package ctl
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+// defaultContextName context created by login without config file
+const defaultContextName = "default"
+
+func newLoginCommand() *cobra.Command {
+	var req client.LoginRequest
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "login by username and password, session is saved to context (password is read from stdin if flag is not set)",
+		Example: "  rtcctl login --url https://rtc.example.com/api/v1 --username admin\n" +
+			"  rtcctl login --context prod --otp 123456",
+		// credentials of context are not used
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return setup(cmd)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			flags := cmd.Root().PersistentFlags()
+			config := configFromContext(ctx)
+
+			selected, err := config.selected(flags.Lookup("context").Value.String())
+			if err != nil {
+				return err
+			}
+
+			if selected == nil {
+				selected = config.context(defaultContextName)
+			}
+
+			if selected == nil {
+				selected = &ctlContext{Name: defaultContextName}
+				config.Contexts = append(config.Contexts, selected)
+			}
+
+			if flags.Changed("url") || selected.URL == "" {
+				selected.URL = flags.Lookup("url").Value.String()
+			}
+
+			req.Username = cmp.Or(req.Username, selected.Username)
+
+			reader := bufio.NewReader(cmd.InOrStdin())
+
+			if err := promptValue(cmd.ErrOrStderr(), reader, "username: ", &req.Username); err != nil {
+				return err
+			}
+
+			if err := promptSecret(cmd.ErrOrStderr(), cmd.InOrStdin(), reader, "password: ", &req.Password); err != nil {
+				return err
+			}
+
+			session, err := client.NewClient(selected.URL, "", "").Login(ctx, &req)
+			if err != nil {
+				return fmt.Errorf("client.Login: %w", err)
+			}
+
+			selected.Username = req.Username
+			selected.setSession(session, time.Now())
+
+			config.CurrentContext = cmp.Or(config.CurrentContext, selected.Name)
+
+			if err := config.save(); err != nil {
+				return fmt.Errorf("config.save: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "logged in to %s as %s, session is saved to context %s\n", selected.URL, req.Username, selected.Name)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Username, "username", "", "Username (default from context)")
+	cmd.Flags().StringVar(&req.Password, "password", "", "Password")
+	cmd.Flags().StringVar(&req.NewPassword, "new-password", "", "New password if one-time password must be changed")
+	cmd.Flags().StringVar(&req.OTP, "otp", "", "TOTP or recovery code if two-factor authentication is enabled")
+
+	return cmd
+}
+
+func newLogoutCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "revoke session of context and remove it from config file",
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return setup(cmd)
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			config := configFromContext(ctx)
+
+			selected, err := config.selected(cmd.Root().PersistentFlags().Lookup("context").Value.String())
+			if err != nil {
+				return err
+			}
+
+			if selected == nil || selected.RefreshToken == "" {
+				return nil
+			}
+
+			if err := client.NewClient(selected.URL, "", "").Logout(ctx, selected.RefreshToken); err != nil {
+				return fmt.Errorf("client.Logout: %w", err)
+			}
+
+			selected.JWT = ""
+			selected.RefreshToken = ""
+			selected.ExpiresAt = nil
+
+			if err := config.save(); err != nil {
+				return fmt.Errorf("config.save: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
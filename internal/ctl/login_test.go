@@ -0,0 +1,105 @@
+package ctl
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+// newLoginTestServer respond with session to login request with body
+func newLoginTestServer(t *testing.T, want string) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/login", r.RequestURI)
+		require.JSONEq(t, want, string(body))
+
+		_, _ = w.Write([]byte(`{"data":{"token":"jwt","refresh_token":"refresh","expires_in":900}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func Test_LoginCommand_Stdin_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	srv := newLoginTestServer(t, `{"username":"admin","password":"secret"}`)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	_, err := executeCommandWithInput(t, path, "admin\nsecret\n", "login", "--url", srv.URL+"/api/v1")
+	require.NoError(t, err)
+
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, defaultContextName, config.CurrentContext)
+
+	saved := config.context(defaultContextName)
+	require.NotNil(t, saved)
+	require.Equal(t, srv.URL+"/api/v1", saved.URL)
+	require.Equal(t, client.AuthJWT, saved.Auth)
+	require.Equal(t, "admin", saved.Username)
+	require.Equal(t, "jwt", saved.JWT)
+	require.Equal(t, "refresh", saved.RefreshToken)
+	require.True(t, saved.ExpiresAt.After(time.Now()))
+}
+
+func Test_LoginCommand_Context_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	srv := newLoginTestServer(t, `{"username":"admin","password":"secret","otp":"123456"}`)
+
+	path := writeTestConfig(t, &ctlConfig{
+		CurrentContext: "ci",
+		Contexts: []*ctlContext{
+			{Name: "ci", URL: "https://ci.example.com/api/v1", Auth: client.AuthToken, Token: "token"},
+			{Name: "prod", URL: srv.URL + "/api/v1", Username: "admin"},
+		},
+	})
+
+	// username is taken from context, password from stdin
+	_, err := executeCommandWithInput(t, path, "secret\n", "login", "--context", "prod", "--otp", "123456")
+	require.NoError(t, err)
+
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, "ci", config.CurrentContext)
+	require.Equal(t, "token", config.context("ci").Token)
+	require.Equal(t, "jwt", config.context("prod").JWT)
+}
+
+func Test_LoginCommand_EmptyPassword_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := executeCommandWithInput(t, filepath.Join(t.TempDir(), "config.yaml"), "admin\n\n", "login")
+	require.EqualError(t, err, "password is required")
+}
+
+func Test_promptSecret_NotTerminal_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	var w bytes.Buffer
+
+	in := strings.NewReader("secret\n")
+
+	var value string
+
+	err := promptSecret(&w, in, bufio.NewReader(in), "password: ", &value)
+	require.NoError(t, err)
+	require.Equal(t, "secret", value)
+	require.Equal(t, "password: ", w.String())
+}
@@ -0,0 +1,130 @@
+package ctl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func newMembersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "manage project members, use project * for all projects",
+	}
+
+	cmd.AddCommand(
+		newListMembersCommand(),
+		newAddMemberCommand(),
+		newRemoveMemberCommand(),
+	)
+
+	return cmd
+}
+
+func newListMembersCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <project>",
+		Short: "list project members",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			members, err := clientFromContext(ctx).ListMembers(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("client.ListMembers: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), members, func(w io.Writer) {
+				fmt.Fprintln(w, "ID\tKIND\tSUBJECT\tENV\tROLE")
+
+				for _, member := range members {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+						member.ID,
+						member.Kind,
+						member.Subject,
+						member.Env,
+						member.Role,
+					)
+				}
+			})
+		},
+	}
+}
+
+func newAddMemberCommand() *cobra.Command {
+	var (
+		user  string
+		group string
+		env   string
+		role  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <project>",
+		Short: "bind role to user or group in project",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			req := &client.AddMemberRequest{
+				Env:  env,
+				Role: role,
+			}
+
+			switch {
+			case user != "" && group == "":
+				req.Kind, req.Subject = "user", user
+			case group != "" && user == "":
+				req.Kind, req.Subject = "group", group
+			default:
+				return errors.New("one of --user or --group is required")
+			}
+
+			member, err := clientFromContext(ctx).AddMember(ctx, args[0], req)
+			if err != nil {
+				return fmt.Errorf("client.AddMember: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "member %d added\n", member.ID)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "Username")
+	cmd.Flags().StringVar(&group, "group", "", "Group (user role) e.g. mapped from LDAP groups")
+	cmd.Flags().StringVarP(&env, "env", "e", "*", "Environment pattern e.g. prod, dev-*")
+	cmd.Flags().StringVarP(&role, "role", "r", "", "Role viewer, editor or admin")
+
+	_ = cmd.MarkFlagRequired("role")
+
+	return cmd
+}
+
+func newRemoveMemberCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <project> <id>",
+		Short: "remove project member",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			id, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid member id: %w", err)
+			}
+
+			if err := clientFromContext(ctx).RemoveMember(ctx, args[0], id); err != nil {
+				return fmt.Errorf("client.RemoveMember: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
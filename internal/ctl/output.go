@@ -0,0 +1,103 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of --output flag
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+type contextKeyOutput struct{}
+
+func outputToContext(ctx context.Context, output string) context.Context {
+	return context.WithValue(ctx, contextKeyOutput{}, output)
+}
+
+func outputFromContext(ctx context.Context) string {
+	output, ok := ctx.Value(contextKeyOutput{}).(string)
+	if !ok {
+		return outputTable
+	}
+
+	return output
+}
+
+func validOutput(output string) bool {
+	switch output {
+	case outputTable, outputJSON, outputYAML:
+		return true
+	default:
+		return false
+	}
+}
+
+// render print value to out in format of --output flag, table is written by callback
+func render(ctx context.Context, out io.Writer, value any, table func(w io.Writer)) error {
+	switch outputFromContext(ctx) {
+	case outputJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value) // nolint:wrapcheck
+	case outputYAML:
+		data, err := marshalYAML(value)
+		if err != nil {
+			return fmt.Errorf("marshalYAML: %w", err)
+		}
+
+		_, err = out.Write(data)
+
+		return err // nolint:wrapcheck
+	default:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		table(w)
+
+		return w.Flush() // nolint:wrapcheck
+	}
+}
+
+// marshalYAML client types have only json tags, so YAML is converted from JSON keeping field names and order
+func marshalYAML(value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	resetYAMLStyle(&node)
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("yaml.Encode: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// resetYAMLStyle JSON is decoded in flow style, block style is more readable
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,132 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func Test_render_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	value := &client.ProjectsPage{
+		Projects: []*client.Project{{Name: "example", Description: "example service"}},
+		Total:    1,
+	}
+
+	table := func(w io.Writer) {
+		fmt.Fprintln(w, "NAME\tDESCRIPTION")
+
+		for _, project := range value.Projects {
+			fmt.Fprintf(w, "%s\t%s\n", project.Name, project.Description)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "table",
+			output: outputTable,
+			want:   "NAME     DESCRIPTION\nexample  example service\n",
+		},
+		{
+			name:   "json",
+			output: outputJSON,
+			want: `{
+  "projects": [
+    {
+      "name": "example",
+      "description": "example service",
+      "created_at": "0001-01-01T00:00:00Z"
+    }
+  ],
+  "total": 1
+}
+`,
+		},
+		{
+			name:   "yaml",
+			output: outputYAML,
+			want: `projects:
+  - name: example
+    description: example service
+    created_at: "0001-01-01T00:00:00Z"
+total: 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+
+		err := render(outputToContext(context.Background(), tt.output), &buf, value, table)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, buf.String(), tt.name)
+	}
+}
+
+func Test_render_DefaultTable_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	err := render(context.Background(), &buf, nil, func(w io.Writer) {
+		fmt.Fprintln(w, "a\tb")
+	})
+	require.NoError(t, err)
+	require.Equal(t, "a  b\n", buf.String())
+}
+
+func Test_validOutput_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{output: outputTable, want: true},
+		{output: outputJSON, want: true},
+		{output: outputYAML, want: true},
+		{output: "xml", want: false},
+		{output: "", want: false},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, validOutput(tt.output), tt.output)
+	}
+}
+
+func Test_printConfigsPlan_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	printConfigsPlan(&buf, &client.ConfigsPlan{
+		Added: []*client.ConfigPlanItem{{Key: "new_key", NewType: "int", ValueReset: true, Value: "5"}},
+		Changed: []*client.ConfigPlanItem{{
+			Key:         "pool",
+			OldType:     "string",
+			NewType:     "int",
+			OldMetadata: &client.ConfigPlanMetadata{Usage: "a"},
+			NewMetadata: &client.ConfigPlanMetadata{Usage: "b"},
+		}},
+		Removed:   []*client.ConfigPlanItem{{Key: "old_key", OldType: "float"}},
+		Kept:      []*client.ConfigPlanItem{{Key: "kept_key", OldType: "bool"}},
+		Unchanged: []*client.ConfigPlanItem{{Key: "same", NewType: "string"}},
+	})
+
+	require.Equal(t, "+ new_key\tint\tvalue set to \"5\"\n"+
+		"~ pool\ttype string -> int, usage \"a\" -> \"b\"\tvalue kept\n"+
+		"- old_key\tfloat\tvalue deleted\n"+
+		"  kept_key\tbool\tnot in values file, kept\n"+
+		"\nplan: 1 to add, 1 to change, 1 to remove, 1 kept, 1 unchanged\n", buf.String())
+}
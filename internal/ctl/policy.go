@@ -0,0 +1,202 @@
+package ctl
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func newPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "debug authorization policy",
+	}
+
+	cmd.AddCommand(
+		newEvalPolicyCommand(),
+		newTestPolicyCommand(),
+	)
+
+	return cmd
+}
+
+func newEvalPolicyCommand() *cobra.Command {
+	var (
+		req       client.EvalPolicyRequest
+		bodyPath  string
+		showInput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "evaluate server authorizer for user request",
+		Example: "  rtcctl policy eval --user simple_user --method PUT --path /api/v1/projects/example/envs/prod/releases/v1/configs --body values.json\n" +
+			"  rtcctl policy eval --user ci --role deployer --path /api/v1/projects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			if bodyPath != "" {
+				body, err := readFileOrStdin(cmd.InOrStdin(), bodyPath)
+				if err != nil {
+					return fmt.Errorf("readFileOrStdin: %w", err)
+				}
+
+				req.Body = body
+			}
+
+			decision, err := clientFromContext(ctx).EvalPolicy(ctx, &req)
+			if err != nil {
+				return fmt.Errorf("client.EvalPolicy: %w", err)
+			}
+
+			var input []byte
+			if showInput {
+				input, err = json.MarshalIndent(decision.Input, "", "  ")
+				if err != nil {
+					return fmt.Errorf("json.MarshalIndent: %w", err)
+				}
+			}
+
+			return render(ctx, cmd.OutOrStdout(), decision, func(w io.Writer) {
+				printDecision(w, decision.Action, &auth.Decision{
+					Allowed:     decision.Allowed,
+					Reason:      decision.Reason,
+					Explanation: decision.Explanation,
+				})
+
+				if showInput {
+					fmt.Fprintf(w, "input:\n%s\n", input)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Username, "user", "", "Username")
+	cmd.Flags().StringSliceVar(&req.Roles, "role", nil, "User roles (roles of stored user by default)")
+	cmd.Flags().StringVarP(&req.Method, "method", "X", "GET", "Request method")
+	cmd.Flags().StringVarP(&req.Path, "path", "p", "", "Request path e.g. /api/v1/projects")
+	cmd.Flags().StringVarP(&bodyPath, "body", "d", "", "Path to JSON request body (- for stdin)")
+	cmd.Flags().StringVar(&req.Explain, "explain", "fails", "Explanation mode full, notes or fails")
+	cmd.Flags().BoolVar(&showInput, "show-input", false, "Print authorizer input")
+
+	_ = cmd.MarkFlagRequired("user")
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+type policyTestCases struct {
+	Query string `yaml:"query"`
+	Cases []struct {
+		Name  string         `yaml:"name"`
+		Input map[string]any `yaml:"input"`
+		Allow bool           `yaml:"allow"`
+	} `yaml:"cases"`
+}
+
+func newTestPolicyCommand() *cobra.Command {
+	var (
+		query   string
+		explain string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "test <policy.rego> <cases.yaml>",
+		Short:   "run rego policy against table of cases offline",
+		Example: "  rtcctl policy test examples/authz.rego examples/authz_cases.yaml",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			mode, err := auth.ParseExplainMode(explain)
+			if err != nil {
+				return fmt.Errorf("auth.ParseExplainMode: %w", err)
+			}
+
+			data, err := os.ReadFile(args[1])
+			if err != nil {
+				return fmt.Errorf("os.ReadFile: %w", err)
+			}
+
+			var tests policyTestCases
+			if err := yaml.Unmarshal(data, &tests); err != nil {
+				return fmt.Errorf("yaml.Unmarshal: %w", err)
+			}
+
+			query = cmp.Or(query, tests.Query, "data.authz.allow")
+
+			policy, err := auth.PrepareRego(ctx, query, args[0])
+			if err != nil {
+				return fmt.Errorf("auth.PrepareRego: %w", err)
+			}
+
+			authorizer := auth.NewRego(policy)
+
+			out := cmd.OutOrStdout()
+
+			var failed int
+
+			for _, test := range tests.Cases {
+				decision := auth.Explain(ctx, authorizer, test.Input, mode)
+				if decision.Allowed == test.Allow {
+					fmt.Fprintf(out, "PASS %s\n", test.Name)
+					continue
+				}
+
+				failed++
+
+				fmt.Fprintf(out, "FAIL %s: expected allow=%t\n", test.Name, test.Allow)
+
+				for _, line := range decision.Explanation {
+					fmt.Fprintf(out, "    %s\n", line)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d cases failed", failed, len(tests.Cases))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&query, "query", "q", "", "Policy query (default from cases file or data.authz.allow)")
+	cmd.Flags().StringVar(&explain, "explain", "fails", "Explanation mode of failed cases full, notes or fails")
+
+	return cmd
+}
+
+func printDecision(w io.Writer, action string, decision *auth.Decision) {
+	result := "denied"
+	if decision.Allowed {
+		result = "allowed"
+	}
+
+	fmt.Fprintf(w, "decision: %s\n", result)
+	fmt.Fprintf(w, "action:   %s\n", action)
+
+	if decision.Reason != "" {
+		fmt.Fprintf(w, "reason:   %s\n", decision.Reason)
+	}
+
+	if len(decision.Explanation) > 0 {
+		fmt.Fprintf(w, "explanation:\n    %s\n", strings.Join(decision.Explanation, "\n    "))
+	}
+}
+
+func readFileOrStdin(in io.Reader, path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(in) // nolint:wrapcheck
+	}
+
+	return os.ReadFile(path) // nolint:wrapcheck,gosec
+}
@@ -0,0 +1,88 @@
+package ctl
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EvalPolicyCommand_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/api/v1/policy/eval", r.RequestURI)
+		require.JSONEq(t, `{"username":"bob","method":"PUT","path":"/api/v1/projects/example","body":{"description":"new"},"explain":"fails"}`, string(body))
+
+		_, _ = w.Write([]byte(`{"data":{"allowed":false,"reason":"not a member","action":"update_project","input":{"method":"PUT"},"explanation":["deny"]}}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "table",
+			args: []string{"--show-input"},
+			want: "decision: denied\naction:   update_project\nreason:   not a member\nexplanation:\n    deny\ninput:\n{\n  \"method\": \"PUT\"\n}\n",
+		},
+		{
+			name: "json",
+			args: []string{"-o", "json"},
+			want: "{\n  \"allowed\": false,\n  \"reason\": \"not a member\",\n  \"action\": \"update_project\",\n" +
+				"  \"input\": {\n    \"method\": \"PUT\"\n  },\n  \"explanation\": [\n    \"deny\"\n  ]\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		args := append([]string{
+			"policy", "eval", "--user", "bob", "-X", "PUT", "-p", "/api/v1/projects/example", "-d", "-",
+			"--url", srv.URL + "/api/v1",
+		}, tt.args...)
+
+		// body is read from command input
+		got, err := executeCommandWithInput(t, filepath.Join(t.TempDir(), "config.yaml"), `{"description":"new"}`, args...)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+func Test_TestPolicyCommand_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	policyPath := filepath.Join(dir, "authz.rego")
+	require.NoError(t, os.WriteFile(policyPath, []byte(`package authz
+
+default allow := false
+
+allow if input.user.username == "admin"
+`), 0o600))
+
+	casesPath := filepath.Join(dir, "cases.yaml")
+	require.NoError(t, os.WriteFile(casesPath, []byte(`cases:
+  - name: admin
+    input: {user: {username: admin}}
+    allow: true
+  - name: bob
+    input: {user: {username: bob}}
+    allow: true
+`), 0o600))
+
+	got, err := executeCommand(t, filepath.Join(dir, "config.yaml"), "policy", "test", policyPath, casesPath, "--explain", "notes")
+	require.EqualError(t, err, "1 of 2 cases failed")
+	// usage is printed after failed command
+	require.True(t, strings.HasPrefix(got, "PASS admin\nFAIL bob: expected allow=true\n"), got)
+}
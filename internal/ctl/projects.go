@@ -0,0 +1,149 @@
+package ctl
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func newProjectsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "projects",
+		Short: "manage projects",
+	}
+
+	cmd.AddCommand(
+		newListProjectsCommand(),
+		newCreateProjectCommand(),
+		newUpdateProjectCommand(),
+		newDeleteProjectCommand(),
+	)
+
+	return cmd
+}
+
+func newListProjectsCommand() *cobra.Command {
+	var (
+		q      string
+		limit  uint64
+		offset uint64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list projects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			page, err := clientFromContext(ctx).ListProjects(ctx, q, limit, offset)
+			if err != nil {
+				return fmt.Errorf("client.ListProjects: %w", err)
+			}
+
+			if err := render(ctx, cmd.OutOrStdout(), page, func(w io.Writer) {
+				fmt.Fprintln(w, "NAME\tDESCRIPTION\tCREATED")
+
+				for _, project := range page.Projects {
+					fmt.Fprintf(w, "%s\t%s\t%s\n",
+						project.Name,
+						project.Description,
+						project.CreatedAt.Format("2006-01-02 15:04:05"),
+					)
+				}
+
+			}); err != nil {
+				return err
+			}
+
+			if shown := offset + uint64(len(page.Projects)); shown < page.Total {
+				fmt.Fprintf(os.Stderr, "shown %d of %d projects, use --offset %d for next page\n", shown, page.Total, shown)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&q, "query", "q", "", "Search by name")
+	cmd.Flags().Uint64Var(&limit, "limit", 50, "Max projects to show")
+	cmd.Flags().Uint64Var(&offset, "offset", 0, "Projects to skip")
+
+	return cmd
+}
+
+func newCreateProjectCommand() *cobra.Command {
+	var description string
+
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   "create project",
+		Example: "  rtcctl projects create example -d \"example service\"",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			project, err := clientFromContext(ctx).CreateProject(ctx, &client.CreateProjectRequest{
+				Name:        args[0],
+				Description: description,
+			})
+			if err != nil {
+				return fmt.Errorf("client.CreateProject: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), project, func(w io.Writer) {
+				fmt.Fprintf(w, "project %s created\n", project.Name)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Project description")
+
+	_ = cmd.MarkFlagRequired("description")
+
+	return cmd
+}
+
+func newUpdateProjectCommand() *cobra.Command {
+	var description string
+
+	cmd := &cobra.Command{
+		Use:   "update <name>",
+		Short: "change project description",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := clientFromContext(ctx).UpdateProject(ctx, args[0], description); err != nil {
+				return fmt.Errorf("client.UpdateProject: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Project description")
+
+	_ = cmd.MarkFlagRequired("description")
+
+	return cmd
+}
+
+func newDeleteProjectCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "delete project with all environments, releases and configs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := clientFromContext(ctx).DeleteProject(ctx, args[0]); err != nil {
+				return fmt.Errorf("client.DeleteProject: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
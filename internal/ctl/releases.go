@@ -0,0 +1,91 @@
+package ctl
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+func newReleasesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "releases",
+		Short: "manage releases of project environment",
+	}
+
+	cmd.AddCommand(
+		newListReleasesCommand(),
+		newDeleteReleaseCommand(),
+	)
+
+	return cmd
+}
+
+func newListReleasesCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list releases",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			releases, err := clientFromContext(ctx).ListReleases(ctx, projectName, envName)
+			if err != nil {
+				return fmt.Errorf("client.ListReleases: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), releases, func(w io.Writer) {
+				fmt.Fprintln(w, "NAME\tCREATED")
+
+				for _, release := range releases {
+					fmt.Fprintf(w, "%s\t%s\n",
+						release.Name,
+						release.CreatedAt.Format("2006-01-02 15:04:05"),
+					)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("env")
+
+	return cmd
+}
+
+func newDeleteReleaseCommand() *cobra.Command {
+	var (
+		projectName string
+		envName     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete <release>",
+		Short: "delete release with its configs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := clientFromContext(ctx).DeleteRelease(ctx, projectName, envName, args[0]); err != nil {
+				return fmt.Errorf("client.DeleteRelease: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&projectName, "project", "p", "", "Project name")
+	cmd.Flags().StringVarP(&envName, "env", "e", "", "Environment name")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("env")
+
+	return cmd
+}
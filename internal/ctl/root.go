@@ -1,6 +1,7 @@
 package ctl
 
 import (
+	"cmp"
 	"fmt"
 	"log/slog"
 	"os"
@@ -24,38 +25,133 @@ func newRootCommand() *cobra.Command {
 		Use:   "rtcctl",
 		Short: "rtcctl is a tool to manage RTC",
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
-			url := cmd.Flags().Lookup("url").Value.String()
-
-			token := cmd.Flags().Lookup("token").Value.String()
-			if token == "" {
-				token = os.Getenv("RTCCTL_TOKEN")
-			}
-
-			logLevel, err := parseLogLevel(cmd.Flags().Lookup("log-level").Value.String())
-			if err != nil {
-				return fmt.Errorf("invalid log level: %w", err)
+			if err := setup(cmd); err != nil {
+				return err
 			}
 
-			slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-				Level: logLevel,
-			})))
-
-			rtcClient := client.NewClient(url, token)
-
-			cmd.SetContext(clientToContext(cmd.Context(), rtcClient))
-			return nil
+			return setupClient(cmd)
 		},
 	}
 
-	cmd.PersistentFlags().StringP("url", "u", "http://localhost:8080/api/v1", "RTC server url")
+	cmd.PersistentFlags().StringP("url", "u", "http://localhost:8080/api/v1", "RTC server url, overrides url of context")
 	cmd.PersistentFlags().StringP("token", "t", "", "RTC server token")
+	cmd.PersistentFlags().String("context", "", "context of config file (current context by default)")
+	cmd.PersistentFlags().String("config", "", "config file (default $RTCCTL_CONFIG or ~/.config/rtcctl/config.yaml)")
 	cmd.PersistentFlags().StringP("log-level", "l", "0", "log level info=0 debug=-4")
+	cmd.PersistentFlags().StringP("output", "o", outputTable, "output format table, json or yaml")
 
-	cmd.AddCommand(newConfigsCommand())
+	cmd.AddCommand(
+		newAuditCommand(),
+		newConfigsCommand(),
+		newContextCommand(),
+		newEnvsCommand(),
+		newLoginCommand(),
+		newLogoutCommand(),
+		newMembersCommand(),
+		newPolicyCommand(),
+		newProjectsCommand(),
+		newReleasesCommand(),
+		newTokensCommand(),
+		newUsersCommand(),
+	)
 
 	return cmd
 }
 
+// setup logger, output format and config file
+func setup(cmd *cobra.Command) error {
+	flags := cmd.Root().PersistentFlags()
+
+	output := flags.Lookup("output").Value.String()
+	if !validOutput(output) {
+		return fmt.Errorf("invalid output %q, expected table, json or yaml", output)
+	}
+
+	logLevel, err := parseLogLevel(flags.Lookup("log-level").Value.String())
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: logLevel,
+	})))
+
+	configPath := cmp.Or(flags.Lookup("config").Value.String(), os.Getenv("RTCCTL_CONFIG"), defaultConfigPath())
+
+	config, err := loadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("loadConfig %s: %w", configPath, err)
+	}
+
+	ctx := outputToContext(cmd.Context(), output)
+	cmd.SetContext(configToContext(ctx, config))
+
+	return nil
+}
+
+// setupClient credentials are taken from --token, RTCCTL_TOKEN or selected context
+func setupClient(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	flags := cmd.Root().PersistentFlags()
+	config := configFromContext(ctx)
+
+	selected, err := config.selected(flags.Lookup("context").Value.String())
+	if err != nil {
+		return err
+	}
+
+	url := flags.Lookup("url").Value.String()
+	authKind, token := client.AuthToken, cmp.Or(flags.Lookup("token").Value.String(), os.Getenv("RTCCTL_TOKEN"))
+
+	if selected != nil {
+		if !flags.Changed("url") {
+			url = selected.URL
+		}
+
+		if token == "" {
+			if err := refreshSession(ctx, config, selected); err != nil {
+				slog.WarnContext(ctx, "failed to refresh session, run rtcctl login", "context", selected.Name, "err", err)
+			}
+
+			authKind, token = selected.credentials()
+		}
+
+		if err := applyContextDefaults(cmd, selected); err != nil {
+			return err
+		}
+	}
+
+	cmd.SetContext(clientToContext(ctx, client.NewClient(url, authKind, token)))
+
+	return nil
+}
+
+// skipContextDefaults annotation of commands where --project and --env are optional filters
+const skipContextDefaults = "skip_context_defaults"
+
+// applyContextDefaults project and environment of context are used if flags are not set
+func applyContextDefaults(cmd *cobra.Command, selected *ctlContext) error {
+	if _, ok := cmd.Annotations[skipContextDefaults]; ok {
+		return nil
+	}
+
+	for name, value := range map[string]string{
+		"project": selected.Project,
+		"env":     selected.Env,
+	} {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil || flag.Changed || value == "" {
+			continue
+		}
+
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("set %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
 func parseLogLevel(logLevel string) (slog.Level, error) {
 	val, err := strconv.Atoi(logLevel)
 	if err != nil {
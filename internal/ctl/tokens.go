@@ -0,0 +1,133 @@
+package ctl
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func newTokensCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tokens",
+		Short: "manage api tokens",
+	}
+
+	cmd.AddCommand(
+		newListTokensCommand(),
+		newCreateTokenCommand(),
+		newRevokeTokenCommand(),
+	)
+
+	return cmd
+}
+
+func newListTokensCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "list own api tokens",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			tokens, err := clientFromContext(ctx).ListTokens(ctx)
+			if err != nil {
+				return fmt.Errorf("client.ListTokens: %w", err)
+			}
+
+			return render(ctx, cmd.OutOrStdout(), tokens, func(w io.Writer) {
+				fmt.Fprintln(w, "ID\tNAME\tSCOPES\tEXPIRES\tLAST USED\tREVOKED")
+
+				for _, token := range tokens {
+					fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n",
+						token.ID,
+						token.Name,
+						strings.Join(token.Scopes, ","),
+						formatOptionalTime(token.ExpiresAt),
+						formatOptionalTime(token.LastUsedAt),
+						formatOptionalTime(token.RevokedAt),
+					)
+				}
+			})
+		},
+	}
+}
+
+func newCreateTokenCommand() *cobra.Command {
+	var (
+		name      string
+		scopes    []string
+		expiresIn time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "create api token",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			req := &client.CreateTokenRequest{
+				Name:   name,
+				Scopes: scopes,
+			}
+
+			if expiresIn > 0 {
+				expiresAt := time.Now().Add(expiresIn)
+				req.ExpiresAt = &expiresAt
+			}
+
+			token, err := clientFromContext(ctx).CreateToken(ctx, req)
+			if err != nil {
+				return fmt.Errorf("client.CreateToken: %w", err)
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "token %d created, it will not be shown again\n", token.ID)
+
+			return render(ctx, cmd.OutOrStdout(), token, func(w io.Writer) {
+				fmt.Fprintln(w, token.Token)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Token name")
+	cmd.Flags().StringSliceVarP(&scopes, "scope", "s", nil, "Token scopes e.g. read, project:example")
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "Token lifetime e.g. 720h (never expires by default)")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func newRevokeTokenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "revoke api token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid token id: %w", err)
+			}
+
+			if err := clientFromContext(ctx).RevokeToken(ctx, id); err != nil {
+				return fmt.Errorf("client.RevokeToken: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,291 @@
+package ctl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/DesSolo/rtc/internal/ctl/client"
+)
+
+func newUsersCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "manage users",
+	}
+
+	cmd.AddCommand(
+		newListUsersCommand(),
+		newCreateUserCommand(),
+		newEnableUserCommand(true),
+		newEnableUserCommand(false),
+		newSetUserRolesCommand(),
+		newChangePasswordCommand(),
+		newResetPasswordCommand(),
+		newResetTOTPCommand(),
+	)
+
+	return cmd
+}
+
+func newListUsersCommand() *cobra.Command {
+	var (
+		q      string
+		limit  uint64
+		offset uint64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "list users",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			page, err := clientFromContext(ctx).ListUsers(ctx, q, limit, offset)
+			if err != nil {
+				return fmt.Errorf("client.ListUsers: %w", err)
+			}
+
+			if err := render(ctx, cmd.OutOrStdout(), page, func(w io.Writer) {
+				fmt.Fprintln(w, "USERNAME\tENABLED\tROLES\tSOURCE\t2FA\tCREATED")
+
+				for _, user := range page.Users {
+					fmt.Fprintf(w, "%s\t%t\t%s\t%s\t%t\t%s\n",
+						user.Username,
+						user.IsEnabled,
+						strings.Join(user.Roles, ","),
+						user.Source,
+						user.TwoFactorEnabled,
+						user.CreatedAt.Format("2006-01-02 15:04:05"),
+					)
+				}
+
+			}); err != nil {
+				return err
+			}
+
+			if shown := offset + uint64(len(page.Users)); shown < page.Total {
+				fmt.Fprintf(os.Stderr, "shown %d of %d users, use --offset %d for next page\n", shown, page.Total, shown)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&q, "query", "q", "", "Search by username")
+	cmd.Flags().Uint64Var(&limit, "limit", 50, "Max users to show")
+	cmd.Flags().Uint64Var(&offset, "offset", 0, "Users to skip")
+
+	return cmd
+}
+
+func newCreateUserCommand() *cobra.Command {
+	var (
+		req      client.CreateUserRequest
+		disabled bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "create <username>",
+		Short:   "create local user (password is read from stdin if flag is not set)",
+		Example: "  rtcctl users create alice --role developers",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			reader := bufio.NewReader(cmd.InOrStdin())
+
+			if err := promptSecret(cmd.ErrOrStderr(), cmd.InOrStdin(), reader, "password: ", &req.Password); err != nil {
+				return err
+			}
+
+			req.Username = args[0]
+			req.IsEnabled = !disabled
+
+			if err := clientFromContext(ctx).CreateUser(ctx, &req); err != nil {
+				return fmt.Errorf("client.CreateUser: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Password, "password", "", "Password")
+	cmd.Flags().StringSliceVar(&req.Roles, "role", nil, "User roles")
+	cmd.Flags().BoolVar(&disabled, "disabled", false, "Create disabled user")
+
+	return cmd
+}
+
+func newEnableUserCommand(enabled bool) *cobra.Command {
+	use, short := "enable <username>", "allow user to login"
+	if !enabled {
+		use, short = "disable <username>", "forbid user to login"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := clientFromContext(ctx).UpdateUser(ctx, args[0], &client.UpdateUserRequest{
+				IsEnabled: &enabled,
+			}); err != nil {
+				return fmt.Errorf("client.UpdateUser: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newSetUserRolesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "set-roles <username> <role>...",
+		Short:   "replace roles of user",
+		Example: "  rtcctl users set-roles alice developers deployer",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := clientFromContext(ctx).UpdateUser(ctx, args[0], &client.UpdateUserRequest{
+				Roles: args[1:],
+			}); err != nil {
+				return fmt.Errorf("client.UpdateUser: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newChangePasswordCommand() *cobra.Command {
+	var oldPassword, newPassword string
+
+	cmd := &cobra.Command{
+		Use:   "passwd",
+		Short: "change own password (passwords are read from stdin if flags are not set)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			reader := bufio.NewReader(cmd.InOrStdin())
+
+			if err := promptSecret(cmd.ErrOrStderr(), cmd.InOrStdin(), reader, "old password: ", &oldPassword); err != nil {
+				return err
+			}
+
+			if err := promptSecret(cmd.ErrOrStderr(), cmd.InOrStdin(), reader, "new password: ", &newPassword); err != nil {
+				return err
+			}
+
+			if err := clientFromContext(ctx).ChangePassword(ctx, &client.ChangePasswordRequest{
+				OldPassword: oldPassword,
+				NewPassword: newPassword,
+			}); err != nil {
+				return fmt.Errorf("client.ChangePassword: %w", err)
+			}
+
+			fmt.Fprintln(cmd.ErrOrStderr(), "password changed, sessions are revoked, log in again with rtcctl login")
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&oldPassword, "old-password", "", "Current password")
+	cmd.Flags().StringVar(&newPassword, "new-password", "", "New password")
+
+	return cmd
+}
+
+func newResetPasswordCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-password <username>",
+		Short: "set one-time password for user, it must be changed on next login",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			password, err := clientFromContext(ctx).ResetPassword(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("client.ResetPassword: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), password)
+
+			return nil
+		},
+	}
+}
+
+func newResetTOTPCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-2fa <username>",
+		Short: "reset two-factor authentication of user e.g. if device is lost",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := clientFromContext(ctx).ResetTOTP(ctx, args[0]); err != nil {
+				return fmt.Errorf("client.ResetTOTP: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// promptValue read line from reader if target is empty
+func promptValue(w io.Writer, reader *bufio.Reader, prompt string, target *string) error {
+	if *target != "" {
+		return nil
+	}
+
+	fmt.Fprint(w, prompt)
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("read %s: %w", strings.TrimSuffix(prompt, ": "), err)
+	}
+
+	*target = strings.TrimRight(line, "\r\n")
+
+	if *target == "" {
+		return fmt.Errorf("%s is required", strings.TrimSuffix(prompt, ": "))
+	}
+
+	return nil
+}
+
+// promptSecret read value without echo if input is terminal, otherwise line from reader like promptValue
+func promptSecret(w io.Writer, in io.Reader, reader *bufio.Reader, prompt string, target *string) error {
+	file, ok := in.(*os.File)
+	if *target != "" || !ok || !term.IsTerminal(int(file.Fd())) {
+		return promptValue(w, reader, prompt, target)
+	}
+
+	fmt.Fprint(w, prompt)
+
+	value, err := term.ReadPassword(int(file.Fd()))
+
+	// line break is not echoed
+	fmt.Fprintln(w)
+
+	if err != nil {
+		return fmt.Errorf("read %s: %w", strings.TrimSuffix(prompt, ": "), err)
+	}
+
+	*target = string(value)
+
+	if *target == "" {
+		return fmt.Errorf("%s is required", strings.TrimSuffix(prompt, ": "))
+	}
+
+	return nil
+}
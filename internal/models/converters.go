@@ -37,6 +37,46 @@ func ConvertAuditActionToModel(auditAction string) AuditAction {
 		return AuditActionProjectDeleted
 	case "release_deleted":
 		return AuditActionReleaseDeleted
+	case "user_login":
+		return AuditActionUserLogin
+	case "user_login_failed":
+		return AuditActionUserLoginFailed
+	case "user_locked":
+		return AuditActionUserLocked
+	case "member_added":
+		return AuditActionMemberAdded
+	case "member_removed":
+		return AuditActionMemberRemoved
+	case "configs_upserted":
+		return AuditActionConfigsUpserted
+	case "environment_created":
+		return AuditActionEnvironmentCreated
+	case "release_created":
+		return AuditActionReleaseCreated
+	case "user_created":
+		return AuditActionUserCreated
+	case "user_updated":
+		return AuditActionUserUpdated
+	case "user_password_reset":
+		return AuditActionUserPasswordReset
+	case "user_password_changed":
+		return AuditActionUserPasswordChanged
+	case "user_totp_enrolled":
+		return AuditActionUserTOTPEnrolled
+	case "user_totp_enabled":
+		return AuditActionUserTOTPEnabled
+	case "user_totp_disabled":
+		return AuditActionUserTOTPDisabled
+	case "user_totp_reset":
+		return AuditActionUserTOTPReset
+	case "api_token_created":
+		return AuditActionAPITokenCreated
+	case "api_token_revoked":
+		return AuditActionAPITokenRevoked
+	case "audit_archived":
+		return AuditActionAuditArchived
+	case "audits_imported":
+		return AuditActionAuditsImported
 	default:
 		return AuditActionUnknown
 	}
@@ -53,6 +53,67 @@ type Config struct {
 	UpdatedAt *time.Time
 }
 
+// ChangeReason why config values are changed, reason may be mandatory for environment
+type ChangeReason struct {
+	Reason string
+	// Ticket reference e.g. issue key or URL
+	Ticket string
+}
+
+// ConfigChange new value of config key, old value is empty for unknown keys
+type ConfigChange struct {
+	Key      string
+	Group    string
+	Exists   bool
+	OldValue []byte
+	NewValue []byte
+}
+
+// UpsertConfigsOptions ...
+type UpsertConfigsOptions struct {
+	// DryRun only resolve plan, nothing is changed
+	DryRun bool
+	// NoPrune keep keys missing in upsert instead of deleting them
+	NoPrune bool
+	// Reason is stored in audit record, it is mandatory in some environments unless dry run
+	Reason ChangeReason
+}
+
+// ConfigsPlan changes of configs upsert
+type ConfigsPlan struct {
+	Added   []*ConfigPlanItem
+	Changed []*ConfigPlanItem
+	Removed []*ConfigPlanItem
+	// Kept keys missing in upsert which are not removed because of no prune
+	Kept []*ConfigPlanItem
+	// Unchanged keys with same type and metadata
+	Unchanged []*ConfigPlanItem
+}
+
+// ConfigPlanItem planned change of config key, old fields are empty for added keys and new for removed
+type ConfigPlanItem struct {
+	Key         string
+	OldType     ValueType
+	NewType     ValueType
+	OldMetadata *ConfigMetadata
+	NewMetadata *ConfigMetadata
+	// ValueReset value is set from upsert, otherwise actual value is kept
+	ValueReset bool
+	Value      []byte
+}
+
+// ConfigHistoryItem change of config value from audit log
+type ConfigHistoryItem struct {
+	// ID of audit record
+	ID       uint64
+	Actor    string
+	OldValue []byte
+	NewValue []byte
+	Reason   string
+	Ticket   string
+	Ts       time.Time
+}
+
 // Project some client for use config
 type Project struct {
 	Name        string
@@ -87,28 +148,340 @@ const (
 	AuditActionProjectDeleted AuditAction = "project_deleted"
 	// AuditActionReleaseDeleted ...
 	AuditActionReleaseDeleted AuditAction = "release_deleted"
+	// AuditActionUserLogin ...
+	AuditActionUserLogin AuditAction = "user_login"
+	// AuditActionUserLoginFailed ...
+	AuditActionUserLoginFailed AuditAction = "user_login_failed"
+	// AuditActionUserLocked login is temporary locked after failed attempts
+	AuditActionUserLocked AuditAction = "user_locked"
+	// AuditActionMemberAdded ...
+	AuditActionMemberAdded AuditAction = "member_added"
+	// AuditActionMemberRemoved ...
+	AuditActionMemberRemoved AuditAction = "member_removed"
+	// AuditActionConfigsUpserted keys added, changed type or deleted by configs upsert
+	AuditActionConfigsUpserted AuditAction = "configs_upserted"
+	// AuditActionEnvironmentCreated environment implicitly created by configs upsert
+	AuditActionEnvironmentCreated AuditAction = "environment_created"
+	// AuditActionReleaseCreated release implicitly created by configs upsert
+	AuditActionReleaseCreated AuditAction = "release_created"
+	// AuditActionUserCreated ...
+	AuditActionUserCreated AuditAction = "user_created"
+	// AuditActionUserUpdated ...
+	AuditActionUserUpdated AuditAction = "user_updated"
+	// AuditActionUserPasswordReset ...
+	AuditActionUserPasswordReset AuditAction = "user_password_reset"
+	// AuditActionUserPasswordChanged password changed by user itself
+	AuditActionUserPasswordChanged AuditAction = "user_password_changed"
+	// AuditActionUserTOTPEnrolled TOTP secret generated, enrollment is not confirmed yet
+	AuditActionUserTOTPEnrolled AuditAction = "user_totp_enrolled"
+	// AuditActionUserTOTPEnabled TOTP enrollment confirmed by code
+	AuditActionUserTOTPEnabled AuditAction = "user_totp_enabled"
+	// AuditActionUserTOTPDisabled TOTP disabled by user itself
+	AuditActionUserTOTPDisabled AuditAction = "user_totp_disabled"
+	// AuditActionUserTOTPReset TOTP reset by admin
+	AuditActionUserTOTPReset AuditAction = "user_totp_reset"
+	// AuditActionAPITokenCreated ...
+	AuditActionAPITokenCreated AuditAction = "api_token_created"
+	// AuditActionAPITokenRevoked ...
+	AuditActionAPITokenRevoked AuditAction = "api_token_revoked"
+	// AuditActionAuditArchived expired records exported to archive and deleted
+	AuditActionAuditArchived AuditAction = "audit_archived"
+	// AuditActionAuditsImported records loaded back from archive
+	AuditActionAuditsImported AuditAction = "audits_imported"
 )
 
 // Audit log record for history
 type Audit struct {
-	Action  AuditAction
-	Actor   string
-	Payload []byte
+	ID     uint64
+	Action AuditAction
+	Actor  string
+	// Payload decoded typed payload of action e.g. *AuditConfigUpdated,
+	// map[string]any for unknown actions or payload versions
+	Payload any
+	// Request is nil for records written outside of HTTP requests
+	Request *RequestMeta
 	Ts      time.Time
 }
 
+// RequestMeta HTTP request of change for audit records
+type RequestMeta struct {
+	ID        string
+	IP        string
+	UserAgent string
+}
+
+// AuditRecord stored audit record as is for export to sinks
+type AuditRecord struct {
+	ID     uint64
+	Action string
+	Actor  string
+	// Payload JSON with request metadata
+	Payload  []byte
+	PrevHash []byte
+	Hash     []byte
+	Ts       time.Time
+}
+
 // AuditFilter ...
 type AuditFilter struct {
 	Action   AuditAction
 	Actor    string
+	Project  string
+	Env      string
+	Release  string
+	Key      string
 	FromDate time.Time
 	ToDate   time.Time
+	// Cursor id of last record of previous page, zero for first page
+	Cursor uint64
+	Limit  uint64
+}
+
+// AuditConfigUpdated payload of config_updated
+type AuditConfigUpdated struct {
+	Project string
+	Env     string
+	Release string
+	Reason  string
+	Ticket  string
+	Items   []AuditConfigValueChange
+}
+
+// AuditConfigValueChange ...
+type AuditConfigValueChange struct {
+	Key      string
+	OldValue string
+	NewValue string
+}
+
+// AuditConfigsUpserted payload of configs_upserted
+type AuditConfigsUpserted struct {
+	Project     string
+	Env         string
+	Release     string
+	Reason      string
+	Ticket      string
+	Added       []AuditConfigTypeChange
+	TypeChanged []AuditConfigTypeChange
+	Deleted     []AuditConfigTypeChange
+}
+
+// AuditConfigTypeChange old type is empty for added keys, new type for deleted
+type AuditConfigTypeChange struct {
+	Key     string
+	OldType string
+	NewType string
+}
+
+// AuditProject payload of project_created, project_updated and project_deleted
+type AuditProject struct {
+	Project        string
+	Description    string
+	OldDescription string
+	NewDescription string
+}
+
+// AuditRelease payload of environment_created, release_created and release_deleted,
+// release is empty for environment
+type AuditRelease struct {
+	Project string
+	Env     string
+	Release string
+}
+
+// AuditUserLogin payload of user_login, user_login_failed and user_locked
+type AuditUserLogin struct {
+	Username string
+	IP       string
+	Reason   string
+	Duration string
+}
+
+// AuditMember payload of member_added and member_removed
+type AuditMember struct {
+	Project string
+	Kind    MemberKind
+	Subject string
+	Env     string
+	Role    MemberRole
+}
+
+// AuditUserCreated payload of user_created
+type AuditUserCreated struct {
+	Username           string
+	Source             string
+	Roles              []string
+	IsEnabled          bool
+	MustChangePassword bool
+}
+
+// AuditUserUpdated payload of user_updated
+type AuditUserUpdated struct {
+	Username      string
+	OldIsEnabled  bool
+	NewIsEnabled  bool
+	OldRoles      []string
+	NewRoles      []string
+	TokensRevoked bool
 }
 
+// AuditUserPasswordReset payload of user_password_reset and user_password_changed
+type AuditUserPasswordReset struct {
+	Username string
+}
+
+// AuditUserTOTP payload of user_totp_enrolled, user_totp_enabled, user_totp_disabled and user_totp_reset
+type AuditUserTOTP struct {
+	Username string
+}
+
+// AuditAPIToken payload of api_token_created and api_token_revoked,
+// only id and owner are known for revoked token
+type AuditAPIToken struct {
+	ID        uint64
+	Owner     string
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+// AuditArchive payload of audit_archived and audits_imported, range of records
+type AuditArchive struct {
+	FirstID uint64
+	LastID  uint64
+	// LastHash hash of last record, chain continues from it after records are deleted
+	LastHash []byte
+	// Digest of archived records, records without hash are imported only if it matches
+	Digest  []byte
+	Records uint64
+	File    string
+}
+
+// AuditChainProblemKind ...
+type AuditChainProblemKind string
+
+const (
+	// AuditChainModified record content does not match its hash
+	AuditChainModified AuditChainProblemKind = "modified"
+	// AuditChainBrokenLink previous record hash mismatch, records are deleted, inserted or reordered
+	AuditChainBrokenLink AuditChainProblemKind = "broken_link"
+	// AuditChainUnchained record without hash after chain start
+	AuditChainUnchained AuditChainProblemKind = "unchained"
+	// AuditChainCheckpoint checkpoint signature is invalid or checkpointed record is changed
+	AuditChainCheckpoint AuditChainProblemKind = "checkpoint"
+)
+
+// AuditChainProblem ...
+type AuditChainProblem struct {
+	// ID of audit record, zero for checkpoint problems
+	ID           uint64
+	CheckpointID uint64
+	Kind         AuditChainProblemKind
+	Message      string
+}
+
+// AuditCheckpoint signed hash of audit chain record
+type AuditCheckpoint struct {
+	ID        uint64
+	LastID    uint64
+	Hash      []byte
+	Signature []byte
+	Ts        time.Time
+}
+
+// AuditChainVerification result of audit log hash chain verification
+type AuditChainVerification struct {
+	Valid bool
+	// Checked records of hash chain
+	Checked uint64
+	// Legacy records written before hash chaining
+	Legacy uint64
+	// Archived records of archives bridging gaps in chain
+	Archived uint64
+	LastID   uint64
+	LastHash []byte
+	// SignaturesVerified checkpoints signatures are verified by server key
+	SignaturesVerified bool
+	Checkpoints        uint64
+	LatestCheckpoint   *AuditCheckpoint
+	// Problems first problems found, verification is not stopped on problem
+	Problems []*AuditChainProblem
+}
+
+// UserSource where user comes from
+type UserSource string
+
+const (
+	// UserSourceLocal user with password stored in rtc
+	UserSourceLocal UserSource = "local"
+	// UserSourceOIDC user provisioned on OpenID Connect login
+	UserSourceOIDC UserSource = "oidc"
+	// UserSourceLDAP user provisioned on LDAP login
+	UserSourceLDAP UserSource = "ldap"
+)
+
 // User ...
 type User struct {
-	Username  string
-	IsEnabled bool
-	Roles     []string
+	Username           string
+	IsEnabled          bool
+	Roles              []string
+	MustChangePassword bool
+	Source             UserSource
+	TwoFactorEnabled   bool
+	CreatedAt          time.Time
+}
+
+// TOTPEnrollment secret for authenticator app, enrollment must be confirmed by code
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// APIToken long-lived token for machine access
+type APIToken struct {
+	ID         uint64
+	Name       string
+	Owner      string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// MemberKind subject of project role binding
+type MemberKind string
+
+const (
+	// MemberKindUser binding to username
+	MemberKindUser MemberKind = "user"
+	// MemberKindGroup binding to user role e.g. mapped from LDAP groups
+	MemberKindGroup MemberKind = "group"
+)
+
+// MemberRole role of project member
+type MemberRole string
+
+const (
+	// MemberRoleViewer read configs
+	MemberRoleViewer MemberRole = "viewer"
+	// MemberRoleEditor change config values
+	MemberRoleEditor MemberRole = "editor"
+	// MemberRoleAdmin manage project, releases and members
+	MemberRoleAdmin MemberRole = "admin"
+)
+
+// AllProjects project name of bindings to all projects
+const AllProjects = "*"
+
+// Member role binding of user or group in project environments
+type Member struct {
+	ID      uint64
+	Project string
+	Kind    MemberKind
+	Subject string
+	// Env environment pattern e.g. prod or dev-*
+	Env       string
+	Role      MemberRole
 	CreatedAt time.Time
 }
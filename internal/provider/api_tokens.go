@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const (
+	apiTokenPrefix = "rtc_"
+	secretLength   = 32
+)
+
+// CreateAPIToken create token for user and return its secret value.
+// The secret value is not stored and can't be received again.
+func (p *Provider) CreateAPIToken(ctx context.Context, username, name string, scopes []string, expiresAt *time.Time) (string, *models.APIToken, error) {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", nil, ErrNotFound
+		}
+
+		return "", nil, fmt.Errorf("storage.User: %w", err)
+	}
+
+	secret, err := generateSecret(apiTokenPrefix)
+	if err != nil {
+		return "", nil, fmt.Errorf("generateSecret: %w", err)
+	}
+
+	token := &storage.APIToken{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Name:      name,
+		TokenHash: hashSecret(secret),
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.CreateAPIToken(ctx, token); err != nil {
+			return fmt.Errorf("storage.CreateAPIToken: %w", err)
+		}
+
+		// token id is known after insert
+		auditRecord, err := encodeAuditRecordAPIToken(models.AuditActionAPITokenCreated, auth.UsernameFromContext(ctx), token)
+		if err != nil {
+			return fmt.Errorf("encodeAuditRecordAPIToken: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return "", nil, fmt.Errorf("storage.WithTransaction: %w", txErr)
+	}
+
+	return secret, convertAPITokenToModel(token), nil
+}
+
+// ListAPITokens list tokens of user (all tokens if username is empty)
+func (p *Provider) ListAPITokens(ctx context.Context, username string) ([]*models.APIToken, error) {
+	tokens, err := p.storage.APITokens(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("storage.APITokens: %w", err)
+	}
+
+	return convertAPITokensToModels(tokens), nil
+}
+
+// RevokeAPIToken ...
+func (p *Provider) RevokeAPIToken(ctx context.Context, username string, id uint64) error {
+	auditRecord, err := encodeAuditRecordAPIToken(models.AuditActionAPITokenRevoked, auth.UsernameFromContext(ctx), &storage.APIToken{
+		ID:       id,
+		Username: username,
+	})
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordAPIToken: %w", err)
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.RevokeAPIToken(ctx, username, id); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return ErrNotFound
+			}
+
+			return fmt.Errorf("storage.RevokeAPIToken: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return txErr // nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// AuthenticateAPIToken find active token and its owner
+func (p *Provider) AuthenticateAPIToken(ctx context.Context, secret string) (*models.User, *models.APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, nil, ErrNotFound
+	}
+
+	token, err := p.storage.APITokenByHash(ctx, hashSecret(secret))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrNotFound
+		}
+
+		return nil, nil, fmt.Errorf("storage.APITokenByHash: %w", err)
+	}
+
+	if token.RevokedAt != nil {
+		slog.DebugContext(ctx, "api token is revoked", "id", token.ID)
+		return nil, nil, ErrNotFound
+	}
+
+	if token.ExpiresAt != nil && token.ExpiresAt.Before(time.Now()) {
+		slog.DebugContext(ctx, "api token is expired", "id", token.ID)
+		return nil, nil, ErrNotFound
+	}
+
+	user, err := p.storage.User(ctx, token.Username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, ErrNotFound
+		}
+
+		return nil, nil, fmt.Errorf("storage.User: %w", err)
+	}
+
+	if !user.IsEnabled {
+		slog.DebugContext(ctx, "api token owner is disabled", "id", token.ID, "username", user.Username)
+		return nil, nil, ErrNotFound
+	}
+
+	if user.MustChangePassword {
+		slog.DebugContext(ctx, "api token owner must change password", "id", token.ID, "username", user.Username)
+		return nil, nil, ErrNotFound
+	}
+
+	if err := p.storage.MarkAPITokenUsed(ctx, token.ID); err != nil {
+		slog.WarnContext(ctx, "storage.MarkAPITokenUsed", "err", err)
+	}
+
+	return convertUserToModel(user), convertAPITokenToModel(token), nil
+}
+
+func generateSecret(prefix string) (string, error) {
+	buf := make([]byte, secretLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return prefix + hex.EncodeToString(buf), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
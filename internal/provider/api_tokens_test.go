@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_CreateAPIToken_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{ID: 1, Username: "test"}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().CreateAPIToken(mock.Anything, mock.MatchedBy(func(token *storage.APIToken) bool {
+		return token.UserID == 1 && token.Name == "ci" && len(token.TokenHash) == 64
+	})).Run(func(_ context.Context, token *storage.APIToken) {
+		token.ID = 10
+	}).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionAPITokenCreated) &&
+			audit.Actor == "admin" &&
+			strings.Contains(string(audit.Payload), `"token_id":10`)
+	})).Return(nil)
+
+	ctx := auth.ToContext(context.Background(), &auth.Payload{Username: "admin"})
+
+	secret, got, err := m.provider.CreateAPIToken(ctx, "test", "ci", []string{"read"}, nil)
+
+	require.NoError(t, err)
+	require.Regexp(t, "^rtc_[0-9a-f]{64}$", secret)
+	require.Equal(t, "test", got.Owner)
+	require.Equal(t, []string{"read"}, got.Scopes)
+}
+
+func Test_RevokeAPIToken_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().RevokeAPIToken(mock.Anything, "test", uint64(10)).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionAPITokenRevoked) && audit.Actor == "test"
+	})).Return(nil)
+
+	ctx := auth.ToContext(context.Background(), &auth.Payload{Username: "test"})
+
+	err := m.provider.RevokeAPIToken(ctx, "test", 10)
+
+	require.NoError(t, err)
+}
+
+func Test_RevokeAPIToken_NotFound_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+	m.storage.EXPECT().RevokeAPIToken(mock.Anything, "test", uint64(10)).Return(storage.ErrNotFound)
+
+	err := m.provider.RevokeAPIToken(context.Background(), "test", 10)
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_AuthenticateAPIToken_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	secret := "rtc_secret"
+
+	m.storage.EXPECT().APITokenByHash(mock.Anything, hashSecret(secret)).Return(&storage.APIToken{
+		ID:       10,
+		Username: "test",
+		Name:     "ci",
+		Scopes:   []string{"project:example"},
+	}, nil)
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{
+		Username:  "test",
+		IsEnabled: true,
+		Roles:     []string{"admin"},
+	}, nil)
+	m.storage.EXPECT().MarkAPITokenUsed(mock.Anything, uint64(10)).Return(nil)
+
+	user, token, err := m.provider.AuthenticateAPIToken(context.Background(), secret)
+
+	require.NoError(t, err)
+	require.Equal(t, &models.User{Username: "test", IsEnabled: true, Roles: []string{"admin"}}, user)
+	require.Equal(t, []string{"project:example"}, token.Scopes)
+}
+
+func Test_AuthenticateAPIToken_Expired_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	expiresAt := time.Now().Add(-time.Minute)
+
+	m.storage.EXPECT().APITokenByHash(mock.Anything, mock.Anything).Return(&storage.APIToken{
+		ID:        10,
+		Username:  "test",
+		ExpiresAt: &expiresAt,
+	}, nil)
+
+	_, _, err := m.provider.AuthenticateAPIToken(context.Background(), "rtc_secret")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_AuthenticateAPIToken_Revoked_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	revokedAt := time.Now()
+
+	m.storage.EXPECT().APITokenByHash(mock.Anything, mock.Anything).Return(&storage.APIToken{
+		ID:        10,
+		Username:  "test",
+		RevokedAt: &revokedAt,
+	}, nil)
+
+	_, _, err := m.provider.AuthenticateAPIToken(context.Background(), "rtc_secret")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_AuthenticateAPIToken_DisabledOwner_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().APITokenByHash(mock.Anything, mock.Anything).Return(&storage.APIToken{ID: 10, Username: "test"}, nil)
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{Username: "test"}, nil)
+
+	_, _, err := m.provider.AuthenticateAPIToken(context.Background(), "rtc_secret")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_AuthenticateAPIToken_MustChangePassword_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().APITokenByHash(mock.Anything, mock.Anything).Return(&storage.APIToken{ID: 10, Username: "test"}, nil)
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{Username: "test", IsEnabled: true, MustChangePassword: true}, nil)
+
+	_, _, err := m.provider.AuthenticateAPIToken(context.Background(), "rtc_secret")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
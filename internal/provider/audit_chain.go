@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/auditchain"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const (
+	auditChainBatchSize   = 1000
+	maxAuditChainProblems = 100
+)
+
+// AuditCheckpoints periodic signing of audit chain head
+type AuditCheckpoints struct {
+	Key      ed25519.PrivateKey
+	Interval time.Duration
+}
+
+// VerifyAuditChain walks whole audit log and checks hashes, links between records and checkpoints
+func (p *Provider) VerifyAuditChain(ctx context.Context) (*models.AuditChainVerification, error) {
+	checkpoints, err := p.storage.AuditCheckpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("storage.AuditCheckpoints: %w", err)
+	}
+
+	archives, err := p.auditArchives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("auditArchives: %w", err)
+	}
+
+	v := &auditChainVerifier{
+		result: &models.AuditChainVerification{
+			SignaturesVerified: p.auditCheckpoints != nil,
+			Checkpoints:        uint64(len(checkpoints)),
+		},
+		checkpoints: make(map[uint64][]*storage.AuditCheckpoint, len(checkpoints)),
+		archives:    archives,
+	}
+
+	for _, checkpoint := range checkpoints {
+		if p.auditCheckpoints != nil {
+			publicKey := p.auditCheckpoints.Key.Public().(ed25519.PublicKey) // nolint:forcetypeassert
+			if !auditchain.VerifyCheckpoint(publicKey, checkpoint.LastID, checkpoint.Hash, checkpoint.Signature) {
+				v.checkpointProblem(checkpoint, "invalid signature")
+			}
+		}
+
+		v.checkpoints[checkpoint.LastID] = append(v.checkpoints[checkpoint.LastID], checkpoint)
+	}
+
+	if len(checkpoints) > 0 {
+		v.result.LatestCheckpoint = convertAuditCheckpointToModel(checkpoints[len(checkpoints)-1])
+	}
+
+	var afterID uint64
+
+	for {
+		audits, err := p.storage.AuditChain(ctx, afterID, auditChainBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("storage.AuditChain: %w", err)
+		}
+
+		for _, audit := range audits {
+			v.verify(audit)
+		}
+
+		if len(audits) < auditChainBatchSize {
+			break
+		}
+
+		afterID = audits[len(audits)-1].ID
+	}
+
+	var archivedID uint64
+	for _, archive := range archives {
+		archivedID = max(archivedID, archive.LastID)
+	}
+
+	for lastID, missing := range v.checkpoints {
+		// record is deleted by retention
+		if lastID <= archivedID {
+			continue
+		}
+
+		for _, checkpoint := range missing {
+			v.checkpointProblem(checkpoint, fmt.Sprintf("record %d is missing", checkpoint.LastID))
+		}
+	}
+
+	v.result.Valid = len(v.result.Problems) == 0
+
+	return v.result, nil
+}
+
+type auditChainVerifier struct {
+	result *models.AuditChainVerification
+	// checkpoints by last id, removed when record is verified
+	checkpoints map[uint64][]*storage.AuditCheckpoint
+	// archives bridge gaps of records deleted by retention
+	archives []*models.AuditArchive
+	chained  bool
+	prevHash []byte
+	prevID   uint64
+}
+
+func (v *auditChainVerifier) verify(audit *storage.Audit) {
+	defer func() {
+		v.prevID = audit.ID
+	}()
+
+	v.result.LastID = audit.ID
+
+	if audit.Hash == nil {
+		if !v.chained {
+			v.result.Legacy++
+			return
+		}
+
+		v.problem(audit.ID, models.AuditChainUnchained, "record has no hash")
+
+		return
+	}
+
+	v.result.Checked++
+
+	if !bytes.Equal(audit.PrevHash, v.prevHash) && !v.bridge(audit) {
+		v.problem(audit.ID, models.AuditChainBrokenLink, "previous record hash mismatch, records before are deleted, inserted or reordered")
+	}
+
+	hash := auditchain.Hash(audit.PrevHash, audit.ID, audit.Action, audit.Actor, audit.Payload, audit.Ts)
+	if !bytes.Equal(hash, audit.Hash) {
+		v.problem(audit.ID, models.AuditChainModified, "record content does not match hash")
+	}
+
+	for _, checkpoint := range v.checkpoints[audit.ID] {
+		if !bytes.Equal(checkpoint.Hash, audit.Hash) {
+			v.checkpointProblem(checkpoint, fmt.Sprintf("record %d hash differs from checkpoint", audit.ID))
+		}
+	}
+
+	delete(v.checkpoints, audit.ID)
+
+	// chain continues from stored hash, so a single change is reported once
+	v.chained = true
+	v.prevHash = audit.Hash
+	v.result.LastHash = audit.Hash
+}
+
+// bridge archive ending right before record and after previous record
+func (v *auditChainVerifier) bridge(audit *storage.Audit) bool {
+	for _, archive := range v.archives {
+		if archive.LastID > v.prevID && archive.LastID < audit.ID && bytes.Equal(archive.LastHash, audit.PrevHash) {
+			v.result.Archived += archive.Records
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *auditChainVerifier) problem(id uint64, kind models.AuditChainProblemKind, message string) {
+	if len(v.result.Problems) >= maxAuditChainProblems {
+		return
+	}
+
+	v.result.Problems = append(v.result.Problems, &models.AuditChainProblem{
+		ID:      id,
+		Kind:    kind,
+		Message: message,
+	})
+}
+
+func (v *auditChainVerifier) checkpointProblem(checkpoint *storage.AuditCheckpoint, message string) {
+	if len(v.result.Problems) >= maxAuditChainProblems {
+		return
+	}
+
+	v.result.Problems = append(v.result.Problems, &models.AuditChainProblem{
+		CheckpointID: checkpoint.ID,
+		Kind:         models.AuditChainCheckpoint,
+		Message:      message,
+	})
+}
+
+// CreateAuditCheckpoint sign hash of last chained record, returns nil if chain is not changed since last checkpoint
+func (p *Provider) CreateAuditCheckpoint(ctx context.Context) (*models.AuditCheckpoint, error) {
+	if p.auditCheckpoints == nil {
+		return nil, fmt.Errorf("%w: audit checkpoints are disabled", ErrNotValid)
+	}
+
+	last, err := p.storage.LastChainedAudit(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil // nolint:nilnil
+		}
+
+		return nil, fmt.Errorf("storage.LastChainedAudit: %w", err)
+	}
+
+	lastCheckpoint, err := p.storage.LastAuditCheckpoint(ctx)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("storage.LastAuditCheckpoint: %w", err)
+	}
+
+	if lastCheckpoint != nil && lastCheckpoint.LastID == last.ID {
+		return nil, nil // nolint:nilnil
+	}
+
+	checkpoint := &storage.AuditCheckpoint{
+		LastID:    last.ID,
+		Hash:      last.Hash,
+		Signature: auditchain.SignCheckpoint(p.auditCheckpoints.Key, last.ID, last.Hash),
+	}
+
+	if err := p.storage.AddAuditCheckpoint(ctx, checkpoint); err != nil {
+		return nil, fmt.Errorf("storage.AddAuditCheckpoint: %w", err)
+	}
+
+	return convertAuditCheckpointToModel(checkpoint), nil
+}
+
+// RunAuditCheckpoints creates checkpoints by interval, blocks until context is done
+func (p *Provider) RunAuditCheckpoints(ctx context.Context) error {
+	if p.auditCheckpoints == nil {
+		return nil
+	}
+
+	ticker := time.NewTicker(p.auditCheckpoints.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			checkpoint, err := p.CreateAuditCheckpoint(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to create audit checkpoint", "err", err)
+				continue
+			}
+
+			if checkpoint != nil {
+				slog.InfoContext(ctx, "audit checkpoint created", "id", checkpoint.ID, "last_id", checkpoint.LastID)
+			}
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auditchain"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func newTestAuditChain(t *testing.T, firstID uint64, size int) []*storage.Audit {
+	t.Helper()
+
+	ts := time.Date(2025, 10, 27, 10, 0, 0, 0, time.UTC)
+
+	var (
+		audits   []*storage.Audit
+		prevHash []byte
+	)
+
+	for i := range size {
+		audit := &storage.Audit{
+			ID:       firstID + uint64(i), // nolint:gosec
+			Action:   "project_created",
+			Actor:    "admin",
+			Payload:  []byte(`{"project": "example"}`),
+			Ts:       ts.Add(time.Duration(i) * time.Second),
+			PrevHash: prevHash,
+		}
+		audit.Hash = auditchain.Hash(audit.PrevHash, audit.ID, audit.Action, audit.Actor, audit.Payload, audit.Ts)
+		prevHash = audit.Hash
+
+		audits = append(audits, audit)
+	}
+
+	return audits
+}
+
+func Test_VerifyAuditChain_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	legacy := &storage.Audit{ID: 1, Action: "project_created", Actor: "admin", Payload: []byte(`{}`)}
+	chain := newTestAuditChain(t, 2, 3)
+
+	m.storage.EXPECT().
+		AuditCheckpoints(mock.AnythingOfType("context.backgroundCtx")).
+		Return([]*storage.AuditCheckpoint{
+			{ID: 1, LastID: 3, Hash: chain[1].Hash},
+		}, nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditChainBatchSize)).
+		Return(append([]*storage.Audit{legacy}, chain...), nil)
+
+	result, err := m.provider.VerifyAuditChain(context.Background())
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+	require.Empty(t, result.Problems)
+	require.Equal(t, uint64(3), result.Checked)
+	require.Equal(t, uint64(1), result.Legacy)
+	require.Equal(t, uint64(4), result.LastID)
+	require.Equal(t, chain[2].Hash, result.LastHash)
+	require.False(t, result.SignaturesVerified)
+	require.Equal(t, uint64(3), result.LatestCheckpoint.LastID)
+}
+
+func Test_VerifyAuditChain_Modified_ExpectProblem(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 3)
+	chain[1].Actor = "intruder"
+
+	m.storage.EXPECT().
+		AuditCheckpoints(mock.AnythingOfType("context.backgroundCtx")).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditChainBatchSize)).
+		Return(chain, nil)
+
+	result, err := m.provider.VerifyAuditChain(context.Background())
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.Equal(t, []*models.AuditChainProblem{
+		{ID: 2, Kind: models.AuditChainModified, Message: "record content does not match hash"},
+	}, result.Problems)
+}
+
+func Test_VerifyAuditChain_Deleted_ExpectProblem(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 4)
+	chain = append(chain[:1], chain[2:]...)
+
+	m.storage.EXPECT().
+		AuditCheckpoints(mock.AnythingOfType("context.backgroundCtx")).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditChainBatchSize)).
+		Return(chain, nil)
+
+	result, err := m.provider.VerifyAuditChain(context.Background())
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.Len(t, result.Problems, 1)
+	require.Equal(t, uint64(3), result.Problems[0].ID)
+	require.Equal(t, models.AuditChainBrokenLink, result.Problems[0].Kind)
+}
+
+func Test_VerifyAuditChain_Reordered_ExpectProblem(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 3)
+	chain[0].ID, chain[1].ID = chain[1].ID, chain[0].ID
+	chain[0], chain[1] = chain[1], chain[0]
+
+	m.storage.EXPECT().
+		AuditCheckpoints(mock.AnythingOfType("context.backgroundCtx")).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditChainBatchSize)).
+		Return(chain, nil)
+
+	result, err := m.provider.VerifyAuditChain(context.Background())
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.NotEmpty(t, result.Problems)
+}
+
+func Test_VerifyAuditChain_CheckpointRecordMissing_ExpectProblem(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditCheckpoints(&AuditCheckpoints{Key: privateKey}))
+
+	chain := newTestAuditChain(t, 1, 3)
+
+	m.storage.EXPECT().
+		AuditCheckpoints(mock.AnythingOfType("context.backgroundCtx")).
+		Return([]*storage.AuditCheckpoint{
+			{ID: 1, LastID: 2, Hash: chain[1].Hash, Signature: auditchain.SignCheckpoint(privateKey, 2, chain[1].Hash)},
+			{ID: 2, LastID: 3, Hash: chain[2].Hash, Signature: []byte("forged")},
+		}, nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	// tail is truncated
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditChainBatchSize)).
+		Return(chain[:2], nil)
+
+	result, err := m.provider.VerifyAuditChain(context.Background())
+	require.NoError(t, err)
+	require.False(t, result.Valid)
+	require.True(t, result.SignaturesVerified)
+	require.Equal(t, []*models.AuditChainProblem{
+		{CheckpointID: 2, Kind: models.AuditChainCheckpoint, Message: "invalid signature"},
+		{CheckpointID: 2, Kind: models.AuditChainCheckpoint, Message: "record 3 is missing"},
+	}, result.Problems)
+}
+
+func Test_VerifyAuditChain_Archived_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 6)
+
+	m.storage.EXPECT().
+		AuditCheckpoints(mock.AnythingOfType("context.backgroundCtx")).
+		Return([]*storage.AuditCheckpoint{
+			{ID: 1, LastID: 2, Hash: chain[1].Hash},
+		}, nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{
+			{ID: 7, Payload: []byte(`{"version": "v1", "first_id": 1, "last_id": 3, "last_hash": "` + hex.EncodeToString(chain[2].Hash) + `", "records": 3}`)},
+		}, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditChainBatchSize)).
+		Return(chain[3:], nil)
+
+	result, err := m.provider.VerifyAuditChain(context.Background())
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+	require.Empty(t, result.Problems)
+	require.Equal(t, uint64(3), result.Checked)
+	require.Equal(t, uint64(3), result.Archived)
+}
+
+func Test_CreateAuditCheckpoint_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditCheckpoints(&AuditCheckpoints{Key: privateKey}))
+
+	chain := newTestAuditChain(t, 1, 2)
+
+	m.storage.EXPECT().
+		LastChainedAudit(mock.AnythingOfType("context.backgroundCtx")).
+		Return(chain[1], nil)
+
+	m.storage.EXPECT().
+		LastAuditCheckpoint(mock.AnythingOfType("context.backgroundCtx")).
+		Return(&storage.AuditCheckpoint{ID: 1, LastID: 1}, nil)
+
+	m.storage.EXPECT().
+		AddAuditCheckpoint(mock.AnythingOfType("context.backgroundCtx"), mock.MatchedBy(func(checkpoint *storage.AuditCheckpoint) bool {
+			return checkpoint.LastID == 2 && auditchain.VerifyCheckpoint(publicKey, 2, chain[1].Hash, checkpoint.Signature)
+		})).
+		Return(nil)
+
+	checkpoint, err := m.provider.CreateAuditCheckpoint(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), checkpoint.LastID)
+}
+
+func Test_CreateAuditCheckpoint_NotChanged_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditCheckpoints(&AuditCheckpoints{Key: privateKey}))
+
+	chain := newTestAuditChain(t, 1, 2)
+
+	m.storage.EXPECT().
+		LastChainedAudit(mock.AnythingOfType("context.backgroundCtx")).
+		Return(chain[1], nil)
+
+	m.storage.EXPECT().
+		LastAuditCheckpoint(mock.AnythingOfType("context.backgroundCtx")).
+		Return(&storage.AuditCheckpoint{ID: 1, LastID: 2}, nil)
+
+	checkpoint, err := m.provider.CreateAuditCheckpoint(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, checkpoint)
+}
@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/auditarchive"
+	"github.com/DesSolo/rtc/internal/auditchain"
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const (
+	// auditActorSystem actor of background jobs
+	auditActorSystem = "system"
+
+	auditArchiveBatchSize  = 1000
+	auditArchiveMaxRecords = auditarchive.MaxRecords
+)
+
+// AuditRetention expired records are exported to archive directory and deleted
+type AuditRetention struct {
+	Keep       time.Duration
+	Interval   time.Duration
+	ArchiveDir string
+}
+
+// RunAuditRetention archives expired records on start and by interval, blocks until context is done
+func (p *Provider) RunAuditRetention(ctx context.Context) error {
+	if p.auditRetention == nil {
+		return nil
+	}
+
+	for {
+		p.archiveExpiredAudits(ctx)
+
+		if !sleepContext(ctx, p.auditRetention.Interval) {
+			return nil
+		}
+	}
+}
+
+func (p *Provider) archiveExpiredAudits(ctx context.Context) {
+	for {
+		archive, err := p.ArchiveExpiredAudits(ctx, time.Now().UTC())
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to archive audit records", "err", err)
+			return
+		}
+
+		if archive == nil {
+			return
+		}
+
+		slog.InfoContext(ctx, "audit records archived", "file", archive.File, "records", archive.Records, "first_id", archive.FirstID, "last_id", archive.LastID)
+
+		// archive is limited by size, the rest is archived immediately
+		if archive.Records < auditArchiveMaxRecords {
+			return
+		}
+	}
+}
+
+// ArchiveExpiredAudits export records older than retention window after last archive, returns nil if nothing is expired
+func (p *Provider) ArchiveExpiredAudits(ctx context.Context, now time.Time) (*models.AuditArchive, error) {
+	if p.auditRetention == nil {
+		return nil, fmt.Errorf("%w: audit retention is disabled", ErrNotValid)
+	}
+
+	var (
+		archive *models.AuditArchive
+		// created archive file is removed if records are not deleted, files of other calls are kept
+		created string
+	)
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		// replicas archive one by one, so range is computed after archive of other replica is committed
+		if err := p.storage.LockAuditArchive(ctx); err != nil {
+			return fmt.Errorf("storage.LockAuditArchive: %w", err)
+		}
+
+		archives, err := p.auditArchives(ctx)
+		if err != nil {
+			return fmt.Errorf("auditArchives: %w", err)
+		}
+
+		var afterID uint64
+		for _, archive := range archives {
+			afterID = max(afterID, archive.LastID)
+		}
+
+		untilID, err := p.auditSinksDeliveredID(ctx)
+		if err != nil {
+			return fmt.Errorf("auditSinksDeliveredID: %w", err)
+		}
+
+		archive, err = p.writeAuditArchive(ctx, afterID, untilID, now.Add(-p.auditRetention.Keep))
+		if err != nil {
+			return fmt.Errorf("writeAuditArchive: %w", err)
+		}
+
+		if archive == nil {
+			return nil
+		}
+
+		created = filepath.Join(p.auditRetention.ArchiveDir, archive.File)
+
+		auditRecord, err := encodeAuditRecordArchive(models.AuditActionAuditArchived, auditActorSystem, archive)
+		if err != nil {
+			return fmt.Errorf("encodeAuditRecordArchive: %w", err)
+		}
+
+		deleted, err := p.storage.DeleteAudits(ctx, afterID, archive.LastID)
+		if err != nil {
+			return fmt.Errorf("storage.DeleteAudits: %w", err)
+		}
+
+		// records are deleted concurrently e.g. by manual cleanup
+		if deleted != archive.Records {
+			return fmt.Errorf("%w: deleted %d records instead of %d", ErrAlreadyExists, deleted, archive.Records)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		if created != "" {
+			if err := os.Remove(created); err != nil {
+				slog.WarnContext(ctx, "failed to remove archive", "path", created, "err", err)
+			}
+		}
+
+		return nil, fmt.Errorf("storage.WithTransaction: %w", txErr)
+	}
+
+	return archive, nil
+}
+
+// writeAuditArchive write expired records to temporary file and link it to archive file,
+// existing archive file is never replaced
+func (p *Provider) writeAuditArchive(ctx context.Context, afterID, untilID uint64, cutoff time.Time) (*models.AuditArchive, error) {
+	tmp, err := os.CreateTemp(p.auditRetention.ArchiveDir, "audit-*.jsonl.gz.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+
+	archive, err := writeExpiredAudits(ctx, p.storage, tmp, afterID, untilID, cutoff)
+	if err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("writeExpiredAudits: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("tmp.Close: %w", err)
+	}
+
+	if archive == nil {
+		return nil, nil // nolint:nilnil
+	}
+
+	archive.File = fmt.Sprintf("audit-%010d-%010d.jsonl.gz", archive.FirstID, archive.LastID)
+	path := filepath.Join(p.auditRetention.ArchiveDir, archive.File)
+
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("%w: archive %s", ErrAlreadyExists, path)
+		}
+
+		return nil, fmt.Errorf("os.Link: %w", err)
+	}
+
+	return archive, nil
+}
+
+// writeExpiredAudits contiguous records after afterID up to untilID older than cutoff are written and synced
+func writeExpiredAudits(ctx context.Context, s storage.Storage, file *os.File, afterID, untilID uint64, cutoff time.Time) (*models.AuditArchive, error) {
+	w := auditarchive.NewWriter(file)
+
+	var archive *models.AuditArchive
+
+loop:
+	for archive == nil || archive.Records < auditArchiveMaxRecords {
+		audits, err := s.AuditChain(ctx, afterID, min(auditArchiveBatchSize, auditArchiveMaxRecords))
+		if err != nil {
+			return nil, fmt.Errorf("storage.AuditChain: %w", err)
+		}
+
+		for _, audit := range audits {
+			if audit.ID > untilID || !audit.Ts.Before(cutoff) || (archive != nil && archive.Records >= auditArchiveMaxRecords) {
+				break loop
+			}
+
+			if err := w.Write(convertAuditToRecord(audit)); err != nil {
+				return nil, fmt.Errorf("w.Write: %w", err)
+			}
+
+			if archive == nil {
+				archive = &models.AuditArchive{FirstID: audit.ID}
+			}
+
+			archive.LastID = audit.ID
+			archive.LastHash = audit.Hash
+			archive.Records++
+
+			afterID = audit.ID
+		}
+
+		if len(audits) < auditArchiveBatchSize {
+			break
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("w.Close: %w", err)
+	}
+
+	if archive != nil {
+		archive.Digest = w.Digest()
+	}
+
+	if err := file.Sync(); err != nil {
+		return nil, fmt.Errorf("file.Sync: %w", err)
+	}
+
+	return archive, nil
+}
+
+// auditArchives ranges of archived records
+func (p *Provider) auditArchives(ctx context.Context) ([]*models.AuditArchive, error) {
+	audits, err := p.storage.AuditsSearch(ctx, storage.AuditFilter{
+		Action: string(models.AuditActionAuditArchived),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage.AuditsSearch: %w", err)
+	}
+
+	archives := make([]*models.AuditArchive, 0, len(audits))
+
+	for _, audit := range audits {
+		payload, err := decodeAuditPayload(models.AuditActionAuditArchived, audit.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("decodeAuditPayload %d: %w", audit.ID, err)
+		}
+
+		archive, ok := payload.(*models.AuditArchive)
+		if !ok {
+			return nil, fmt.Errorf("unexpected payload of record %d", audit.ID)
+		}
+
+		archives = append(archives, archive)
+	}
+
+	return archives, nil
+}
+
+// ImportAudits load archived records back, existing records are skipped.
+// Archive must match range of audit_archived record, hashes of records are checked, modified archive is rejected.
+// Records without hash are checked by digest of audit_archived record.
+func (p *Provider) ImportAudits(ctx context.Context, records []*models.AuditRecord, file string) (*models.AuditArchive, error) {
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: archive is empty", ErrNotValid)
+	}
+
+	imported := &models.AuditArchive{
+		FirstID:  records[0].ID,
+		LastID:   records[len(records)-1].ID,
+		LastHash: records[len(records)-1].Hash,
+		File:     file,
+	}
+
+	archives, err := p.auditArchives(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("auditArchives: %w", err)
+	}
+
+	legacy, err := matchAuditArchive(archives, imported, records)
+	if err != nil {
+		return nil, fmt.Errorf("matchAuditArchive: %w", err)
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		for i, record := range records {
+			if i > 0 && record.ID <= records[i-1].ID {
+				return fmt.Errorf("%w: record %d is out of order", ErrNotValid, record.ID)
+			}
+
+			if record.Hash == nil {
+				// chain starts with first hashed record, older records have no hash
+				if !legacy || (i > 0 && records[i-1].Hash != nil) {
+					return fmt.Errorf("%w: record %d has no hash", ErrNotValid, record.ID)
+				}
+			} else {
+				if i > 0 && records[i-1].Hash != nil && !bytes.Equal(record.PrevHash, records[i-1].Hash) {
+					return fmt.Errorf("%w: record %d is not linked to previous record", ErrNotValid, record.ID)
+				}
+
+				// every record is checked, chain is trusted from last hash of archived range
+				if !bytes.Equal(record.Hash, auditchain.Hash(record.PrevHash, record.ID, record.Action, record.Actor, record.Payload, record.Ts)) {
+					return fmt.Errorf("%w: record %d content does not match hash", ErrNotValid, record.ID)
+				}
+			}
+
+			audit := convertRecordToAudit(record)
+
+			inserted, err := p.storage.ImportAudit(ctx, audit)
+			if err != nil {
+				return fmt.Errorf("storage.ImportAudit: %w", err)
+			}
+
+			if !inserted {
+				continue
+			}
+
+			// hash is checked against payload normalized by storage
+			if audit.Hash != nil && !bytes.Equal(audit.Hash, auditchain.Hash(audit.PrevHash, audit.ID, audit.Action, audit.Actor, audit.Payload, audit.Ts)) {
+				return fmt.Errorf("%w: record %d content does not match hash", ErrNotValid, record.ID)
+			}
+
+			imported.Records++
+		}
+
+		auditRecord, err := encodeAuditRecordArchive(models.AuditActionAuditsImported, auth.UsernameFromContext(ctx), imported)
+		if err != nil {
+			return fmt.Errorf("encodeAuditRecordArchive: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+	if txErr != nil {
+		if errors.Is(txErr, ErrNotValid) {
+			return nil, txErr // nolint:wrapcheck
+		}
+
+		return nil, fmt.Errorf("storage.WithTransaction: %w", txErr)
+	}
+
+	return imported, nil
+}
+
+// matchAuditArchive archive must be exactly one of archived ranges,
+// returns true if records without hash are allowed because no hashed range is archived before
+// and records match digest of archived range
+func matchAuditArchive(archives []*models.AuditArchive, imported *models.AuditArchive, records []*models.AuditRecord) (bool, error) {
+	var matched *models.AuditArchive
+
+	legacy := true
+
+	for _, archive := range archives {
+		if archive.FirstID == imported.FirstID && archive.LastID == imported.LastID && bytes.Equal(archive.LastHash, imported.LastHash) {
+			matched = archive
+		}
+
+		if archive.LastID < imported.FirstID && len(archive.LastHash) != 0 {
+			legacy = false
+		}
+	}
+
+	if matched == nil {
+		return false, fmt.Errorf("%w: records %d-%d do not match any archived range", ErrNotValid, imported.FirstID, imported.LastID)
+	}
+
+	if matched.Records != uint64(len(records)) {
+		return false, fmt.Errorf("%w: archive has %d records instead of %d", ErrNotValid, len(records), matched.Records)
+	}
+
+	// last hash can't verify records without hash, so only range archived with digest is accepted
+	if len(matched.Digest) == 0 {
+		return false, nil
+	}
+
+	digest, err := auditarchive.Digest(records)
+	if err != nil {
+		return false, fmt.Errorf("auditarchive.Digest: %w", err)
+	}
+
+	if !bytes.Equal(matched.Digest, digest) {
+		return false, fmt.Errorf("%w: records %d-%d do not match digest of archived range", ErrNotValid, imported.FirstID, imported.LastID)
+	}
+
+	return legacy, nil
+}
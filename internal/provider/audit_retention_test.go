@@ -0,0 +1,461 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auditarchive"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_ArchiveExpiredAudits_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditRetention(&AuditRetention{
+		Keep:       time.Hour,
+		ArchiveDir: dir,
+	}))
+
+	chain := newTestAuditChain(t, 1, 5)
+	now := chain[3].Ts.Add(time.Hour)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+
+	m.storage.EXPECT().LockAuditArchive(mock.Anything).Return(nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditArchiveBatchSize)).
+		Return(chain, nil)
+
+	m.storage.EXPECT().DeleteAudits(mock.Anything, uint64(0), uint64(3)).Return(3, nil)
+
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "audit_archived" && audit.Actor == "system"
+	})).Return(nil)
+
+	archive, err := m.provider.ArchiveExpiredAudits(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), archive.FirstID)
+	require.Equal(t, uint64(3), archive.LastID)
+	require.Equal(t, uint64(3), archive.Records)
+	require.Equal(t, chain[2].Hash, archive.LastHash)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, archive.File, entries[0].Name())
+
+	file, err := os.Open(filepath.Join(dir, archive.File))
+	require.NoError(t, err)
+	defer file.Close()
+
+	reader, err := auditarchive.NewReader(file)
+	require.NoError(t, err)
+
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, chain[2].Hash, records[2].Hash)
+
+	digest, err := auditarchive.Digest(records)
+	require.NoError(t, err)
+	require.Equal(t, digest, archive.Digest)
+}
+
+func Test_ArchiveExpiredAudits_SinkBehind_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage,
+		WithAuditRetention(&AuditRetention{
+			Keep:       time.Hour,
+			ArchiveDir: dir,
+		}),
+		WithAuditSinks(&AuditSinks{
+			Sinks: []AuditSink{&testAuditSink{}},
+		}),
+	)
+
+	chain := newTestAuditChain(t, 1, 5)
+	now := chain[3].Ts.Add(time.Hour)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+
+	m.storage.EXPECT().LockAuditArchive(mock.Anything).Return(nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	// records after cursor are not delivered yet
+	m.storage.EXPECT().
+		AuditSinkCursor(mock.AnythingOfType("context.backgroundCtx"), "test").
+		Return(2, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(0), uint64(auditArchiveBatchSize)).
+		Return(chain, nil)
+
+	m.storage.EXPECT().DeleteAudits(mock.Anything, uint64(0), uint64(2)).Return(2, nil)
+
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "audit_archived"
+	})).Return(nil)
+
+	archive, err := m.provider.ArchiveExpiredAudits(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), archive.FirstID)
+	require.Equal(t, uint64(2), archive.LastID)
+	require.Equal(t, uint64(2), archive.Records)
+}
+
+func Test_ArchiveExpiredAudits_NotExpired_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditRetention(&AuditRetention{
+		Keep:       time.Hour,
+		ArchiveDir: dir,
+	}))
+
+	chain := newTestAuditChain(t, 4, 2)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+
+	m.storage.EXPECT().LockAuditArchive(mock.Anything).Return(nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.AnythingOfType("context.backgroundCtx"), storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{
+			{ID: 7, Payload: []byte(`{"version": "v1", "first_id": 1, "last_id": 3, "last_hash": "", "records": 3}`)},
+		}, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(3), uint64(auditArchiveBatchSize)).
+		Return(chain, nil)
+
+	archive, err := m.provider.ArchiveExpiredAudits(context.Background(), chain[0].Ts)
+	require.NoError(t, err)
+	require.Nil(t, archive)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func Test_ArchiveExpiredAudits_FileExists_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditRetention(&AuditRetention{
+		Keep:       time.Hour,
+		ArchiveDir: dir,
+	}))
+
+	chain := newTestAuditChain(t, 1, 5)
+	now := chain[3].Ts.Add(time.Hour)
+
+	// archive of other call is not replaced and not removed
+	path := filepath.Join(dir, "audit-0000000001-0000000003.jsonl.gz")
+	require.NoError(t, os.WriteFile(path, []byte("other"), 0o600))
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+
+	m.storage.EXPECT().LockAuditArchive(mock.Anything).Return(nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.Anything, uint64(0), uint64(auditArchiveBatchSize)).
+		Return(chain, nil)
+
+	_, err := m.provider.ArchiveExpiredAudits(context.Background(), now)
+	require.ErrorIs(t, err, ErrAlreadyExists)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "other", string(data))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func Test_ArchiveExpiredAudits_NotDeleted_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithAuditRetention(&AuditRetention{
+		Keep:       time.Hour,
+		ArchiveDir: dir,
+	}))
+
+	chain := newTestAuditChain(t, 1, 5)
+	now := chain[3].Ts.Add(time.Hour)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+
+	m.storage.EXPECT().LockAuditArchive(mock.Anything).Return(nil)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return(nil, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.Anything, uint64(0), uint64(auditArchiveBatchSize)).
+		Return(chain, nil)
+
+	m.storage.EXPECT().DeleteAudits(mock.Anything, uint64(0), uint64(3)).Return(2, nil)
+
+	_, err := m.provider.ArchiveExpiredAudits(context.Background(), now)
+	require.ErrorIs(t, err, ErrAlreadyExists)
+
+	// created archive is removed
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+// newTestArchivedAudit audit_archived record of archive with audits
+func newTestArchivedAudit(t *testing.T, audits []*storage.Audit) *storage.Audit {
+	t.Helper()
+
+	digest, err := auditarchive.Digest(convertAuditsToRecords(audits))
+	require.NoError(t, err)
+
+	audit, err := encodeAuditRecordArchive(models.AuditActionAuditArchived, auditActorSystem, &models.AuditArchive{
+		FirstID:  audits[0].ID,
+		LastID:   audits[len(audits)-1].ID,
+		LastHash: audits[len(audits)-1].Hash,
+		Digest:   digest,
+		Records:  uint64(len(audits)),
+	})
+	require.NoError(t, err)
+
+	return audit
+}
+
+// newTestArchivedAuditWithoutDigest audit_archived record written before digest of archive was recorded
+func newTestArchivedAuditWithoutDigest(t *testing.T, audits []*storage.Audit) *storage.Audit {
+	t.Helper()
+
+	audit, err := encodeAuditRecordArchive(models.AuditActionAuditArchived, auditActorSystem, &models.AuditArchive{
+		FirstID:  audits[0].ID,
+		LastID:   audits[len(audits)-1].ID,
+		LastHash: audits[len(audits)-1].Hash,
+		Records:  uint64(len(audits)),
+	})
+	require.NoError(t, err)
+
+	return audit
+}
+
+func Test_ImportAudits_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 3)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{newTestArchivedAudit(t, chain)}, nil)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+
+	m.storage.EXPECT().ImportAudit(mock.Anything, mock.AnythingOfType("*storage.Audit")).Return(true, nil).Times(2)
+	m.storage.EXPECT().ImportAudit(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.ID == 3
+	})).Return(false, nil).Once()
+
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "audits_imported"
+	})).Return(nil)
+
+	imported, err := m.provider.ImportAudits(context.Background(), convertAuditsToRecords(chain), "audit.jsonl.gz")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), imported.FirstID)
+	require.Equal(t, uint64(3), imported.LastID)
+	require.Equal(t, uint64(2), imported.Records)
+}
+
+func Test_ImportAudits_Modified_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 2)
+
+	// hashed records are checked without digest
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{newTestArchivedAuditWithoutDigest(t, chain)}, nil)
+
+	chain[1].Actor = "intruder"
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+
+	m.storage.EXPECT().ImportAudit(mock.Anything, mock.AnythingOfType("*storage.Audit")).Return(true, nil)
+
+	_, err := m.provider.ImportAudits(context.Background(), convertAuditsToRecords(chain), "audit.jsonl.gz")
+	require.ErrorIs(t, err, ErrNotValid)
+}
+
+func Test_ImportAudits_NotArchived_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 1, 3)
+
+	// records are made up to fill range which is not archived
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{newTestArchivedAudit(t, chain[:2])}, nil)
+
+	_, err := m.provider.ImportAudits(context.Background(), convertAuditsToRecords(chain), "audit.jsonl.gz")
+	require.ErrorIs(t, err, ErrNotValid)
+}
+
+func Test_ImportAudits_Legacy_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	legacy := &storage.Audit{ID: 1, Action: "project_created", Actor: "admin", Payload: []byte(`{}`)}
+	audits := append([]*storage.Audit{legacy}, newTestAuditChain(t, 2, 2)...)
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{newTestArchivedAudit(t, audits)}, nil)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+
+	m.storage.EXPECT().ImportAudit(mock.Anything, mock.AnythingOfType("*storage.Audit")).Return(true, nil).Times(3)
+
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "audits_imported"
+	})).Return(nil)
+
+	imported, err := m.provider.ImportAudits(context.Background(), convertAuditsToRecords(audits), "audit.jsonl.gz")
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), imported.Records)
+}
+
+func Test_ImportAudits_Legacy_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	legacy := &storage.Audit{ID: 1, Action: "project_created", Actor: "admin", Payload: []byte(`{}`)}
+	audits := append([]*storage.Audit{legacy}, newTestAuditChain(t, 2, 2)...)
+
+	modified := *legacy
+	modified.Actor = "intruder"
+
+	tests := []struct {
+		name     string
+		archived *storage.Audit
+		audits   []*storage.Audit
+	}{
+		// last hash can't verify record without hash
+		{name: "no digest", archived: newTestArchivedAuditWithoutDigest(t, audits), audits: audits},
+		{name: "modified", archived: newTestArchivedAudit(t, audits), audits: append([]*storage.Audit{&modified}, audits[1:]...)},
+	}
+
+	for _, tt := range tests {
+		m := newMk(t)
+
+		m.storage.EXPECT().
+			AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+			Return([]*storage.Audit{tt.archived}, nil)
+
+		m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+				return f(ctx)
+			}).
+			Maybe()
+
+		_, err := m.provider.ImportAudits(context.Background(), convertAuditsToRecords(tt.audits), "audit.jsonl.gz")
+		require.ErrorIs(t, err, ErrNotValid, tt.name)
+	}
+}
+
+func Test_ImportAudits_UnchainedRecord_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	previous := newTestAuditChain(t, 1, 2)
+	chain := newTestAuditChain(t, 3, 3)
+	// made up record without hash between chained records
+	chain[1].Hash = nil
+	chain[1].PrevHash = nil
+	chain[1].Actor = "intruder"
+
+	m.storage.EXPECT().
+		AuditsSearch(mock.Anything, storage.AuditFilter{Action: "audit_archived"}).
+		Return([]*storage.Audit{newTestArchivedAudit(t, previous), newTestArchivedAudit(t, chain)}, nil)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+
+	m.storage.EXPECT().ImportAudit(mock.Anything, mock.AnythingOfType("*storage.Audit")).Return(true, nil).Once()
+
+	_, err := m.provider.ImportAudits(context.Background(), convertAuditsToRecords(chain), "audit.jsonl.gz")
+	require.ErrorIs(t, err, ErrNotValid)
+	require.ErrorContains(t, err, "record 4 has no hash")
+}
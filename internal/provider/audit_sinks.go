@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const (
+	auditSinkBatchSize = 100
+
+	auditSinkMinRetryDelay = time.Second
+	auditSinkMaxRetryDelay = time.Minute
+)
+
+// AuditSink receives committed audit records in chain order, records may be sent more than once
+type AuditSink interface {
+	// Name identifies delivery position of sink
+	Name() string
+	Send(ctx context.Context, records []*models.AuditRecord) error
+}
+
+// AuditSinks ...
+type AuditSinks struct {
+	Sinks        []AuditSink
+	PollInterval time.Duration
+}
+
+// RunAuditSinks deliver new audit records to every sink, blocks until context is done
+func (p *Provider) RunAuditSinks(ctx context.Context) error {
+	if p.auditSinks == nil {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+
+	for _, sink := range p.auditSinks.Sinks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			p.runAuditSink(ctx, sink)
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// runAuditSink failed batch is retried with backoff until it is delivered
+func (p *Provider) runAuditSink(ctx context.Context, sink AuditSink) {
+	delay := auditSinkMinRetryDelay
+
+	for {
+		sent, err := p.deliverAuditRecords(ctx, sink)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to deliver audit records", "sink", sink.Name(), "retry_in", delay, "err", err)
+
+			if !sleepContext(ctx, delay) {
+				return
+			}
+
+			delay = min(delay*2, auditSinkMaxRetryDelay)
+
+			continue
+		}
+
+		delay = auditSinkMinRetryDelay
+
+		// next batch is sent immediately while sink is behind
+		if sent == auditSinkBatchSize {
+			continue
+		}
+
+		if !sleepContext(ctx, p.auditSinks.PollInterval) {
+			return
+		}
+	}
+}
+
+// deliverAuditRecords send next batch to sink, cursor is moved only after successful send
+func (p *Provider) deliverAuditRecords(ctx context.Context, sink AuditSink) (int, error) {
+	lastID, err := p.auditSinkCursor(ctx, sink.Name())
+	if err != nil {
+		return 0, fmt.Errorf("auditSinkCursor: %w", err)
+	}
+
+	audits, err := p.storage.AuditChain(ctx, lastID, auditSinkBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("storage.AuditChain: %w", err)
+	}
+
+	if len(audits) == 0 {
+		return 0, nil
+	}
+
+	if err := sink.Send(ctx, convertAuditsToRecords(audits)); err != nil {
+		return 0, fmt.Errorf("sink.Send: %w", err)
+	}
+
+	if err := p.storage.SetAuditSinkCursor(ctx, sink.Name(), audits[len(audits)-1].ID); err != nil {
+		return 0, fmt.Errorf("storage.SetAuditSinkCursor: %w", err)
+	}
+
+	return len(audits), nil
+}
+
+// auditSinkCursor new sink starts from the newest record
+func (p *Provider) auditSinkCursor(ctx context.Context, name string) (uint64, error) {
+	lastID, err := p.storage.AuditSinkCursor(ctx, name)
+	if err == nil {
+		return lastID, nil
+	}
+
+	if !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("storage.AuditSinkCursor: %w", err)
+	}
+
+	last, err := p.storage.LastChainedAudit(ctx)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return 0, fmt.Errorf("storage.LastChainedAudit: %w", err)
+	}
+
+	if last != nil {
+		lastID = last.ID
+	}
+
+	if err := p.storage.SetAuditSinkCursor(ctx, name, lastID); err != nil {
+		return 0, fmt.Errorf("storage.SetAuditSinkCursor: %w", err)
+	}
+
+	return lastID, nil
+}
+
+// auditSinksDeliveredID last record delivered to every sink, records after it must not be deleted
+func (p *Provider) auditSinksDeliveredID(ctx context.Context) (uint64, error) {
+	deliveredID := uint64(math.MaxUint64)
+	if p.auditSinks == nil {
+		return deliveredID, nil
+	}
+
+	for _, sink := range p.auditSinks.Sinks {
+		lastID, err := p.storage.AuditSinkCursor(ctx, sink.Name())
+		if err != nil {
+			// sink which never started begins from the newest record
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+
+			return 0, fmt.Errorf("storage.AuditSinkCursor: %w", err)
+		}
+
+		deliveredID = min(deliveredID, lastID)
+	}
+
+	return deliveredID, nil
+}
+
+// sleepContext returns false if context is done
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
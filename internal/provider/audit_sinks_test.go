@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+type testAuditSink struct {
+	err     error
+	records []*models.AuditRecord
+}
+
+func (s *testAuditSink) Name() string {
+	return "test"
+}
+
+func (s *testAuditSink) Send(_ context.Context, records []*models.AuditRecord) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.records = append(s.records, records...)
+
+	return nil
+}
+
+func Test_deliverAuditRecords_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	chain := newTestAuditChain(t, 11, 2)
+
+	m.storage.EXPECT().
+		AuditSinkCursor(mock.AnythingOfType("context.backgroundCtx"), "test").
+		Return(10, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(10), uint64(auditSinkBatchSize)).
+		Return(chain, nil)
+
+	m.storage.EXPECT().
+		SetAuditSinkCursor(mock.AnythingOfType("context.backgroundCtx"), "test", uint64(12)).
+		Return(nil)
+
+	sink := &testAuditSink{}
+
+	sent, err := m.provider.deliverAuditRecords(context.Background(), sink)
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	require.Len(t, sink.records, 2)
+	require.Equal(t, uint64(11), sink.records[0].ID)
+	require.Equal(t, chain[0].Hash, sink.records[0].Hash)
+}
+
+func Test_deliverAuditRecords_SendFailed_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().
+		AuditSinkCursor(mock.AnythingOfType("context.backgroundCtx"), "test").
+		Return(10, nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(10), uint64(auditSinkBatchSize)).
+		Return(newTestAuditChain(t, 11, 1), nil)
+
+	// cursor is not moved, batch is sent again on retry
+	sent, err := m.provider.deliverAuditRecords(context.Background(), &testAuditSink{err: errors.New("connection refused")})
+	require.EqualError(t, err, "sink.Send: connection refused")
+	require.Zero(t, sent)
+}
+
+func Test_deliverAuditRecords_NewSink_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().
+		AuditSinkCursor(mock.AnythingOfType("context.backgroundCtx"), "test").
+		Return(0, storage.ErrNotFound)
+
+	m.storage.EXPECT().
+		LastChainedAudit(mock.AnythingOfType("context.backgroundCtx")).
+		Return(&storage.Audit{ID: 42}, nil)
+
+	m.storage.EXPECT().
+		SetAuditSinkCursor(mock.AnythingOfType("context.backgroundCtx"), "test", uint64(42)).
+		Return(nil)
+
+	m.storage.EXPECT().
+		AuditChain(mock.AnythingOfType("context.backgroundCtx"), uint64(42), uint64(auditSinkBatchSize)).
+		Return(nil, nil)
+
+	sent, err := m.provider.deliverAuditRecords(context.Background(), &testAuditSink{})
+	require.NoError(t, err)
+	require.Zero(t, sent)
+}
@@ -5,16 +5,47 @@ import (
 	"fmt"
 
 	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
 )
 
-// AuditsSearch ...
-func (p *Provider) AuditsSearch(ctx context.Context, filter models.AuditFilter) ([]*models.Audit, error) {
-	audits, err := p.storage.AuditsSearch(ctx, convertModelToAuditFilter(filter))
+// AuditsSearch newest records first, next cursor is zero on last page
+func (p *Provider) AuditsSearch(ctx context.Context, filter models.AuditFilter) ([]*models.Audit, uint64, error) {
+	storageFilter := convertModelToAuditFilter(filter)
+	if filter.Limit != 0 {
+		// one more record to find out whether next page exists
+		storageFilter.Limit = filter.Limit + 1
+	}
+
+	audits, err := p.storage.AuditsSearch(ctx, storageFilter)
 	if err != nil {
-		return nil, fmt.Errorf("storage.AuditsSearch: %w", err)
+		return nil, 0, fmt.Errorf("storage.AuditsSearch: %w", err)
+	}
+
+	var nextCursor uint64
+	if filter.Limit != 0 && uint64(len(audits)) > filter.Limit {
+		audits = audits[:filter.Limit]
+		nextCursor = audits[len(audits)-1].ID
+	}
+
+	return convertAuditsToModels(ctx, audits), nextCursor, nil
+}
+
+// addAuditRecord request metadata of context is added to payload
+func (p *Provider) addAuditRecord(ctx context.Context, audit *storage.Audit) error {
+	if meta := requestMetaFromContext(ctx); meta != nil {
+		payload, err := encodeAuditRequestMeta(audit.Payload, meta)
+		if err != nil {
+			return fmt.Errorf("encodeAuditRequestMeta: %w", err)
+		}
+
+		audit.Payload = payload
+	}
+
+	if err := p.storage.AddAuditRecord(ctx, audit); err != nil {
+		return fmt.Errorf("storage.AddAuditRecord: %w", err)
 	}
 
-	return convertAuditsToModels(audits), nil
+	return nil
 }
 
 // AuditActions ...
@@ -25,5 +56,25 @@ func (p *Provider) AuditActions(_ context.Context) ([]models.AuditAction, error)
 		models.AuditActionProjectUpdated,
 		models.AuditActionProjectDeleted,
 		models.AuditActionReleaseDeleted,
+		models.AuditActionUserLogin,
+		models.AuditActionUserLoginFailed,
+		models.AuditActionUserLocked,
+		models.AuditActionMemberAdded,
+		models.AuditActionMemberRemoved,
+		models.AuditActionConfigsUpserted,
+		models.AuditActionEnvironmentCreated,
+		models.AuditActionReleaseCreated,
+		models.AuditActionUserCreated,
+		models.AuditActionUserUpdated,
+		models.AuditActionUserPasswordReset,
+		models.AuditActionUserPasswordChanged,
+		models.AuditActionUserTOTPEnrolled,
+		models.AuditActionUserTOTPEnabled,
+		models.AuditActionUserTOTPDisabled,
+		models.AuditActionUserTOTPReset,
+		models.AuditActionAPITokenCreated,
+		models.AuditActionAPITokenRevoked,
+		models.AuditActionAuditArchived,
+		models.AuditActionAuditsImported,
 	}, nil
 }
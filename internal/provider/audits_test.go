@@ -32,14 +32,15 @@ func Test_AuditSearch_ExpectOk(t *testing.T) {
 		},
 	).Return([]*storage.Audit{
 		{
-			ID:     10,
-			Action: "project_updated",
-			Actor:  "test_actor",
-			Ts:     now,
+			ID:      10,
+			Action:  "project_updated",
+			Actor:   "test_actor",
+			Payload: []byte(`{"version":"v1","project":"test_project","old_description":"old","new_description":"new"}`),
+			Ts:      now,
 		},
 	}, nil)
 
-	got, err := m.provider.AuditsSearch(context.Background(), models.AuditFilter{
+	got, nextCursor, err := m.provider.AuditsSearch(context.Background(), models.AuditFilter{
 		Action:   "project_updated",
 		Actor:    "test_actor",
 		FromDate: fromDate,
@@ -47,15 +48,53 @@ func Test_AuditSearch_ExpectOk(t *testing.T) {
 	})
 
 	require.NoError(t, err)
+	require.Zero(t, nextCursor)
 	require.Equal(t, got, []*models.Audit{
 		{
+			ID:     10,
 			Action: models.AuditActionProjectUpdated,
 			Actor:  "test_actor",
-			Ts:     now,
+			Payload: &models.AuditProject{
+				Project:        "test_project",
+				OldDescription: "old",
+				NewDescription: "new",
+			},
+			Ts: now,
 		},
 	})
 }
 
+func Test_AuditSearch_NextPage_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().AuditsSearch(
+		mock.AnythingOfType("context.backgroundCtx"),
+		storage.AuditFilter{
+			Project: "test_project",
+			Key:     "test_key",
+			Cursor:  100,
+			Limit:   3,
+		},
+	).Return([]*storage.Audit{
+		{ID: 99, Action: "release_created", Payload: []byte(`{"version":"v1","project":"test_project"}`)},
+		{ID: 98, Action: "release_created", Payload: []byte(`{"version":"v1","project":"test_project"}`)},
+		{ID: 97, Action: "release_created", Payload: []byte(`{"version":"v1","project":"test_project"}`)},
+	}, nil)
+
+	got, nextCursor, err := m.provider.AuditsSearch(context.Background(), models.AuditFilter{
+		Project: "test_project",
+		Key:     "test_key",
+		Cursor:  100,
+		Limit:   2,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, uint64(98), nextCursor)
+}
+
 func Test_AuditSearch_StorageError_ExpectErr(t *testing.T) {
 	t.Parallel()
 
@@ -75,7 +114,7 @@ func Test_AuditSearch_StorageError_ExpectErr(t *testing.T) {
 		},
 	).Return(nil, errors.New("some error"))
 
-	got, err := m.provider.AuditsSearch(context.Background(), models.AuditFilter{
+	got, _, err := m.provider.AuditsSearch(context.Background(), models.AuditFilter{
 		Action:   "project_updated",
 		Actor:    "test_actor",
 		FromDate: fromDate,
@@ -100,5 +139,144 @@ func Test_AuditActions_ExpectOk(t *testing.T) {
 		models.AuditActionProjectUpdated,
 		models.AuditActionProjectDeleted,
 		models.AuditActionReleaseDeleted,
+		models.AuditActionUserLogin,
+		models.AuditActionUserLoginFailed,
+		models.AuditActionUserLocked,
+		models.AuditActionMemberAdded,
+		models.AuditActionMemberRemoved,
+		models.AuditActionConfigsUpserted,
+		models.AuditActionEnvironmentCreated,
+		models.AuditActionReleaseCreated,
+		models.AuditActionUserCreated,
+		models.AuditActionUserUpdated,
+		models.AuditActionUserPasswordReset,
+		models.AuditActionUserPasswordChanged,
+		models.AuditActionUserTOTPEnrolled,
+		models.AuditActionUserTOTPEnabled,
+		models.AuditActionUserTOTPDisabled,
+		models.AuditActionUserTOTPReset,
+		models.AuditActionAPITokenCreated,
+		models.AuditActionAPITokenRevoked,
+		models.AuditActionAuditArchived,
+		models.AuditActionAuditsImported,
 	})
 }
+
+func Test_decodeAuditPayload_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	mustEncode := func(record *storage.Audit, err error) *storage.Audit {
+		require.NoError(t, err)
+		return record
+	}
+
+	tests := []struct {
+		name   string
+		record *storage.Audit
+		want   any
+	}{
+		{
+			name: "config_updated",
+			record: mustEncode(encodeAuditRecordConfigUpdated("admin", &auditRecordConfigUpdated{
+				ProjectName:     "project",
+				EnvironmentName: "prod",
+				ReleaseName:     "v1",
+				Reason:          models.ChangeReason{Reason: "incident", Ticket: "OPS-1"},
+				Items:           []*auditRecordConfigUpdatedItems{{Key: "key", OldValue: "1", NewValue: "2"}},
+			})),
+			want: &models.AuditConfigUpdated{
+				Project: "project",
+				Env:     "prod",
+				Release: "v1",
+				Reason:  "incident",
+				Ticket:  "OPS-1",
+				Items:   []models.AuditConfigValueChange{{Key: "key", OldValue: "1", NewValue: "2"}},
+			},
+		},
+		{
+			name: "configs_upserted",
+			record: mustEncode(encodeAuditRecordConfigsUpserted("admin", &auditRecordConfigsUpserted{
+				ProjectName:     "project",
+				EnvironmentName: "prod",
+				ReleaseName:     "v1",
+				Added:           []*auditRecordConfigsUpsertedItem{{Key: "added", NewType: "int"}},
+				Deleted:         []*auditRecordConfigsUpsertedItem{{Key: "deleted", OldType: "bool"}},
+			})),
+			want: &models.AuditConfigsUpserted{
+				Project:     "project",
+				Env:         "prod",
+				Release:     "v1",
+				Added:       []models.AuditConfigTypeChange{{Key: "added", NewType: "int"}},
+				TypeChanged: []models.AuditConfigTypeChange{},
+				Deleted:     []models.AuditConfigTypeChange{{Key: "deleted", OldType: "bool"}},
+			},
+		},
+		{
+			name:   "release_created",
+			record: mustEncode(encodeAuditRecordReleaseCreated("admin", "project", "prod", "v1")),
+			want:   &models.AuditRelease{Project: "project", Env: "prod", Release: "v1"},
+		},
+		{
+			name:   "user_locked",
+			record: mustEncode(encodeAuditRecordUserLocked("admin", "10.0.0.1", "ip", time.Minute)),
+			want:   &models.AuditUserLogin{Username: "admin", IP: "10.0.0.1", Reason: "ip", Duration: "1m0s"},
+		},
+		{
+			name: "user_updated",
+			record: mustEncode(encodeAuditRecordUserUpdated("admin", &auditRecordUserUpdated{
+				Username:      "user",
+				OldIsEnabled:  true,
+				OldRoles:      []string{"viewer"},
+				NewRoles:      []string{"viewer"},
+				TokensRevoked: true,
+			})),
+			want: &models.AuditUserUpdated{
+				Username:      "user",
+				OldIsEnabled:  true,
+				OldRoles:      []string{"viewer"},
+				NewRoles:      []string{"viewer"},
+				TokensRevoked: true,
+			},
+		},
+		{
+			name:   "unknown",
+			record: &storage.Audit{Action: "unknown", Payload: []byte(`{"version":"v1","field":"value"}`)},
+			want:   map[string]any{"version": "v1", "field": "value"},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := decodeAuditPayload(models.ConvertAuditActionToModel(tt.record.Action), tt.record.Payload)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.want, got, tt.name)
+	}
+}
+
+func Test_addAuditRecord_RequestMeta_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	ctx := RequestMetaToContext(context.Background(), &models.RequestMeta{
+		ID:        "req-1",
+		IP:        "10.0.0.1",
+		UserAgent: "rtcctl",
+	})
+
+	m.storage.EXPECT().
+		AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+			return string(audit.Payload) == `{"project":"test_project","request":{"id":"req-1","ip":"10.0.0.1","user_agent":"rtcctl"},"version":"v1"}`
+		})).
+		Return(nil)
+
+	err := m.provider.addAuditRecord(ctx, &storage.Audit{
+		Action:  "project_created",
+		Actor:   "admin",
+		Payload: []byte(`{"version":"v1","project":"test_project"}`),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, &models.RequestMeta{ID: "req-1", IP: "10.0.0.1", UserAgent: "rtcctl"},
+		decodeAuditRequestMeta([]byte(`{"version":"v1","request":{"id":"req-1","ip":"10.0.0.1","user_agent":"rtcctl"}}`)))
+	require.Nil(t, decodeAuditRequestMeta([]byte(`{"version":"v1"}`)))
+}
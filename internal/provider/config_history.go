@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+// ConfigHistory value changes of config key built from config_updated audit records, newest first.
+// Project, environment, release and key of filter are required.
+func (p *Provider) ConfigHistory(ctx context.Context, filter models.AuditFilter) ([]*models.ConfigHistoryItem, uint64, error) {
+	filter.Action = models.AuditActionConfigUpdated
+
+	audits, nextCursor, err := p.AuditsSearch(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("p.AuditsSearch: %w", err)
+	}
+
+	history := make([]*models.ConfigHistoryItem, 0, len(audits))
+
+	for _, audit := range audits {
+		payload, change, ok := configValueChange(audit, filter.Project, filter.Env, filter.Release, filter.Key)
+		if !ok {
+			continue
+		}
+
+		history = append(history, &models.ConfigHistoryItem{
+			ID:       audit.ID,
+			Actor:    audit.Actor,
+			OldValue: []byte(change.OldValue),
+			NewValue: []byte(change.NewValue),
+			Reason:   payload.Reason,
+			Ticket:   payload.Ticket,
+			Ts:       audit.Ts,
+		})
+	}
+
+	return history, nextCursor, nil
+}
+
+// ConfigHistoryValue value of key set by config_updated audit record or value before it
+func (p *Provider) ConfigHistoryValue(ctx context.Context, projectName, envName, releaseName, key string, id uint64, before bool) ([]byte, error) {
+	record, err := p.storage.Audit(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("storage.Audit: %w", err)
+	}
+
+	audits := convertAuditsToModels(ctx, []*storage.Audit{record})
+
+	_, change, ok := configValueChange(audits[0], projectName, envName, releaseName, key)
+	if !ok {
+		return nil, fmt.Errorf("%w: audit record %d is not change of %s", ErrNotFound, id, key)
+	}
+
+	if before {
+		return []byte(change.OldValue), nil
+	}
+
+	return []byte(change.NewValue), nil
+}
+
+// RevertConfigValue restore historical value of key, see ConfigHistoryValue.
+// Value is set by SetConfigValues, so revert is audited as usual change.
+func (p *Provider) RevertConfigValue(ctx context.Context, projectName, envName, releaseName, key string, id uint64, before bool, reason models.ChangeReason) error {
+	value, err := p.ConfigHistoryValue(ctx, projectName, envName, releaseName, key, id, before)
+	if err != nil {
+		return fmt.Errorf("p.ConfigHistoryValue: %w", err)
+	}
+
+	// key may be deleted by upsert after change
+	if _, err := p.storage.Config(ctx, projectName, envName, releaseName, key); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return fmt.Errorf("storage.Config: %w", err)
+	}
+
+	if err := p.SetConfigValues(ctx, projectName, envName, releaseName, models.KV{key: value}, reason); err != nil {
+		return fmt.Errorf("p.SetConfigValues: %w", err)
+	}
+
+	return nil
+}
+
+// configValueChange change of key in config_updated audit record of release
+func configValueChange(audit *models.Audit, projectName, envName, releaseName, key string) (*models.AuditConfigUpdated, *models.AuditConfigValueChange, bool) {
+	payload, ok := audit.Payload.(*models.AuditConfigUpdated)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if payload.Project != projectName || payload.Env != envName || payload.Release != releaseName {
+		return nil, nil, false
+	}
+
+	for _, item := range payload.Items {
+		if item.Key == key {
+			return payload, &item, true
+		}
+	}
+
+	return nil, nil, false
+}
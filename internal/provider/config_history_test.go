@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_ConfigHistory_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	now := time.Now()
+
+	m.storage.EXPECT().
+		AuditsSearch(
+			mock.AnythingOfType("context.backgroundCtx"),
+			storage.AuditFilter{
+				Action:  "config_updated",
+				Project: "test_project",
+				Env:     "test_env",
+				Release: "test_release",
+				Key:     "max_conns",
+				Limit:   11,
+			},
+		).
+		Return([]*storage.Audit{
+			{
+				ID:      2,
+				Action:  "config_updated",
+				Actor:   "admin",
+				Payload: []byte(`{"version":"v1","project":"test_project","environment":"test_env","release":"test_release","items":[{"key":"timeout","old_value":"1","new_value":"2"},{"key":"max_conns","old_value":"10","new_value":"20"}]}`),
+				Ts:      now,
+			},
+			{
+				// environment of payload is checked again after search
+				ID:      1,
+				Action:  "config_updated",
+				Actor:   "admin",
+				Payload: []byte(`{"version":"v1","project":"test_project","environment":"other_env","release":"test_release","items":[{"key":"max_conns","old_value":"1","new_value":"2"}]}`),
+				Ts:      now,
+			},
+		}, nil)
+
+	history, nextCursor, err := m.provider.ConfigHistory(context.Background(), models.AuditFilter{
+		Project: "test_project",
+		Env:     "test_env",
+		Release: "test_release",
+		Key:     "max_conns",
+		Limit:   10,
+	})
+
+	require.NoError(t, err)
+	require.Zero(t, nextCursor)
+	require.Equal(t, []*models.ConfigHistoryItem{
+		{
+			ID:       2,
+			Actor:    "admin",
+			OldValue: []byte("10"),
+			NewValue: []byte("20"),
+			Ts:       now,
+		},
+	}, history)
+}
+
+func Test_ConfigHistoryValue_OtherKey_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().
+		Audit(mock.AnythingOfType("context.backgroundCtx"), uint64(2)).
+		Return(&storage.Audit{
+			ID:      2,
+			Action:  "config_updated",
+			Payload: []byte(`{"version":"v1","project":"test_project","environment":"test_env","release":"test_release","items":[{"key":"timeout","old_value":"1","new_value":"2"}]}`),
+		}, nil)
+
+	_, err := m.provider.ConfigHistoryValue(context.Background(), "test_project", "test_env", "test_release", "max_conns", 2, false)
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_RevertConfigValue_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().
+		Audit(mock.AnythingOfType("context.backgroundCtx"), uint64(2)).
+		Return(&storage.Audit{
+			ID:      2,
+			Action:  "config_updated",
+			Payload: []byte(`{"version":"v1","project":"test_project","environment":"test_env","release":"test_release","items":[{"key":"max_conns","old_value":"10","new_value":"20"}]}`),
+		}, nil)
+
+	m.storage.EXPECT().
+		Config(mock.AnythingOfType("context.backgroundCtx"), "test_project", "test_env", "test_release", "max_conns").
+		Return(&storage.Config{ID: 5, Key: "max_conns"}, nil)
+
+	m.storage.EXPECT().
+		ConfigsByKeys(mock.AnythingOfType("context.backgroundCtx"), "test_project", "test_env", "test_release", []string{"max_conns"}).
+		Return([]*storage.Config{
+			{
+				ID:        5,
+				Key:       "max_conns",
+				ValueType: "int",
+				Metadata:  []byte(`{"version":"v1", "writable": true}`),
+			},
+		}, nil)
+
+	m.valuesStorage.EXPECT().
+		Values(mock.AnythingOfType("context.backgroundCtx"), []storage.ValuesStorageKey{"test_project/test_env/test_release/max_conns"}).
+		Return(storage.ValuesStorageKV{
+			"max_conns": []byte("20"),
+		}, nil)
+
+	m.storage.EXPECT().
+		WithTransaction(mock.AnythingOfType("context.backgroundCtx"), mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+
+	m.valuesStorage.EXPECT().
+		SetValues(mock.AnythingOfType("context.backgroundCtx"), storage.ValuesStorageKV{
+			"test_project/test_env/test_release/max_conns": []byte("10"),
+		}).
+		Return(nil)
+
+	m.storage.EXPECT().
+		MarkConfigsUpdated(mock.AnythingOfType("context.backgroundCtx"), []uint64{5}).
+		Return(nil)
+
+	m.storage.EXPECT().
+		AddAuditRecord(mock.AnythingOfType("context.backgroundCtx"), mock.MatchedBy(func(record *storage.Audit) bool {
+			return record.Action == string(models.AuditActionConfigUpdated) &&
+				strings.Contains(string(record.Payload), `"reason":"rollback"`)
+		})).
+		Return(nil)
+
+	err := m.provider.RevertConfigValue(context.Background(), "test_project", "test_env", "test_release", "max_conns", 2, true, models.ChangeReason{Reason: "rollback"})
+
+	require.NoError(t, err)
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"path"
+	"slices"
 
 	"github.com/samber/lo"
 
@@ -34,8 +36,12 @@ func (p *Provider) Configs(ctx context.Context, projectName, envName, releaseNam
 	return convertConfigsToModel(configs, values), nil
 }
 
-// SetConfigValues ...
-func (p *Provider) SetConfigValues(ctx context.Context, projectName, envName, releaseName string, kv models.KV) error {
+// SetConfigValues reason is stored in audit record, it is mandatory in environments configured by WithReasonRequiredEnvs
+func (p *Provider) SetConfigValues(ctx context.Context, projectName, envName, releaseName string, kv models.KV, reason models.ChangeReason) error {
+	if err := p.validateChangeReason(envName, reason); err != nil {
+		return fmt.Errorf("validateChangeReason: %w", err)
+	}
+
 	storageKeys := lo.MapToSlice(kv, func(key string, _ []byte) string {
 		return key
 	})
@@ -88,6 +94,7 @@ func (p *Provider) SetConfigValues(ctx context.Context, projectName, envName, re
 		ProjectName:     projectName,
 		EnvironmentName: envName,
 		ReleaseName:     releaseName,
+		Reason:          reason,
 		Items:           auditLogItems,
 	})
 	if err != nil {
@@ -103,8 +110,8 @@ func (p *Provider) SetConfigValues(ctx context.Context, projectName, envName, re
 			return fmt.Errorf("storage.MarkConfigUpdated: %w", err)
 		}
 
-		if err := p.storage.AddAuditRecord(ctx, auditRecord); err != nil {
-			return fmt.Errorf("storage.AddAuditRecord: %w", err)
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
 		}
 
 		return nil
@@ -117,42 +124,141 @@ func (p *Provider) SetConfigValues(ctx context.Context, projectName, envName, re
 	return nil
 }
 
-// UpsertConfigs ...
-func (p *Provider) UpsertConfigs(ctx context.Context, projectName, envName, releaseName string, configs []*models.Config) error {
+// ConfigChanges returns current and new values of keys, used for authorization before SetConfigValues
+func (p *Provider) ConfigChanges(ctx context.Context, projectName, envName, releaseName string, kv models.KV) ([]*models.ConfigChange, error) {
+	keys := slices.Sorted(maps.Keys(kv))
+
+	configsFromStorage, err := p.storage.ConfigsByKeys(ctx, projectName, envName, releaseName, keys)
+	if err != nil {
+		return nil, fmt.Errorf("storage.ConfigsByKeys: %w", err)
+	}
+
+	valuesStorageKeys := lo.Map(configsFromStorage, func(item *storage.Config, _ int) storage.ValuesStorageKey {
+		return formatValuesStorageKey(projectName, envName, releaseName, item.Key)
+	})
+
+	actualValues, err := p.valuesStorage.Values(ctx, valuesStorageKeys)
+	if err != nil {
+		return nil, fmt.Errorf("valuesStorage.Values: %w", err)
+	}
+
+	configs := lo.SliceToMap(convertConfigsToModel(configsFromStorage, actualValues), func(config *models.Config) (string, *models.Config) {
+		return config.Key, config
+	})
+
+	changes := make([]*models.ConfigChange, 0, len(kv))
+
+	for _, key := range keys {
+		change := &models.ConfigChange{
+			Key:      key,
+			NewValue: kv[key],
+		}
+
+		if config, ok := configs[key]; ok {
+			change.Group = config.Metadata.Group
+			change.Exists = true
+			change.OldValue = config.Value
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// UpsertConfigs returns plan of upsert in dry run and nothing is changed, plan is nil otherwise
+// reason is mandatory in environments configured by WithReasonRequiredEnvs unless dry run
+func (p *Provider) UpsertConfigs(ctx context.Context, projectName, envName, releaseName string, configs []*models.Config, opts models.UpsertConfigsOptions) (*models.ConfigsPlan, error) {
+	if !opts.DryRun {
+		if err := p.validateChangeReason(envName, opts.Reason); err != nil {
+			return nil, fmt.Errorf("validateChangeReason: %w", err)
+		}
+	}
+
 	project, err := p.storage.ProjectByName(ctx, projectName)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
-			return ErrNotFound
+			return nil, ErrNotFound
 		}
 
-		return fmt.Errorf("storage.ProjectByName: %w", err)
+		return nil, fmt.Errorf("storage.ProjectByName: %w", err)
 	}
 
 	if err := validateUpsert(configs); err != nil {
-		return fmt.Errorf("validateUpsert: %w", err)
+		return nil, fmt.Errorf("validateUpsert: %w", err)
+	}
+
+	newValuesStorageItems, err := p.resolveNewValuesStorageItems(ctx, configs, projectName, envName, releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("resolveNewValuesStorageItems: %w", err)
 	}
 
-	environment, err := storage.GetOrCreateEnvironment(ctx, p.storage, project.ID, envName)
+	actualConfigs, err := p.storage.Configs(ctx, projectName, envName, releaseName)
 	if err != nil {
-		return fmt.Errorf("storage.GetOrCreateEnvironment: %w", err)
+		return nil, fmt.Errorf("storage.Configs: %w", err)
+	}
+
+	if opts.DryRun {
+		plan, err := resolveConfigsPlan(configs, actualConfigs, newValuesStorageItems, projectName, envName, releaseName, opts.NoPrune)
+		if err != nil {
+			return nil, fmt.Errorf("resolveConfigsPlan: %w", err)
+		}
+
+		return plan, nil
 	}
 
-	release, err := storage.GetOrCreateRelease(ctx, p.storage, environment.ID, releaseName)
+	toDelete := &toDeleteConfigs{}
+	if !opts.NoPrune {
+		toDelete = resolveToDeleteConfigs(configs, actualConfigs, projectName, envName, releaseName)
+	}
+
+	actor := auth.UsernameFromContext(ctx)
+
+	upserted := resolveUpsertedConfigs(configs, actualConfigs, projectName, envName, releaseName)
+	if opts.NoPrune {
+		upserted.Deleted = nil
+	}
+
+	upserted.Reason = opts.Reason
+
+	auditRecord, err := encodeAuditRecordConfigsUpserted(actor, upserted)
 	if err != nil {
-		return fmt.Errorf("storage.GetOrCreateRelease: %w", err)
+		return nil, fmt.Errorf("encodeAuditRecordConfigsUpserted: %w", err)
 	}
 
-	newValuesStorageItems, err := p.resolveNewValuesStorageItems(ctx, configs, projectName, envName, releaseName)
+	envAuditRecord, err := encodeAuditRecordEnvironmentCreated(actor, projectName, envName)
 	if err != nil {
-		return fmt.Errorf("resolveNewValuesStorageItems: %w", err)
+		return nil, fmt.Errorf("encodeAuditRecordEnvironmentCreated: %w", err)
 	}
 
-	toDelete, err := p.resolveToDeleteConfigs(ctx, configs, projectName, envName, releaseName)
+	releaseAuditRecord, err := encodeAuditRecordReleaseCreated(actor, projectName, envName, releaseName)
 	if err != nil {
-		return fmt.Errorf("resolveToDeleteConfigsIDs: %w", err)
+		return nil, fmt.Errorf("encodeAuditRecordReleaseCreated: %w", err)
 	}
 
 	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		environment, created, err := storage.GetOrCreateEnvironment(ctx, p.storage, project.ID, envName)
+		if err != nil {
+			return fmt.Errorf("storage.GetOrCreateEnvironment: %w", err)
+		}
+
+		if created {
+			if err := p.addAuditRecord(ctx, envAuditRecord); err != nil {
+				return fmt.Errorf("addAuditRecord: %w", err)
+			}
+		}
+
+		release, created, err := storage.GetOrCreateRelease(ctx, p.storage, environment.ID, releaseName)
+		if err != nil {
+			return fmt.Errorf("storage.GetOrCreateRelease: %w", err)
+		}
+
+		if created {
+			if err := p.addAuditRecord(ctx, releaseAuditRecord); err != nil {
+				return fmt.Errorf("addAuditRecord: %w", err)
+			}
+		}
+
 		if len(newValuesStorageItems) != 0 {
 			if err := p.valuesStorage.SetValues(ctx, newValuesStorageItems); err != nil {
 				return fmt.Errorf("valuesStorage.SetValues: %w", err)
@@ -175,14 +281,20 @@ func (p *Provider) UpsertConfigs(ctx context.Context, projectName, envName, rele
 			}
 		}
 
+		if !upserted.isEmpty() {
+			if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+				return fmt.Errorf("addAuditRecord: %w", err)
+			}
+		}
+
 		return nil
 	})
 
 	if txErr != nil {
-		return fmt.Errorf("storage.WithTransaction: %w", txErr)
+		return nil, fmt.Errorf("storage.WithTransaction: %w", txErr)
 	}
 
-	return nil
+	return nil, nil // nolint:nilnil
 }
 
 func (p *Provider) resolveNewValuesStorageItems(ctx context.Context, configs []*models.Config, projectName, envName, releaseName string) (map[storage.ValuesStorageKey]storage.ValuesStorageValue, error) {
@@ -220,27 +332,140 @@ type toDeleteConfigs struct {
 	valuesStorageKeys []storage.ValuesStorageKey
 }
 
-func (p *Provider) resolveToDeleteConfigs(ctx context.Context, configs []*models.Config, projectName, envName, releaseName string) (*toDeleteConfigs, error) {
+func resolveToDeleteConfigs(configs []*models.Config, actualConfigs []*storage.Config, projectName, envName, releaseName string) *toDeleteConfigs {
 	newKeys := make(map[string]struct{}, len(configs))
 	for _, config := range configs {
 		newKeys[config.Key] = struct{}{}
 	}
 
-	allActualConfigs, err := p.storage.Configs(ctx, projectName, envName, releaseName)
-	if err != nil {
-		return nil, fmt.Errorf("storage.Configs: %w", err)
-	}
-
 	var toDelete toDeleteConfigs
 
-	for _, actualConfig := range allActualConfigs {
+	for _, actualConfig := range actualConfigs {
 		if _, ok := newKeys[actualConfig.Key]; !ok {
 			toDelete.ids = append(toDelete.ids, actualConfig.ID)
 			toDelete.valuesStorageKeys = append(toDelete.valuesStorageKeys, formatValuesStorageKey(projectName, envName, releaseName, actualConfig.Key))
 		}
 	}
 
-	return &toDelete, nil
+	return &toDelete
+}
+
+// resolveUpsertedConfigs keys added, changed type and deleted by upsert
+func resolveUpsertedConfigs(configs []*models.Config, actualConfigs []*storage.Config, projectName, envName, releaseName string) *auditRecordConfigsUpserted {
+	actualTypes := make(map[string]string, len(actualConfigs))
+	for _, actualConfig := range actualConfigs {
+		actualTypes[actualConfig.Key] = actualConfig.ValueType
+	}
+
+	record := auditRecordConfigsUpserted{
+		ProjectName:     projectName,
+		EnvironmentName: envName,
+		ReleaseName:     releaseName,
+	}
+
+	newKeys := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		newKeys[config.Key] = struct{}{}
+
+		oldType, ok := actualTypes[config.Key]
+		switch {
+		case !ok:
+			record.Added = append(record.Added, &auditRecordConfigsUpsertedItem{
+				Key:     config.Key,
+				NewType: string(config.ValueType),
+			})
+		case oldType != string(config.ValueType):
+			record.TypeChanged = append(record.TypeChanged, &auditRecordConfigsUpsertedItem{
+				Key:     config.Key,
+				OldType: oldType,
+				NewType: string(config.ValueType),
+			})
+		}
+	}
+
+	for _, actualConfig := range actualConfigs {
+		if _, ok := newKeys[actualConfig.Key]; !ok {
+			record.Deleted = append(record.Deleted, &auditRecordConfigsUpsertedItem{
+				Key:     actualConfig.Key,
+				OldType: actualConfig.ValueType,
+			})
+		}
+	}
+
+	return &record
+}
+
+// resolveConfigsPlan value is reset only for keys missing in values storage
+func resolveConfigsPlan(configs []*models.Config, actualConfigs []*storage.Config, newValues map[storage.ValuesStorageKey]storage.ValuesStorageValue, projectName, envName, releaseName string, noPrune bool) (*models.ConfigsPlan, error) {
+	actual := make(map[string]*storage.Config, len(actualConfigs))
+	for _, actualConfig := range actualConfigs {
+		actual[actualConfig.Key] = actualConfig
+	}
+
+	var plan models.ConfigsPlan
+
+	newKeys := make(map[string]struct{}, len(configs))
+	for _, config := range configs {
+		newKeys[config.Key] = struct{}{}
+
+		item := &models.ConfigPlanItem{
+			Key:         config.Key,
+			NewType:     config.ValueType,
+			NewMetadata: &config.Metadata,
+		}
+
+		if value, ok := newValues[formatValuesStorageKey(projectName, envName, releaseName, config.Key)]; ok {
+			item.ValueReset = true
+			item.Value = value
+		}
+
+		actualConfig, ok := actual[config.Key]
+		if !ok {
+			plan.Added = append(plan.Added, item)
+			continue
+		}
+
+		metadata, err := decodeMetadata(actualConfig.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("decodeMetadata: %w", err)
+		}
+
+		item.OldType = models.ValueType(actualConfig.ValueType)
+		item.OldMetadata = &metadata
+
+		if item.OldType != item.NewType || metadata != config.Metadata {
+			plan.Changed = append(plan.Changed, item)
+			continue
+		}
+
+		plan.Unchanged = append(plan.Unchanged, item)
+	}
+
+	for _, actualConfig := range actualConfigs {
+		if _, ok := newKeys[actualConfig.Key]; ok {
+			continue
+		}
+
+		metadata, err := decodeMetadata(actualConfig.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("decodeMetadata: %w", err)
+		}
+
+		item := &models.ConfigPlanItem{
+			Key:         actualConfig.Key,
+			OldType:     models.ValueType(actualConfig.ValueType),
+			OldMetadata: &metadata,
+		}
+
+		if noPrune {
+			plan.Kept = append(plan.Kept, item)
+			continue
+		}
+
+		plan.Removed = append(plan.Removed, item)
+	}
+
+	return &plan, nil
 }
 
 func formatValuesStoragePath(projectName, envName, releaseName string) storage.ValuesStoragePath {
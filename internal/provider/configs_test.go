@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"path"
 	"testing"
 	"time"
 
@@ -195,8 +196,60 @@ func Test_SetConfigValues_ExpectOk(t *testing.T) {
 
 	err := m.provider.SetConfigValues(context.Background(), "test_project", "test_env", "test_release", models.KV{
 		"test_key": []byte("new_value"),
+	}, models.ChangeReason{})
+	require.NoError(t, err)
+}
+
+func Test_ConfigChanges_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().
+		ConfigsByKeys(
+			mock.AnythingOfType("context.backgroundCtx"),
+			"test_project",
+			"test_env",
+			"test_release",
+			[]string{"test_key", "unknown_key"},
+		).
+		Return([]*storage.Config{
+			{
+				ID:        10,
+				ReleaseID: 11,
+				Key:       "test_key",
+				ValueType: "string",
+				Metadata:  []byte(`{"version":"v1", "group": "limits", "writable": true}`),
+			},
+		}, nil)
+
+	m.valuesStorage.EXPECT().
+		Values(
+			mock.AnythingOfType("context.backgroundCtx"),
+			[]storage.ValuesStorageKey{"test_project/test_env/test_release/test_key"},
+		).
+		Return(storage.ValuesStorageKV{
+			"test_key": []byte("old_value"),
+		}, nil)
+
+	got, err := m.provider.ConfigChanges(context.Background(), "test_project", "test_env", "test_release", models.KV{
+		"test_key":    []byte("new_value"),
+		"unknown_key": []byte("value"),
 	})
 	require.NoError(t, err)
+	require.Equal(t, []*models.ConfigChange{
+		{
+			Key:      "test_key",
+			Group:    "limits",
+			Exists:   true,
+			OldValue: []byte("old_value"),
+			NewValue: []byte("new_value"),
+		},
+		{
+			Key:      "unknown_key",
+			NewValue: []byte("value"),
+		},
+	}, got)
 }
 
 func Test_SetConfigValues_ConfigsByKeysError_ExpectErr(t *testing.T) {
@@ -216,7 +269,7 @@ func Test_SetConfigValues_ConfigsByKeysError_ExpectErr(t *testing.T) {
 
 	err := m.provider.SetConfigValues(context.Background(), "test_project", "test_env", "test_release", models.KV{
 		"test_key": []byte("new_value"),
-	})
+	}, models.ChangeReason{})
 	require.EqualError(t, err, "storage.ConfigsByKeys: storage error")
 }
 
@@ -251,7 +304,7 @@ func Test_SetConfigValues_ValuesError_ExpectErr(t *testing.T) {
 
 	err := m.provider.SetConfigValues(context.Background(), "test_project", "test_env", "test_release", models.KV{
 		"test_key": []byte("new_value"),
-	})
+	}, models.ChangeReason{})
 	require.EqualError(t, err, "valuesStorage.Values: values error")
 }
 
@@ -274,7 +327,7 @@ func Test_UpsertConfigs_ProjectNotFound_ExpectErr(t *testing.T) {
 			Value:     []byte("value"),
 		},
 	}
-	err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs)
+	_, err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs, models.UpsertConfigsOptions{})
 	require.ErrorIs(t, err, ErrNotFound)
 }
 
@@ -297,6 +350,267 @@ func Test_UpsertConfigs_ProjectByNameError_ExpectErr(t *testing.T) {
 			Value:     []byte("value"),
 		},
 	}
-	err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs)
+	_, err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs, models.UpsertConfigsOptions{})
 	require.EqualError(t, err, "storage.ProjectByName: project error")
 }
+
+func Test_UpsertConfigs_NewRelease_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().ProjectByName(mock.Anything, "test_project").Return(&storage.Project{ID: 1, Name: "test_project"}, nil)
+	m.valuesStorage.EXPECT().Values(mock.Anything, []storage.ValuesStorageKey{"test_project/test_env/test_release/test_key"}).
+		Return(map[storage.ValuesStorageKey]storage.ValuesStorageValue{}, nil)
+	m.storage.EXPECT().Configs(mock.Anything, "test_project", "test_env", "test_release").Return(nil, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().Environment(mock.Anything, uint64(1), "test_env").Return(nil, storage.ErrNotFound)
+	m.storage.EXPECT().CreateEnvironment(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, env *storage.Environment) {
+			env.ID = 2
+		}).
+		Return(nil)
+	m.storage.EXPECT().Release(mock.Anything, uint64(2), "test_release").Return(nil, storage.ErrNotFound)
+	m.storage.EXPECT().CreateRelease(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, release *storage.Release) {
+			release.ID = 3
+		}).
+		Return(nil)
+	m.valuesStorage.EXPECT().SetValues(mock.Anything, storage.ValuesStorageKV{
+		"test_project/test_env/test_release/test_key": []byte("value"),
+	}).Return(nil)
+	m.storage.EXPECT().UpsertConfigs(mock.Anything, mock.Anything).Return(nil)
+
+	var actions []string
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, record *storage.Audit) {
+			actions = append(actions, record.Action)
+		}).
+		Return(nil)
+
+	configs := []*models.Config{
+		{
+			Key:       "test_key",
+			ValueType: "string",
+			Value:     []byte("value"),
+		},
+	}
+	_, err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs, models.UpsertConfigsOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		string(models.AuditActionEnvironmentCreated),
+		string(models.AuditActionReleaseCreated),
+		string(models.AuditActionConfigsUpserted),
+	}, actions)
+}
+
+func Test_UpsertConfigs_DryRun_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().ProjectByName(mock.Anything, "test_project").Return(&storage.Project{ID: 1, Name: "test_project"}, nil)
+	m.valuesStorage.EXPECT().Values(mock.Anything, []storage.ValuesStorageKey{"test_project/test_env/test_release/test_key"}).
+		Return(map[storage.ValuesStorageKey]storage.ValuesStorageValue{}, nil)
+	m.storage.EXPECT().Configs(mock.Anything, "test_project", "test_env", "test_release").
+		Return([]*storage.Config{{ID: 4, Key: "old_key", ValueType: "int", Metadata: []byte(`{"version":"v1","usage":"old"}`)}}, nil)
+
+	configs := []*models.Config{
+		{
+			Key:       "test_key",
+			ValueType: "string",
+			Value:     []byte("value"),
+			Metadata:  models.ConfigMetadata{Usage: "test"},
+		},
+	}
+	plan, err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs, models.UpsertConfigsOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, &models.ConfigsPlan{
+		Added: []*models.ConfigPlanItem{
+			{
+				Key:         "test_key",
+				NewType:     models.ValueTypeString,
+				NewMetadata: &models.ConfigMetadata{Usage: "test"},
+				ValueReset:  true,
+				Value:       []byte("value"),
+			},
+		},
+		Removed: []*models.ConfigPlanItem{
+			{
+				Key:         "old_key",
+				OldType:     models.ValueTypeInt,
+				OldMetadata: &models.ConfigMetadata{Usage: "old"},
+			},
+		},
+	}, plan)
+}
+
+func Test_UpsertConfigs_NoPrune_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().ProjectByName(mock.Anything, "test_project").Return(&storage.Project{ID: 1, Name: "test_project"}, nil)
+	m.valuesStorage.EXPECT().Values(mock.Anything, []storage.ValuesStorageKey{"test_project/test_env/test_release/test_key"}).
+		Return(map[storage.ValuesStorageKey]storage.ValuesStorageValue{
+			"test_project/test_env/test_release/test_key": []byte("actual"),
+		}, nil)
+	m.storage.EXPECT().Configs(mock.Anything, "test_project", "test_env", "test_release").
+		Return([]*storage.Config{
+			{ID: 3, Key: "test_key", ValueType: "string", Metadata: []byte(`{"version":"v1","usage":"test"}`)},
+			{ID: 4, Key: "old_key", ValueType: "int", Metadata: []byte(`{"version":"v1","usage":"old"}`)},
+		}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().Environment(mock.Anything, uint64(1), "test_env").Return(&storage.Environment{ID: 2}, nil)
+	m.storage.EXPECT().Release(mock.Anything, uint64(2), "test_release").Return(&storage.Release{ID: 3}, nil)
+	m.storage.EXPECT().UpsertConfigs(mock.Anything, mock.Anything).Return(nil)
+
+	configs := []*models.Config{
+		{
+			Key:       "test_key",
+			ValueType: "string",
+			Value:     []byte("value"),
+			Metadata:  models.ConfigMetadata{Usage: "test"},
+		},
+	}
+	// old_key is not deleted, plan is not built without dry run
+	plan, err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs, models.UpsertConfigsOptions{NoPrune: true})
+	require.NoError(t, err)
+	require.Nil(t, plan)
+}
+
+func Test_UpsertConfigs_DryRunNoPrune_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().ProjectByName(mock.Anything, "test_project").Return(&storage.Project{ID: 1, Name: "test_project"}, nil)
+	m.valuesStorage.EXPECT().Values(mock.Anything, []storage.ValuesStorageKey{"test_project/test_env/test_release/test_key"}).
+		Return(map[storage.ValuesStorageKey]storage.ValuesStorageValue{
+			"test_project/test_env/test_release/test_key": []byte("actual"),
+		}, nil)
+	m.storage.EXPECT().Configs(mock.Anything, "test_project", "test_env", "test_release").
+		Return([]*storage.Config{
+			{ID: 3, Key: "test_key", ValueType: "string", Metadata: []byte(`{"version":"v1","usage":"test"}`)},
+			{ID: 4, Key: "old_key", ValueType: "int", Metadata: []byte(`{"version":"v1","usage":"old"}`)},
+		}, nil)
+
+	configs := []*models.Config{
+		{
+			Key:       "test_key",
+			ValueType: "string",
+			Value:     []byte("value"),
+			Metadata:  models.ConfigMetadata{Usage: "test"},
+		},
+	}
+	plan, err := m.provider.UpsertConfigs(context.Background(), "test_project", "test_env", "test_release", configs, models.UpsertConfigsOptions{DryRun: true, NoPrune: true})
+	require.NoError(t, err)
+	require.Empty(t, plan.Removed)
+	require.Len(t, plan.Kept, 1)
+	require.Equal(t, "old_key", plan.Kept[0].Key)
+	require.Len(t, plan.Unchanged, 1)
+	require.False(t, plan.Unchanged[0].ValueReset)
+}
+
+func Test_resolveConfigsPlan_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	configs := []*models.Config{
+		{Key: "type_changed", ValueType: "bool", Value: []byte("true")},
+		{Key: "meta_changed", ValueType: "string", Value: []byte("value"), Metadata: models.ConfigMetadata{Usage: "new"}},
+		{Key: "reset", ValueType: "string", Value: []byte("value")},
+	}
+	actualConfigs := []*storage.Config{
+		{Key: "type_changed", ValueType: "string", Metadata: []byte(`{"version":"v1"}`)},
+		{Key: "meta_changed", ValueType: "string", Metadata: []byte(`{"version":"v1","usage":"old"}`)},
+		{Key: "reset", ValueType: "string", Metadata: []byte(`{"version":"v1"}`)},
+	}
+	newValues := map[storage.ValuesStorageKey]storage.ValuesStorageValue{
+		"test_project/test_env/test_release/reset": []byte("value"),
+	}
+
+	plan, err := resolveConfigsPlan(configs, actualConfigs, newValues, "test_project", "test_env", "test_release", false)
+	require.NoError(t, err)
+	require.Empty(t, plan.Added)
+	require.Empty(t, plan.Removed)
+	require.Len(t, plan.Changed, 2)
+	require.Equal(t, models.ValueTypeString, plan.Changed[0].OldType)
+	require.Equal(t, models.ValueTypeBool, plan.Changed[0].NewType)
+	require.False(t, plan.Changed[0].ValueReset)
+	require.Equal(t, "old", plan.Changed[1].OldMetadata.Usage)
+	require.Equal(t, "new", plan.Changed[1].NewMetadata.Usage)
+	require.Len(t, plan.Unchanged, 1)
+	require.True(t, plan.Unchanged[0].ValueReset)
+	require.Equal(t, []byte("value"), plan.Unchanged[0].Value)
+}
+
+func Test_resolveUpsertedConfigs_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	configs := []*models.Config{
+		{Key: "added", ValueType: "int"},
+		{Key: "changed", ValueType: "bool"},
+		{Key: "same", ValueType: "string"},
+	}
+	actualConfigs := []*storage.Config{
+		{Key: "changed", ValueType: "string"},
+		{Key: "same", ValueType: "string"},
+		{Key: "deleted", ValueType: "float"},
+	}
+
+	record := resolveUpsertedConfigs(configs, actualConfigs, "test_project", "test_env", "test_release")
+
+	require.Equal(t, &auditRecordConfigsUpserted{
+		ProjectName:     "test_project",
+		EnvironmentName: "test_env",
+		ReleaseName:     "test_release",
+		Added:           []*auditRecordConfigsUpsertedItem{{Key: "added", NewType: "int"}},
+		TypeChanged:     []*auditRecordConfigsUpsertedItem{{Key: "changed", OldType: "string", NewType: "bool"}},
+		Deleted:         []*auditRecordConfigsUpsertedItem{{Key: "deleted", OldType: "float"}},
+	}, record)
+}
+
+func Test_SetConfigValues_ReasonRequired_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithReasonRequiredEnvs([]string{"prod", "prod-*"}))
+
+	err := m.provider.SetConfigValues(context.Background(), "test_project", "prod-eu", "test_release", models.KV{
+		"test_key": []byte("new_value"),
+	}, models.ChangeReason{Ticket: "OPS-1"})
+	require.ErrorIs(t, err, ErrNotValid)
+}
+
+func Test_SetConfigValues_InvalidReasonPattern_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithReasonRequiredEnvs([]string{"prod-["}))
+
+	err := m.provider.SetConfigValues(context.Background(), "test_project", "prod-eu", "test_release", models.KV{
+		"test_key": []byte("new_value"),
+	}, models.ChangeReason{})
+	require.ErrorIs(t, err, path.ErrBadPattern)
+}
+
+func Test_UpsertConfigs_ReasonRequired_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithReasonRequiredEnvs([]string{"prod"}))
+
+	got, err := m.provider.UpsertConfigs(context.Background(), "test_project", "prod", "test_release", []*models.Config{
+		{Key: "test_key", ValueType: models.ValueTypeString, Value: []byte("value")},
+	}, models.UpsertConfigsOptions{Reason: models.ChangeReason{Ticket: "OPS-1"}})
+	require.Nil(t, got)
+	require.ErrorIs(t, err, ErrNotValid)
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+type ctxKeyRequestMeta struct{}
+
+// RequestMetaToContext request metadata is added to audit records written with context
+func RequestMetaToContext(ctx context.Context, meta *models.RequestMeta) context.Context {
+	return context.WithValue(ctx, ctxKeyRequestMeta{}, meta)
+}
+
+func requestMetaFromContext(ctx context.Context) *models.RequestMeta {
+	meta, ok := ctx.Value(ctxKeyRequestMeta{}).(*models.RequestMeta)
+	if !ok {
+		return nil
+	}
+
+	return meta
+}
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 
@@ -109,18 +110,33 @@ func convertModelToAuditFilter(filter models.AuditFilter) storage.AuditFilter {
 	return storage.AuditFilter{
 		Action:   string(filter.Action),
 		Actor:    filter.Actor,
+		Project:  filter.Project,
+		Env:      filter.Env,
+		Release:  filter.Release,
+		Key:      filter.Key,
 		FromDate: filter.FromDate,
 		ToDate:   filter.ToDate,
+		Cursor:   filter.Cursor,
+		Limit:    filter.Limit,
 	}
 }
 
-func convertAuditsToModels(audits []*storage.Audit) []*models.Audit {
+func convertAuditsToModels(ctx context.Context, audits []*storage.Audit) []*models.Audit {
 	result := make([]*models.Audit, 0, len(audits))
 	for _, audit := range audits {
+		action := models.ConvertAuditActionToModel(audit.Action)
+
+		payload, err := decodeAuditPayload(action, audit.Payload)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to decode audit payload", "id", audit.ID, "err", err)
+		}
+
 		result = append(result, &models.Audit{
-			Action:  models.ConvertAuditActionToModel(audit.Action),
+			ID:      audit.ID,
+			Action:  action,
 			Actor:   audit.Actor,
-			Payload: audit.Payload,
+			Payload: payload,
+			Request: decodeAuditRequestMeta(audit.Payload),
 			Ts:      audit.Ts,
 		})
 	}
@@ -139,18 +155,109 @@ func convertUsersToModels(users []*storage.User) []*models.User {
 
 func convertUserToModel(user *storage.User) *models.User {
 	return &models.User{
-		Username:  user.Username,
-		IsEnabled: user.IsEnabled,
-		Roles:     user.Roles,
-		CreatedAt: user.CreatedAt,
+		Username:           user.Username,
+		IsEnabled:          user.IsEnabled,
+		Roles:              user.Roles,
+		MustChangePassword: user.MustChangePassword,
+		Source:             models.UserSource(user.Source),
+		TwoFactorEnabled:   user.TOTPEnabled,
+		CreatedAt:          user.CreatedAt,
 	}
 }
 
 func convertModelToUser(user *models.User, passwordHash string) *storage.User {
 	return &storage.User{
-		Username:     user.Username,
-		PasswordHash: passwordHash,
-		IsEnabled:    user.IsEnabled,
-		Roles:        user.Roles,
+		Username:           user.Username,
+		PasswordHash:       passwordHash,
+		IsEnabled:          user.IsEnabled,
+		Roles:              user.Roles,
+		MustChangePassword: user.MustChangePassword,
+		Source:             string(user.Source),
+	}
+}
+
+func convertAPITokensToModels(tokens []*storage.APIToken) []*models.APIToken {
+	result := make([]*models.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, convertAPITokenToModel(token))
+	}
+
+	return result
+}
+
+func convertAPITokenToModel(token *storage.APIToken) *models.APIToken {
+	return &models.APIToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Owner:      token.Username,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func convertRoleBindingsToMembers(bindings []*storage.RoleBinding) []*models.Member {
+	members := make([]*models.Member, 0, len(bindings))
+	for _, binding := range bindings {
+		members = append(members, convertRoleBindingToMember(binding))
+	}
+
+	return members
+}
+
+func convertRoleBindingToMember(binding *storage.RoleBinding) *models.Member {
+	return &models.Member{
+		ID:        binding.ID,
+		Project:   binding.ProjectName,
+		Kind:      models.MemberKind(binding.Kind),
+		Subject:   binding.Subject,
+		Env:       binding.EnvPattern,
+		Role:      models.MemberRole(binding.Role),
+		CreatedAt: binding.CreatedAt,
+	}
+}
+
+func convertAuditCheckpointToModel(checkpoint *storage.AuditCheckpoint) *models.AuditCheckpoint {
+	return &models.AuditCheckpoint{
+		ID:        checkpoint.ID,
+		LastID:    checkpoint.LastID,
+		Hash:      checkpoint.Hash,
+		Signature: checkpoint.Signature,
+		Ts:        checkpoint.Ts,
+	}
+}
+
+func convertAuditsToRecords(audits []*storage.Audit) []*models.AuditRecord {
+	result := make([]*models.AuditRecord, 0, len(audits))
+	for _, audit := range audits {
+		result = append(result, convertAuditToRecord(audit))
+	}
+
+	return result
+}
+
+func convertAuditToRecord(audit *storage.Audit) *models.AuditRecord {
+	return &models.AuditRecord{
+		ID:       audit.ID,
+		Action:   audit.Action,
+		Actor:    audit.Actor,
+		Payload:  audit.Payload,
+		PrevHash: audit.PrevHash,
+		Hash:     audit.Hash,
+		Ts:       audit.Ts,
+	}
+}
+
+func convertRecordToAudit(record *models.AuditRecord) *storage.Audit {
+	return &storage.Audit{
+		ID:       record.ID,
+		Action:   record.Action,
+		Actor:    record.Actor,
+		Payload:  record.Payload,
+		PrevHash: record.PrevHash,
+		Hash:     record.Hash,
+		Ts:       record.Ts,
 	}
 }
@@ -1,8 +1,10 @@
 package provider
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/DesSolo/rtc/internal/models"
 	"github.com/DesSolo/rtc/internal/storage"
@@ -56,6 +58,7 @@ type auditRecordConfigUpdated struct {
 	ProjectName     string
 	EnvironmentName string
 	ReleaseName     string
+	Reason          models.ChangeReason
 	Items           []*auditRecordConfigUpdatedItems
 }
 
@@ -77,6 +80,8 @@ func encodeAuditRecordConfigUpdated(actor string, record *auditRecordConfigUpdat
 		Project     string           `json:"project"`
 		Environment string           `json:"environment"`
 		Release     string           `json:"release"`
+		Reason      string           `json:"reason,omitempty"`
+		Ticket      string           `json:"ticket,omitempty"`
 		Items       []payloadV1Items `json:"items"`
 	}
 
@@ -94,6 +99,8 @@ func encodeAuditRecordConfigUpdated(actor string, record *auditRecordConfigUpdat
 		Project:     record.ProjectName,
 		Environment: record.EnvironmentName,
 		Release:     record.ReleaseName,
+		Reason:      record.Reason.Reason,
+		Ticket:      record.Reason.Ticket,
 		Items:       items,
 	})
 	if err != nil {
@@ -197,3 +204,597 @@ func encodeAuditRecordReleaseDeleted(actor string, projectName, envName, release
 		Payload: data,
 	}, nil
 }
+
+func encodeAuditRecordUserLogin(action models.AuditAction, username, clientIP string) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version  string `json:"version"`
+		Username string `json:"username"`
+		IP       string `json:"ip"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:  "v1",
+		Username: username,
+		IP:       clientIP,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(action),
+		Actor:   username,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordUserLocked(username, clientIP, reason string, duration time.Duration) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version  string `json:"version"`
+		Username string `json:"username"`
+		IP       string `json:"ip"`
+		Reason   string `json:"reason"`
+		Duration string `json:"duration"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:  "v1",
+		Username: username,
+		IP:       clientIP,
+		Reason:   reason,
+		Duration: duration.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(models.AuditActionUserLocked),
+		Actor:   username,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordMember(action models.AuditAction, actor string, member *models.Member) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version string `json:"version"`
+		Project string `json:"project"`
+		Kind    string `json:"kind"`
+		Subject string `json:"subject"`
+		Env     string `json:"env"`
+		Role    string `json:"role"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version: "v1",
+		Project: member.Project,
+		Kind:    string(member.Kind),
+		Subject: member.Subject,
+		Env:     member.Env,
+		Role:    string(member.Role),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(action),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+type auditRecordConfigsUpserted struct {
+	ProjectName     string
+	EnvironmentName string
+	ReleaseName     string
+	Reason          models.ChangeReason
+	Added           []*auditRecordConfigsUpsertedItem
+	TypeChanged     []*auditRecordConfigsUpsertedItem
+	Deleted         []*auditRecordConfigsUpsertedItem
+}
+
+type auditRecordConfigsUpsertedItem struct {
+	Key     string
+	OldType string
+	NewType string
+}
+
+func (r *auditRecordConfigsUpserted) isEmpty() bool {
+	return len(r.Added) == 0 && len(r.TypeChanged) == 0 && len(r.Deleted) == 0
+}
+
+func encodeAuditRecordConfigsUpserted(actor string, record *auditRecordConfigsUpserted) (*storage.Audit, error) {
+	type payloadV1Items struct {
+		Key     string `json:"key"`
+		OldType string `json:"old_type,omitempty"`
+		NewType string `json:"new_type,omitempty"`
+	}
+
+	type payloadV1 struct {
+		Version     string           `json:"version"`
+		Project     string           `json:"project"`
+		Environment string           `json:"environment"`
+		Release     string           `json:"release"`
+		Reason      string           `json:"reason,omitempty"`
+		Ticket      string           `json:"ticket,omitempty"`
+		Added       []payloadV1Items `json:"added"`
+		TypeChanged []payloadV1Items `json:"type_changed"`
+		Deleted     []payloadV1Items `json:"deleted"`
+	}
+
+	convertItems := func(items []*auditRecordConfigsUpsertedItem) []payloadV1Items {
+		result := make([]payloadV1Items, 0, len(items))
+		for _, item := range items {
+			result = append(result, payloadV1Items{
+				Key:     item.Key,
+				OldType: item.OldType,
+				NewType: item.NewType,
+			})
+		}
+
+		return result
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:     "v1",
+		Project:     record.ProjectName,
+		Environment: record.EnvironmentName,
+		Release:     record.ReleaseName,
+		Reason:      record.Reason.Reason,
+		Ticket:      record.Reason.Ticket,
+		Added:       convertItems(record.Added),
+		TypeChanged: convertItems(record.TypeChanged),
+		Deleted:     convertItems(record.Deleted),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(models.AuditActionConfigsUpserted),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordEnvironmentCreated(actor string, projectName, envName string) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version string `json:"version"`
+		Project string `json:"project"`
+		Env     string `json:"env"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version: "v1",
+		Project: projectName,
+		Env:     envName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(models.AuditActionEnvironmentCreated),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordReleaseCreated(actor string, projectName, envName, releaseName string) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version string `json:"version"`
+		Project string `json:"project"`
+		Env     string `json:"env"`
+		Release string `json:"release"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version: "v1",
+		Project: projectName,
+		Env:     envName,
+		Release: releaseName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(models.AuditActionReleaseCreated),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordUserCreated(actor string, user *storage.User) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version            string   `json:"version"`
+		Username           string   `json:"username"`
+		Source             string   `json:"source"`
+		Roles              []string `json:"roles"`
+		IsEnabled          bool     `json:"is_enabled"`
+		MustChangePassword bool     `json:"must_change_password"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:            "v1",
+		Username:           user.Username,
+		Source:             user.Source,
+		Roles:              user.Roles,
+		IsEnabled:          user.IsEnabled,
+		MustChangePassword: user.MustChangePassword,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(models.AuditActionUserCreated),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+type auditRecordUserUpdated struct {
+	Username      string
+	OldIsEnabled  bool
+	NewIsEnabled  bool
+	OldRoles      []string
+	NewRoles      []string
+	TokensRevoked bool
+}
+
+func encodeAuditRecordUserUpdated(actor string, record *auditRecordUserUpdated) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version       string   `json:"version"`
+		Username      string   `json:"username"`
+		OldIsEnabled  bool     `json:"old_is_enabled"`
+		NewIsEnabled  bool     `json:"new_is_enabled"`
+		OldRoles      []string `json:"old_roles"`
+		NewRoles      []string `json:"new_roles"`
+		TokensRevoked bool     `json:"tokens_revoked"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:       "v1",
+		Username:      record.Username,
+		OldIsEnabled:  record.OldIsEnabled,
+		NewIsEnabled:  record.NewIsEnabled,
+		OldRoles:      record.OldRoles,
+		NewRoles:      record.NewRoles,
+		TokensRevoked: record.TokensRevoked,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(models.AuditActionUserUpdated),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordUserPasswordReset(actor string, username string) (*storage.Audit, error) {
+	return encodeAuditRecordUser(models.AuditActionUserPasswordReset, actor, username)
+}
+
+// encodeAuditRecordUser payload of user actions without details e.g. user_password_changed and user_totp_*
+func encodeAuditRecordUser(action models.AuditAction, actor string, username string) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version  string `json:"version"`
+		Username string `json:"username"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:  "v1",
+		Username: username,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(action),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordAPIToken(action models.AuditAction, actor string, token *storage.APIToken) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version   string     `json:"version"`
+		TokenID   uint64     `json:"token_id"`
+		Owner     string     `json:"owner"`
+		Name      string     `json:"name,omitempty"`
+		Scopes    []string   `json:"scopes,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:   "v1",
+		TokenID:   token.ID,
+		Owner:     token.Username,
+		Name:      token.Name,
+		Scopes:    token.Scopes,
+		ExpiresAt: token.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(action),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+func encodeAuditRecordArchive(action models.AuditAction, actor string, archive *models.AuditArchive) (*storage.Audit, error) {
+	type payloadV1 struct {
+		Version  string `json:"version"`
+		FirstID  uint64 `json:"first_id"`
+		LastID   uint64 `json:"last_id"`
+		LastHash string `json:"last_hash"`
+		Digest   string `json:"digest,omitempty"`
+		Records  uint64 `json:"records"`
+		File     string `json:"file"`
+	}
+
+	data, err := json.Marshal(payloadV1{
+		Version:  "v1",
+		FirstID:  archive.FirstID,
+		LastID:   archive.LastID,
+		LastHash: hex.EncodeToString(archive.LastHash),
+		Digest:   hex.EncodeToString(archive.Digest),
+		Records:  archive.Records,
+		File:     archive.File,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return &storage.Audit{
+		Action:  string(action),
+		Actor:   actor,
+		Payload: data,
+	}, nil
+}
+
+// auditPayloadV1 union of fields of all v1 audit payloads
+type auditPayloadV1 struct {
+	Version            string                    `json:"version"`
+	Project            string                    `json:"project"`
+	Environment        string                    `json:"environment"`
+	Env                string                    `json:"env"`
+	Release            string                    `json:"release"`
+	Items              []auditPayloadV1ValueItem `json:"items"`
+	Added              []auditPayloadV1TypeItem  `json:"added"`
+	TypeChanged        []auditPayloadV1TypeItem  `json:"type_changed"`
+	Deleted            []auditPayloadV1TypeItem  `json:"deleted"`
+	Description        string                    `json:"description"`
+	OldDescription     string                    `json:"old_description"`
+	NewDescription     string                    `json:"new_description"`
+	Username           string                    `json:"username"`
+	IP                 string                    `json:"ip"`
+	Reason             string                    `json:"reason"`
+	Ticket             string                    `json:"ticket"`
+	Duration           string                    `json:"duration"`
+	Kind               string                    `json:"kind"`
+	Subject            string                    `json:"subject"`
+	Role               string                    `json:"role"`
+	Source             string                    `json:"source"`
+	Roles              []string                  `json:"roles"`
+	IsEnabled          bool                      `json:"is_enabled"`
+	MustChangePassword bool                      `json:"must_change_password"`
+	OldIsEnabled       bool                      `json:"old_is_enabled"`
+	NewIsEnabled       bool                      `json:"new_is_enabled"`
+	OldRoles           []string                  `json:"old_roles"`
+	NewRoles           []string                  `json:"new_roles"`
+	TokensRevoked      bool                      `json:"tokens_revoked"`
+	FirstID            uint64                    `json:"first_id"`
+	LastID             uint64                    `json:"last_id"`
+	LastHash           string                    `json:"last_hash"`
+	Digest             string                    `json:"digest"`
+	Records            uint64                    `json:"records"`
+	File               string                    `json:"file"`
+	TokenID            uint64                    `json:"token_id"`
+	Owner              string                    `json:"owner"`
+	Name               string                    `json:"name"`
+	Scopes             []string                  `json:"scopes"`
+	ExpiresAt          *time.Time                `json:"expires_at"`
+}
+
+type auditPayloadV1ValueItem struct {
+	Key      string `json:"key"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type auditPayloadV1TypeItem struct {
+	Key     string `json:"key"`
+	OldType string `json:"old_type"`
+	NewType string `json:"new_type"`
+}
+
+// decodeAuditPayload typed payload of action, unknown actions and versions are decoded to map
+func decodeAuditPayload(action models.AuditAction, payload []byte) (any, error) {
+	var p auditPayloadV1
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if p.Version != "v1" {
+		return decodeAuditPayloadRaw(payload)
+	}
+
+	switch action {
+	case models.AuditActionConfigUpdated:
+		items := make([]models.AuditConfigValueChange, 0, len(p.Items))
+		for _, item := range p.Items {
+			items = append(items, models.AuditConfigValueChange(item))
+		}
+
+		return &models.AuditConfigUpdated{
+			Project: p.Project,
+			Env:     p.Environment,
+			Release: p.Release,
+			Reason:  p.Reason,
+			Ticket:  p.Ticket,
+			Items:   items,
+		}, nil
+	case models.AuditActionConfigsUpserted:
+		return &models.AuditConfigsUpserted{
+			Project:     p.Project,
+			Env:         p.Environment,
+			Release:     p.Release,
+			Reason:      p.Reason,
+			Ticket:      p.Ticket,
+			Added:       convertAuditPayloadTypeItems(p.Added),
+			TypeChanged: convertAuditPayloadTypeItems(p.TypeChanged),
+			Deleted:     convertAuditPayloadTypeItems(p.Deleted),
+		}, nil
+	case models.AuditActionProjectCreated, models.AuditActionProjectUpdated, models.AuditActionProjectDeleted:
+		return &models.AuditProject{
+			Project:        p.Project,
+			Description:    p.Description,
+			OldDescription: p.OldDescription,
+			NewDescription: p.NewDescription,
+		}, nil
+	case models.AuditActionEnvironmentCreated, models.AuditActionReleaseCreated, models.AuditActionReleaseDeleted:
+		return &models.AuditRelease{
+			Project: p.Project,
+			Env:     p.Env,
+			Release: p.Release,
+		}, nil
+	case models.AuditActionUserLogin, models.AuditActionUserLoginFailed, models.AuditActionUserLocked:
+		return &models.AuditUserLogin{
+			Username: p.Username,
+			IP:       p.IP,
+			Reason:   p.Reason,
+			Duration: p.Duration,
+		}, nil
+	case models.AuditActionMemberAdded, models.AuditActionMemberRemoved:
+		return &models.AuditMember{
+			Project: p.Project,
+			Kind:    models.MemberKind(p.Kind),
+			Subject: p.Subject,
+			Env:     p.Env,
+			Role:    models.MemberRole(p.Role),
+		}, nil
+	case models.AuditActionUserCreated:
+		return &models.AuditUserCreated{
+			Username:           p.Username,
+			Source:             p.Source,
+			Roles:              p.Roles,
+			IsEnabled:          p.IsEnabled,
+			MustChangePassword: p.MustChangePassword,
+		}, nil
+	case models.AuditActionUserUpdated:
+		return &models.AuditUserUpdated{
+			Username:      p.Username,
+			OldIsEnabled:  p.OldIsEnabled,
+			NewIsEnabled:  p.NewIsEnabled,
+			OldRoles:      p.OldRoles,
+			NewRoles:      p.NewRoles,
+			TokensRevoked: p.TokensRevoked,
+		}, nil
+	case models.AuditActionUserPasswordReset, models.AuditActionUserPasswordChanged:
+		return &models.AuditUserPasswordReset{
+			Username: p.Username,
+		}, nil
+	case models.AuditActionUserTOTPEnrolled, models.AuditActionUserTOTPEnabled,
+		models.AuditActionUserTOTPDisabled, models.AuditActionUserTOTPReset:
+		return &models.AuditUserTOTP{
+			Username: p.Username,
+		}, nil
+	case models.AuditActionAPITokenCreated, models.AuditActionAPITokenRevoked:
+		return &models.AuditAPIToken{
+			ID:        p.TokenID,
+			Owner:     p.Owner,
+			Name:      p.Name,
+			Scopes:    p.Scopes,
+			ExpiresAt: p.ExpiresAt,
+		}, nil
+	case models.AuditActionAuditArchived, models.AuditActionAuditsImported:
+		lastHash, err := hex.DecodeString(p.LastHash)
+		if err != nil {
+			return nil, fmt.Errorf("hex.DecodeString: %w", err)
+		}
+
+		digest, err := hex.DecodeString(p.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("hex.DecodeString: %w", err)
+		}
+
+		return &models.AuditArchive{
+			FirstID:  p.FirstID,
+			LastID:   p.LastID,
+			LastHash: lastHash,
+			Digest:   digest,
+			Records:  p.Records,
+			File:     p.File,
+		}, nil
+	default:
+		return decodeAuditPayloadRaw(payload)
+	}
+}
+
+func decodeAuditPayloadRaw(payload []byte) (map[string]any, error) {
+	var raw map[string]any
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	return raw, nil
+}
+
+func convertAuditPayloadTypeItems(items []auditPayloadV1TypeItem) []models.AuditConfigTypeChange {
+	result := make([]models.AuditConfigTypeChange, 0, len(items))
+	for _, item := range items {
+		result = append(result, models.AuditConfigTypeChange(item))
+	}
+
+	return result
+}
+
+type auditRequestMetaV1 struct {
+	ID        string `json:"id,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// encodeAuditRequestMeta adds request field to payload object
+func encodeAuditRequestMeta(payload []byte, meta *models.RequestMeta) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	request, err := json.Marshal(auditRequestMetaV1(*meta))
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal request: %w", err)
+	}
+
+	fields["request"] = request
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return data, nil
+}
+
+// decodeAuditRequestMeta returns nil if payload has no request field
+func decodeAuditRequestMeta(payload []byte) *models.RequestMeta {
+	var p struct {
+		Request *auditRequestMetaV1 `json:"request"`
+	}
+
+	if err := json.Unmarshal(payload, &p); err != nil || p.Request == nil {
+		return nil
+	}
+
+	return (*models.RequestMeta)(p.Request)
+}
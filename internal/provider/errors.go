@@ -11,4 +11,10 @@ var (
 
 	// ErrNotValid validation error
 	ErrNotValid = errors.New("not valid")
+
+	// ErrTwoFactorRequired login requires one-time code
+	ErrTwoFactorRequired = errors.New("two-factor code required")
+
+	// ErrTwoFactorEnrollmentRequired user must enroll two-factor authentication before login
+	ErrTwoFactorEnrollmentRequired = errors.New("two-factor enrollment required")
 )
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+// LoginBackend external password authentication e.g. LDAP.
+// Must return ErrNotFound for invalid credentials.
+type LoginBackend interface {
+	AuthenticatePassword(ctx context.Context, username, password string) (*models.User, error)
+}
+
+// LoginBackendFunc ...
+type LoginBackendFunc func(ctx context.Context, username, password string) (*models.User, error)
+
+// AuthenticatePassword ...
+func (f LoginBackendFunc) AuthenticatePassword(ctx context.Context, username, password string) (*models.User, error) {
+	return f(ctx, username, password)
+}
+
+// LoginAttempt credentials of login request
+type LoginAttempt struct {
+	Username string
+	Password string
+	// OTP TOTP or recovery code, required if two-factor authentication is enabled
+	OTP      string
+	ClientIP string
+}
+
+// Login authenticate user with throttling of failed attempts per username and client address.
+// Successful, failed attempts and lockouts are written to audit.
+func (p *Provider) Login(ctx context.Context, attempt LoginAttempt) (*models.User, error) {
+	userKey, ipKey := loginUserKey(attempt.Username), "ip:"+attempt.ClientIP
+
+	// attempts during lockout are not audited to not flood audit log
+	if retryAfter := max(p.loginLimiter.lockedFor(userKey), p.loginLimiter.lockedFor(ipKey)); retryAfter > 0 {
+		slog.DebugContext(ctx, "login is locked", "username", attempt.Username, "ip", attempt.ClientIP)
+		return nil, &LockedError{RetryAfter: retryAfter}
+	}
+
+	user, err := p.authenticate(ctx, attempt)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("p.authenticate: %w", err)
+		}
+
+		if err := p.loginFailed(ctx, attempt.Username, attempt.ClientIP); err != nil {
+			return nil, fmt.Errorf("p.loginFailed: %w", err)
+		}
+
+		return nil, ErrNotFound
+	}
+
+	p.loginLimiter.reset(userKey)
+
+	// login backend may return canonical username
+	auditRecord, err := encodeAuditRecordUserLogin(models.AuditActionUserLogin, user.Username, attempt.ClientIP)
+	if err != nil {
+		return nil, fmt.Errorf("encodeAuditRecordUserLogin: %w", err)
+	}
+
+	if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+		return nil, fmt.Errorf("addAuditRecord: %w", err)
+	}
+
+	return user, nil
+}
+
+// authenticate check password and second factor
+func (p *Provider) authenticate(ctx context.Context, attempt LoginAttempt) (*models.User, error) {
+	user, err := p.AuthenticateUser(ctx, attempt.Username, attempt.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.checkTwoFactor(ctx, user, attempt.OTP); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// loginFailed count failed attempt before audit, so storage errors can't bypass throttling
+func (p *Provider) loginFailed(ctx context.Context, username, clientIP string) error {
+	throttle := p.loginLimiter.throttle
+
+	var lockReasons []string
+
+	if p.loginLimiter.fail(loginUserKey(username), throttle.MaxAttempts) {
+		lockReasons = append(lockReasons, "username")
+	}
+
+	if p.loginLimiter.fail("ip:"+clientIP, throttle.MaxAttemptsPerIP) {
+		lockReasons = append(lockReasons, "ip")
+	}
+
+	auditRecord, err := encodeAuditRecordUserLogin(models.AuditActionUserLoginFailed, username, clientIP)
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordUserLogin: %w", err)
+	}
+
+	if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+		return fmt.Errorf("addAuditRecord: %w", err)
+	}
+
+	for _, reason := range lockReasons {
+		slog.WarnContext(ctx, "login locked", "username", username, "ip", clientIP, "reason", reason)
+
+		auditRecord, err := encodeAuditRecordUserLocked(username, clientIP, reason, throttle.LockoutDuration)
+		if err != nil {
+			return fmt.Errorf("encodeAuditRecordUserLocked: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loginUserKey throttle key of username, case variants share attempts
+// because directory logins are case-insensitive
+func loginUserKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func newThrottleMk(t *testing.T, throttle LoginThrottle) *mk {
+	t.Helper()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithLoginThrottle(throttle))
+
+	return m
+}
+
+func auditAction(action models.AuditAction) any {
+	return mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(action)
+	})
+}
+
+func Test_Login_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(&storage.User{
+		ID:           1,
+		Username:     "admin",
+		PasswordHash: string(hash),
+		IsEnabled:    true,
+		Source:       "local",
+	}, nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "user_login" && audit.Actor == "admin" &&
+			string(audit.Payload) == `{"version":"v1","username":"admin","ip":"10.0.0.1"}`
+	})).Return(nil)
+
+	got, err := m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", ClientIP: "10.0.0.1"})
+
+	require.NoError(t, err)
+	require.Equal(t, "admin", got.Username)
+}
+
+func Test_Login_LockUsername_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newThrottleMk(t, LoginThrottle{
+		MaxAttempts:      2,
+		MaxAttemptsPerIP: 10,
+		Window:           time.Minute,
+		LockoutDuration:  time.Minute,
+	})
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(nil, storage.ErrNotFound).Times(2)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserLoginFailed)).Return(nil).Times(2)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserLocked)).Return(nil).Once()
+
+	for range 2 {
+		_, err := m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "wrong", ClientIP: "10.0.0.1"})
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	// locked for any address, storage is not called
+	_, err := m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", ClientIP: "10.0.0.2"})
+
+	var lockedErr *LockedError
+
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+	require.ErrorAs(t, err, &lockedErr)
+	require.Positive(t, lockedErr.RetryAfter)
+}
+
+func Test_Login_LockUsernameCaseVariants_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newThrottleMk(t, LoginThrottle{
+		MaxAttempts:      2,
+		MaxAttemptsPerIP: 10,
+		Window:           time.Minute,
+		LockoutDuration:  time.Minute,
+	})
+
+	m.storage.EXPECT().User(mock.Anything, mock.Anything).Return(nil, storage.ErrNotFound).Times(2)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserLoginFailed)).Return(nil).Times(2)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserLocked)).Return(nil).Once()
+
+	for _, username := range []string{"Admin", "admin"} {
+		_, err := m.provider.Login(context.Background(), LoginAttempt{Username: username, Password: "wrong", ClientIP: "10.0.0.1"})
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	_, err := m.provider.Login(context.Background(), LoginAttempt{Username: "ADMIN", Password: "secret", ClientIP: "10.0.0.2"})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func Test_Login_LockIP_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newThrottleMk(t, LoginThrottle{
+		MaxAttempts:      10,
+		MaxAttemptsPerIP: 2,
+		Window:           time.Minute,
+		LockoutDuration:  time.Minute,
+	})
+
+	m.storage.EXPECT().User(mock.Anything, mock.Anything).Return(nil, storage.ErrNotFound).Times(2)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserLoginFailed)).Return(nil).Times(2)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserLocked)).Return(nil).Once()
+
+	for _, username := range []string{"first", "second"} {
+		_, err := m.provider.Login(context.Background(), LoginAttempt{Username: username, Password: "wrong", ClientIP: "10.0.0.1"})
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+
+	_, err := m.provider.Login(context.Background(), LoginAttempt{Username: "third", Password: "wrong", ClientIP: "10.0.0.1"})
+	require.ErrorIs(t, err, ErrTooManyAttempts)
+}
+
+func Test_loginLimiter_WindowExpired_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	limiter := newLoginLimiter(LoginThrottle{Window: time.Minute, LockoutDuration: time.Minute})
+	limiter.now = func() time.Time { return now }
+
+	require.False(t, limiter.fail("user:admin", 2))
+
+	now = now.Add(time.Minute * 2)
+
+	// previous attempt is outside of window
+	require.False(t, limiter.fail("user:admin", 2))
+	require.True(t, limiter.fail("user:admin", 2))
+	require.Equal(t, time.Minute, limiter.lockedFor("user:admin"))
+
+	now = now.Add(time.Minute)
+
+	require.Zero(t, limiter.lockedFor("user:admin"))
+}
+
+func Test_loginLimiter_MaxKeys_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	limiter := newLoginLimiter(LoginThrottle{Window: time.Minute, LockoutDuration: time.Minute})
+	limiter.now = func() time.Time { return now }
+	limiter.maxKeys = 2
+
+	require.True(t, limiter.fail("user:admin", 1))
+	require.False(t, limiter.fail("ip:10.0.0.1", 2))
+
+	// admin is failed again and becomes most recent
+	require.True(t, limiter.fail("user:admin", 1))
+	require.False(t, limiter.fail("ip:10.0.0.2", 2))
+
+	require.Len(t, limiter.attempts, 2)
+	require.Equal(t, 2, limiter.order.Len())
+	require.Equal(t, time.Minute, limiter.lockedFor("user:admin"))
+
+	// least recently failed key is evicted
+	require.False(t, limiter.fail("ip:10.0.0.1", 2))
+}
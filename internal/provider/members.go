@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+var (
+	memberKinds = []models.MemberKind{models.MemberKindUser, models.MemberKindGroup}
+	memberRoles = []models.MemberRole{models.MemberRoleViewer, models.MemberRoleEditor, models.MemberRoleAdmin}
+)
+
+// Members role bindings of project, project * is for bindings to all projects
+func (p *Provider) Members(ctx context.Context, projectName string) ([]*models.Member, error) {
+	projectID, err := p.memberProjectID(ctx, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("p.memberProjectID: %w", err)
+	}
+
+	bindings, err := p.storage.RoleBindings(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("storage.RoleBindings: %w", err)
+	}
+
+	return convertRoleBindingsToMembers(bindings), nil
+}
+
+// AddMember bind role to user or group in project environments matching pattern (all environments if empty)
+func (p *Provider) AddMember(ctx context.Context, member *models.Member) (*models.Member, error) {
+	member.Env = cmp.Or(member.Env, auth.AnyEnv)
+
+	if err := validateMember(member); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotValid, err)
+	}
+
+	projectID, err := p.memberProjectID(ctx, member.Project)
+	if err != nil {
+		return nil, fmt.Errorf("p.memberProjectID: %w", err)
+	}
+
+	binding := &storage.RoleBinding{
+		ProjectID:   projectID,
+		ProjectName: member.Project,
+		Kind:        string(member.Kind),
+		Subject:     member.Subject,
+		EnvPattern:  member.Env,
+		Role:        string(member.Role),
+	}
+
+	auditRecord, err := encodeAuditRecordMember(models.AuditActionMemberAdded, auth.UsernameFromContext(ctx), member)
+	if err != nil {
+		return nil, fmt.Errorf("encodeAuditRecordMember: %w", err)
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.CreateRoleBinding(ctx, binding); err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return ErrAlreadyExists
+			}
+
+			return fmt.Errorf("storage.CreateRoleBinding: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr // nolint:wrapcheck
+	}
+
+	return convertRoleBindingToMember(binding), nil
+}
+
+// RemoveMember delete role binding of project
+func (p *Provider) RemoveMember(ctx context.Context, projectName string, id uint64) error {
+	projectID, err := p.memberProjectID(ctx, projectName)
+	if err != nil {
+		return fmt.Errorf("p.memberProjectID: %w", err)
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		binding, err := p.storage.DeleteRoleBinding(ctx, projectID, id)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return ErrNotFound
+			}
+
+			return fmt.Errorf("storage.DeleteRoleBinding: %w", err)
+		}
+
+		binding.ProjectName = projectName
+
+		auditRecord, err := encodeAuditRecordMember(models.AuditActionMemberRemoved, auth.UsernameFromContext(ctx), convertRoleBindingToMember(binding))
+		if err != nil {
+			return fmt.Errorf("encodeAuditRecordMember: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return txErr // nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// UserMemberships role bindings of user and its groups in all projects
+func (p *Provider) UserMemberships(ctx context.Context, username string, groups []string) ([]*models.Member, error) {
+	bindings, err := p.storage.SubjectRoleBindings(ctx, username, groups)
+	if err != nil {
+		return nil, fmt.Errorf("storage.SubjectRoleBindings: %w", err)
+	}
+
+	return convertRoleBindingsToMembers(bindings), nil
+}
+
+// memberProjectID returns nil for all projects
+func (p *Provider) memberProjectID(ctx context.Context, projectName string) (*uint64, error) {
+	if projectName == models.AllProjects {
+		return nil, nil // nolint:nilnil
+	}
+
+	project, err := p.storage.ProjectByName(ctx, projectName)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("storage.ProjectByName: %w", err)
+	}
+
+	return &project.ID, nil
+}
+
+func validateMember(member *models.Member) error {
+	if !slices.Contains(memberKinds, member.Kind) {
+		return fmt.Errorf("unknown kind %q", member.Kind)
+	}
+
+	if member.Subject == "" {
+		return errors.New("subject is required")
+	}
+
+	if !slices.Contains(memberRoles, member.Role) {
+		return fmt.Errorf("unknown role %q", member.Role)
+	}
+
+	if err := auth.ValidateEnvPattern(member.Env); err != nil {
+		return fmt.Errorf("env: %w", err)
+	}
+
+	return nil
+}
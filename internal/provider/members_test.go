@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_AddMember_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	projectID := uint64(1)
+
+	m.storage.EXPECT().ProjectByName(mock.Anything, "example").Return(&storage.Project{ID: projectID, Name: "example"}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().CreateRoleBinding(mock.Anything, &storage.RoleBinding{
+		ProjectID:   &projectID,
+		ProjectName: "example",
+		Kind:        "user",
+		Subject:     "user",
+		EnvPattern:  "*",
+		Role:        "editor",
+	}).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "member_added" &&
+			string(audit.Payload) == `{"version":"v1","project":"example","kind":"user","subject":"user","env":"*","role":"editor"}`
+	})).Return(nil)
+
+	got, err := m.provider.AddMember(context.Background(), &models.Member{
+		Project: "example",
+		Kind:    models.MemberKindUser,
+		Subject: "user",
+		Role:    models.MemberRoleEditor,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "*", got.Env)
+}
+
+func Test_AddMember_NotValid_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	for _, member := range []*models.Member{
+		{Project: "example", Kind: "team", Subject: "user", Role: models.MemberRoleViewer},
+		{Project: "example", Kind: models.MemberKindUser, Role: models.MemberRoleViewer},
+		{Project: "example", Kind: models.MemberKindUser, Subject: "user", Role: "owner"},
+		{Project: "example", Kind: models.MemberKindUser, Subject: "user", Env: "[", Role: models.MemberRoleViewer},
+	} {
+		_, err := m.provider.AddMember(context.Background(), member)
+		require.ErrorIs(t, err, ErrNotValid)
+	}
+}
+
+func Test_RemoveMember_AllProjects_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().DeleteRoleBinding(mock.Anything, (*uint64)(nil), uint64(2)).Return(&storage.RoleBinding{
+		ID:         2,
+		Kind:       "group",
+		Subject:    "developers",
+		EnvPattern: "*",
+		Role:       "viewer",
+	}, nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == "member_removed" &&
+			string(audit.Payload) == `{"version":"v1","project":"*","kind":"group","subject":"developers","env":"*","role":"viewer"}`
+	})).Return(nil)
+
+	require.NoError(t, m.provider.RemoveMember(context.Background(), "*", 2))
+}
+
+func Test_RemoveMember_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().ProjectByName(mock.Anything, "example").Return(nil, errors.New("some error"))
+
+	err := m.provider.RemoveMember(context.Background(), "example", 2)
+	require.EqualError(t, err, "p.memberProjectID: storage.ProjectByName: some error")
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"cmp"
+	"time"
+)
+
+// OptionFunc ...
+type OptionFunc func(p *Provider)
+
+// Noop ...
+func Noop() OptionFunc {
+	return func(_ *Provider) {}
+}
+
+// WithPasswordPolicy ...
+func WithPasswordPolicy(policy PasswordPolicy) OptionFunc {
+	return func(p *Provider) {
+		policy.MinLength = cmp.Or(policy.MinLength, defaultPasswordPolicy.MinLength)
+
+		p.passwordPolicy = policy
+	}
+}
+
+// WithLoginBackend check passwords by external backend (e.g. LDAP) instead of local users.
+// If fallbackLocal is set local users are checked when external login fails.
+func WithLoginBackend(backend LoginBackend, fallbackLocal bool) OptionFunc {
+	return func(p *Provider) {
+		p.loginBackend = backend
+		p.fallbackLocalLogin = fallbackLocal
+	}
+}
+
+// WithRefreshTokenTTL ...
+func WithRefreshTokenTTL(ttl time.Duration) OptionFunc {
+	return func(p *Provider) {
+		p.refreshTokenTTL = ttl
+	}
+}
+
+// WithLoginThrottle ...
+func WithLoginThrottle(throttle LoginThrottle) OptionFunc {
+	return func(p *Provider) {
+		p.loginLimiter = newLoginLimiter(LoginThrottle{
+			MaxAttempts:      cmp.Or(throttle.MaxAttempts, defaultLoginThrottle.MaxAttempts),
+			MaxAttemptsPerIP: cmp.Or(throttle.MaxAttemptsPerIP, defaultLoginThrottle.MaxAttemptsPerIP),
+			Window:           cmp.Or(throttle.Window, defaultLoginThrottle.Window),
+			LockoutDuration:  cmp.Or(throttle.LockoutDuration, defaultLoginThrottle.LockoutDuration),
+		})
+	}
+}
+
+// WithReasonRequiredEnvs change reason is mandatory in environments matching patterns e.g. prod or prod-*
+func WithReasonRequiredEnvs(patterns []string) OptionFunc {
+	return func(p *Provider) {
+		p.reasonRequiredEnvs = patterns
+	}
+}
+
+// WithTwoFactor enable TOTP two-factor authentication for local users
+func WithTwoFactor(policy TwoFactorPolicy) OptionFunc {
+	return func(p *Provider) {
+		p.twoFactor = &policy
+	}
+}
+
+// WithAuditCheckpoints sign audit chain head by interval, signatures are checked on verification
+func WithAuditCheckpoints(checkpoints *AuditCheckpoints) OptionFunc {
+	return func(p *Provider) {
+		p.auditCheckpoints = checkpoints
+	}
+}
+
+// WithAuditSinks export committed audit records
+func WithAuditSinks(sinks *AuditSinks) OptionFunc {
+	return func(p *Provider) {
+		p.auditSinks = sinks
+	}
+}
+
+// WithAuditRetention archive and delete expired audit records
+func WithAuditRetention(retention *AuditRetention) OptionFunc {
+	return func(p *Provider) {
+		p.auditRetention = retention
+	}
+}
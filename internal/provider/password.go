@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+var defaultPasswordPolicy = PasswordPolicy{
+	MinLength: 8,
+}
+
+// PasswordPolicy requirements for local users passwords, zero min length is default
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// Validate checks password satisfies policy
+func (p PasswordPolicy) Validate(password string) error {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var problems []string
+
+	if len([]rune(password)) < p.MinLength {
+		problems = append(problems, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+
+	if p.RequireUpper && !hasUpper {
+		problems = append(problems, "must contain an uppercase letter")
+	}
+
+	if p.RequireLower && !hasLower {
+		problems = append(problems, "must contain a lowercase letter")
+	}
+
+	if p.RequireDigit && !hasDigit {
+		problems = append(problems, "must contain a digit")
+	}
+
+	if p.RequireSymbol && !hasSymbol {
+		problems = append(problems, "must contain a symbol")
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("%w: password %s", ErrNotValid, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+const (
+	oneTimePasswordLength = 20
+
+	passwordUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordLower   = "abcdefghijkmnopqrstuvwxyz"
+	passwordDigits  = "23456789"
+	passwordSymbols = "!@#$%^&*-_=+"
+)
+
+// generatePassword returns random password with every character class
+// so it satisfies any policy with min length less than generated length
+func (p PasswordPolicy) generatePassword() (string, error) {
+	length := max(p.MinLength, oneTimePasswordLength)
+
+	classes := []string{passwordUpper, passwordLower, passwordDigits, passwordSymbols}
+	alphabet := strings.Join(classes, "")
+
+	result := make([]byte, 0, length)
+
+	for _, class := range classes {
+		c, err := randomChar(class)
+		if err != nil {
+			return "", err
+		}
+
+		result = append(result, c)
+	}
+
+	for len(result) < length {
+		c, err := randomChar(alphabet)
+		if err != nil {
+			return "", err
+		}
+
+		result = append(result, c)
+	}
+
+	// shuffle to avoid predictable classes positions
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("rand.Int: %w", err)
+		}
+
+		result[i], result[j.Int64()] = result[j.Int64()], result[i]
+	}
+
+	return string(result), nil
+}
+
+func randomChar(alphabet string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+	if err != nil {
+		return 0, fmt.Errorf("rand.Int: %w", err)
+	}
+
+	return alphabet[n.Int64()], nil
+}
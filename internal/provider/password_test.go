@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_PasswordPolicy_Validate_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	policy := PasswordPolicy{
+		MinLength:     10,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+
+	require.NoError(t, policy.Validate("Secret-pass1"))
+	require.ErrorIs(t, policy.Validate("Secret-1"), ErrNotValid)
+	require.ErrorIs(t, policy.Validate("secret-pass1"), ErrNotValid)
+	require.ErrorIs(t, policy.Validate("Secret-pass"), ErrNotValid)
+	require.ErrorIs(t, policy.Validate("Secretpass1"), ErrNotValid)
+}
+
+func Test_PasswordPolicy_GeneratePassword_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	policy := PasswordPolicy{
+		MinLength:     32,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+	}
+
+	password, err := policy.generatePassword()
+
+	require.NoError(t, err)
+	require.Len(t, password, 32)
+	require.NoError(t, policy.Validate(password))
+}
+
+func Test_ChangePassword_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{
+		ID:                 1,
+		Username:           "test",
+		PasswordHash:       string(hash),
+		IsEnabled:          true,
+		MustChangePassword: true,
+	}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionUserPasswordChanged) && audit.Actor == "test"
+	})).Return(nil)
+	m.storage.EXPECT().RevokeUserRefreshTokens(mock.Anything, uint64(1)).Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), mock.MatchedBy(func(user *storage.User) bool {
+		return !user.MustChangePassword && user.PasswordChangedAt != nil && user.TokensValidAfter != nil &&
+			isValidPassword(user.PasswordHash, "new-password")
+	})).Return(nil)
+
+	err = m.provider.ChangePassword(context.Background(), "test", "old-password", "new-password")
+
+	require.NoError(t, err)
+}
+
+func Test_ChangePassword_InvalidOldPassword_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{
+		ID:           1,
+		PasswordHash: string(hash),
+		IsEnabled:    true,
+	}, nil)
+
+	err = m.provider.ChangePassword(context.Background(), "test", "wrong-password", "new-password")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_ChangePassword_PolicyViolation_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{
+		ID:           1,
+		PasswordHash: string(hash),
+		IsEnabled:    true,
+	}, nil)
+
+	err = m.provider.ChangePassword(context.Background(), "test", "old-password", "short")
+
+	require.ErrorIs(t, err, ErrNotValid)
+}
+
+func Test_ResetPassword_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{ID: 1, Username: "test", Source: "local"}, nil)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().RevokeUserRefreshTokens(mock.Anything, uint64(1)).Return(nil)
+
+	var updated *storage.User
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), mock.Anything).
+		Run(func(_ context.Context, _ uint64, user *storage.User) {
+			updated = user
+		}).
+		Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(record *storage.Audit) bool {
+		return record.Action == string(models.AuditActionUserPasswordReset)
+	})).Return(nil)
+
+	password, err := m.provider.ResetPassword(context.Background(), "test")
+
+	require.NoError(t, err)
+	require.True(t, updated.MustChangePassword)
+	require.NotNil(t, updated.TokensValidAfter)
+	require.True(t, isValidPassword(updated.PasswordHash, password))
+}
+
+func Test_ExpireDefaultAdminPassword_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("rtc"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(&storage.User{
+		ID:           1,
+		Username:     "admin",
+		PasswordHash: string(hash),
+		IsEnabled:    true,
+		Roles:        []string{"admin"},
+		Source:       "local",
+	}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), mock.MatchedBy(func(user *storage.User) bool {
+		return user.MustChangePassword && user.IsEnabled && user.PasswordHash == string(hash)
+	})).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(record *storage.Audit) bool {
+		return record.Action == string(models.AuditActionUserPasswordReset) && record.Actor == "system"
+	})).Return(nil)
+
+	expired, err := m.provider.ExpireDefaultAdminPassword(context.Background())
+
+	require.NoError(t, err)
+	require.True(t, expired)
+}
+
+func Test_ExpireDefaultAdminPassword_Skipped_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	defaultHash, err := bcrypt.GenerateFromPassword([]byte("rtc"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	changedHash, err := bcrypt.GenerateFromPassword([]byte("changed-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		user *storage.User
+		err  error
+	}{
+		{name: "not found", err: storage.ErrNotFound},
+		{name: "changed password", user: &storage.User{ID: 1, Username: "admin", PasswordHash: string(changedHash), Source: "local"}},
+		{name: "already expired", user: &storage.User{ID: 1, Username: "admin", PasswordHash: string(defaultHash), Source: "local", MustChangePassword: true}},
+		{name: "external user", user: &storage.User{ID: 1, Username: "admin", Source: "ldap"}},
+	}
+
+	for _, tt := range tests {
+		m := newMk(t)
+
+		m.storage.EXPECT().User(mock.Anything, "admin").Return(tt.user, tt.err)
+
+		expired, err := m.provider.ExpireDefaultAdminPassword(context.Background())
+
+		require.NoError(t, err, tt.name)
+		require.False(t, expired, tt.name)
+	}
+}
@@ -42,8 +42,8 @@ func (p *Provider) CreateProject(ctx context.Context, name, description string)
 			return fmt.Errorf("storage.CreateProject: %w", err)
 		}
 
-		if err := p.storage.AddAuditRecord(ctx, auditRecord); err != nil {
-			return fmt.Errorf("storage.AddAuditRecord: %w", err)
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
 		}
 
 		return nil
@@ -79,8 +79,8 @@ func (p *Provider) UpdateProjectDescription(ctx context.Context, name, newDescri
 		if err := p.storage.UpdateProject(ctx, project); err != nil {
 			return fmt.Errorf("storage.UpdateProject: %w", err)
 		}
-		if err := p.storage.AddAuditRecord(ctx, auditRecord); err != nil {
-			return fmt.Errorf("storage.AddAuditRecord: %w", err)
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
 		}
 
 		return nil
@@ -115,8 +115,8 @@ func (p *Provider) DeleteProject(ctx context.Context, name string) error {
 			return fmt.Errorf("storage.DeleteProject: %w", err)
 		}
 
-		if err := p.storage.AddAuditRecord(ctx, auditRecord); err != nil {
-			return fmt.Errorf("storage.AddAuditRecord: %w", err)
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
 		}
 
 		return nil
@@ -1,17 +1,49 @@
 package provider
 
-import "github.com/DesSolo/rtc/internal/storage"
+import (
+	"time"
+
+	"github.com/DesSolo/rtc/internal/storage"
+)
 
 // Provider ...
 type Provider struct {
 	storage       storage.Storage
 	valuesStorage storage.ValuesStorage
+
+	passwordPolicy  PasswordPolicy
+	refreshTokenTTL time.Duration
+
+	loginBackend       LoginBackend
+	fallbackLocalLogin bool
+	loginLimiter       *loginLimiter
+
+	// twoFactor is nil if disabled
+	twoFactor *TwoFactorPolicy
+
+	reasonRequiredEnvs []string
+
+	// auditCheckpoints is nil if disabled
+	auditCheckpoints *AuditCheckpoints
+	// auditSinks is nil if disabled
+	auditSinks *AuditSinks
+	// auditRetention is nil if records are kept forever
+	auditRetention *AuditRetention
 }
 
 // NewProvider ...
-func NewProvider(storage storage.Storage, valuesStorage storage.ValuesStorage) *Provider {
-	return &Provider{
-		storage:       storage,
-		valuesStorage: valuesStorage,
+func NewProvider(storage storage.Storage, valuesStorage storage.ValuesStorage, options ...OptionFunc) *Provider {
+	p := &Provider{
+		storage:         storage,
+		valuesStorage:   valuesStorage,
+		passwordPolicy:  defaultPasswordPolicy,
+		refreshTokenTTL: defaultRefreshTokenTTL,
+		loginLimiter:    newLoginLimiter(defaultLoginThrottle),
+	}
+
+	for _, option := range options {
+		option(p)
 	}
+
+	return p
 }
@@ -55,8 +55,8 @@ func (p *Provider) DeleteRelease(ctx context.Context, projectName, envName, rele
 			return fmt.Errorf("storage.DeleteValues: %w", err)
 		}
 
-		if err := p.storage.AddAuditRecord(ctx, auditRecord); err != nil {
-			return fmt.Errorf("storage.AddAuditRecord: %w", err)
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
 		}
 
 		return nil
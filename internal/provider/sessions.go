@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const (
+	refreshTokenPrefix = "rtr_"
+
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// CreateSession issue refresh token for authenticated user
+func (p *Provider) CreateSession(ctx context.Context, username string) (string, error) {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", ErrNotFound
+		}
+
+		return "", fmt.Errorf("storage.User: %w", err)
+	}
+
+	family, err := generateSecret("")
+	if err != nil {
+		return "", fmt.Errorf("generateSecret: %w", err)
+	}
+
+	return p.issueRefreshToken(ctx, user.ID, family)
+}
+
+// RefreshSession rotate refresh token, returns user and new refresh token.
+// Reuse of rotated token revokes whole session.
+func (p *Provider) RefreshSession(ctx context.Context, refreshToken string) (*models.User, string, error) {
+	token, err := p.refreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if token.RevokedAt != nil {
+		slog.WarnContext(ctx, "refresh token reuse detected, revoking session", "username", token.Username)
+
+		if err := p.storage.RevokeRefreshTokenFamily(ctx, token.Family); err != nil {
+			return nil, "", fmt.Errorf("storage.RevokeRefreshTokenFamily: %w", err)
+		}
+
+		return nil, "", ErrNotFound
+	}
+
+	if token.ExpiresAt.Before(time.Now()) {
+		return nil, "", ErrNotFound
+	}
+
+	user, err := p.storage.User(ctx, token.Username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, "", ErrNotFound
+		}
+
+		return nil, "", fmt.Errorf("storage.User: %w", err)
+	}
+
+	// refresh tokens of revoked users are revoked in storage, so check only disabled
+	if !user.IsEnabled {
+		return nil, "", ErrNotFound
+	}
+
+	var newToken string
+
+	err = p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.RevokeRefreshToken(ctx, token.ID); err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				// rotated by concurrent request
+				return ErrNotFound
+			}
+
+			return fmt.Errorf("storage.RevokeRefreshToken: %w", err)
+		}
+
+		newToken, err = p.issueRefreshToken(ctx, user.ID, token.Family)
+
+		return err
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("storage.WithTransaction: %w", err)
+	}
+
+	return convertUserToModel(user), newToken, nil
+}
+
+// DeleteSession revoke refresh token and all its rotations
+func (p *Provider) DeleteSession(ctx context.Context, refreshToken string) error {
+	token, err := p.refreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	if err := p.storage.RevokeRefreshTokenFamily(ctx, token.Family); err != nil {
+		return fmt.Errorf("storage.RevokeRefreshTokenFamily: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked checks token issued at given time is still valid for user.
+// Unknown users are not checked (e.g. tokens issued by external systems).
+func (p *Provider) IsTokenRevoked(ctx context.Context, username string, issuedAt time.Time) (bool, error) {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("storage.User: %w", err)
+	}
+
+	return isUserTokenRevoked(user, issuedAt), nil
+}
+
+func (p *Provider) refreshToken(ctx context.Context, refreshToken string) (*storage.RefreshToken, error) {
+	if !strings.HasPrefix(refreshToken, refreshTokenPrefix) {
+		return nil, ErrNotFound
+	}
+
+	token, err := p.storage.RefreshTokenByHash(ctx, hashSecret(refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("storage.RefreshTokenByHash: %w", err)
+	}
+
+	return token, nil
+}
+
+func (p *Provider) issueRefreshToken(ctx context.Context, userID uint64, family string) (string, error) {
+	secret, err := generateSecret(refreshTokenPrefix)
+	if err != nil {
+		return "", fmt.Errorf("generateSecret: %w", err)
+	}
+
+	if err := p.storage.CreateRefreshToken(ctx, &storage.RefreshToken{
+		UserID:    userID,
+		Family:    family,
+		TokenHash: hashSecret(secret),
+		ExpiresAt: time.Now().Add(p.refreshTokenTTL),
+	}); err != nil {
+		return "", fmt.Errorf("storage.CreateRefreshToken: %w", err)
+	}
+
+	return secret, nil
+}
+
+// isUserTokenRevoked tokens of users with one-time password are not accepted until password is changed
+func isUserTokenRevoked(user *storage.User, issuedAt time.Time) bool {
+	if !user.IsEnabled || user.MustChangePassword {
+		return true
+	}
+
+	// jwt issued at has seconds precision
+	return user.TokensValidAfter != nil && issuedAt.Before(user.TokensValidAfter.Truncate(time.Second))
+}
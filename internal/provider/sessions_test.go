@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_RefreshSession_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().RefreshTokenByHash(mock.Anything, hashSecret("rtr_old")).Return(&storage.RefreshToken{
+		ID:        10,
+		UserID:    1,
+		Username:  "test",
+		Family:    "family",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{ID: 1, Username: "test", IsEnabled: true}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().RevokeRefreshToken(mock.Anything, uint64(10)).Return(nil)
+	m.storage.EXPECT().CreateRefreshToken(mock.Anything, mock.MatchedBy(func(token *storage.RefreshToken) bool {
+		return token.UserID == 1 && token.Family == "family"
+	})).Return(nil)
+
+	user, token, err := m.provider.RefreshSession(context.Background(), "rtr_old")
+
+	require.NoError(t, err)
+	require.Equal(t, "test", user.Username)
+	require.Regexp(t, "^rtr_[0-9a-f]{64}$", token)
+}
+
+func Test_RefreshSession_Reuse_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	revokedAt := time.Now()
+
+	m.storage.EXPECT().RefreshTokenByHash(mock.Anything, mock.Anything).Return(&storage.RefreshToken{
+		ID:        10,
+		Username:  "test",
+		Family:    "family",
+		ExpiresAt: time.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}, nil)
+	m.storage.EXPECT().RevokeRefreshTokenFamily(mock.Anything, "family").Return(nil)
+
+	_, _, err := m.provider.RefreshSession(context.Background(), "rtr_old")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_UpdateUser_Disable_RevokeTokens_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{ID: 1, Username: "test", IsEnabled: true}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().RevokeUserRefreshTokens(mock.Anything, uint64(1)).Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), mock.MatchedBy(func(user *storage.User) bool {
+		return !user.IsEnabled && user.TokensValidAfter != nil
+	})).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(record *storage.Audit) bool {
+		return record.Action == string(models.AuditActionUserUpdated)
+	})).Return(nil)
+
+	isEnabled := false
+
+	err := m.provider.UpdateUser(context.Background(), "test", &UpdateUserFields{IsEnabled: &isEnabled})
+
+	require.NoError(t, err)
+}
+
+func Test_IsTokenRevoked_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	validAfter := time.Now()
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{
+		ID:               1,
+		IsEnabled:        true,
+		TokensValidAfter: &validAfter,
+	}, nil)
+	m.storage.EXPECT().User(mock.Anything, "external").Return(nil, storage.ErrNotFound)
+
+	revoked, err := m.provider.IsTokenRevoked(context.Background(), "test", validAfter.Add(-time.Minute))
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = m.provider.IsTokenRevoked(context.Background(), "test", validAfter.Truncate(time.Second))
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	revoked, err = m.provider.IsTokenRevoked(context.Background(), "external", validAfter.Add(-time.Minute))
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func Test_IsTokenRevoked_MustChangePassword_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "test").Return(&storage.User{
+		ID:                 1,
+		IsEnabled:          true,
+		MustChangePassword: true,
+	}, nil)
+
+	revoked, err := m.provider.IsTokenRevoked(context.Background(), "test", time.Now())
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
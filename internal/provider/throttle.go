@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	defaultLoginThrottle = LoginThrottle{
+		MaxAttempts:      5,
+		MaxAttemptsPerIP: 20,
+		Window:           time.Minute * 15,
+		LockoutDuration:  time.Minute * 15,
+	}
+
+	// maxLoginAttemptsKeys limit of tracked usernames and addresses,
+	// least recently failed keys are forgotten first
+	maxLoginAttemptsKeys = 100_000
+
+	// ErrTooManyAttempts login is temporary locked
+	ErrTooManyAttempts = errors.New("too many attempts")
+)
+
+// LoginThrottle failed login attempts limits, zero fields are default
+type LoginThrottle struct {
+	// MaxAttempts failed attempts per username before lockout
+	MaxAttempts int
+	// MaxAttemptsPerIP failed attempts per client address before lockout
+	MaxAttemptsPerIP int
+	// Window failed attempts are counted within
+	Window time.Duration
+	// LockoutDuration how long login is locked
+	LockoutDuration time.Duration
+}
+
+// LockedError returned while username or client address is locked
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", ErrTooManyAttempts, e.RetryAfter.Round(time.Second))
+}
+
+// Unwrap ...
+func (e *LockedError) Unwrap() error {
+	return ErrTooManyAttempts
+}
+
+type loginAttempts struct {
+	key         string
+	failed      int
+	firstFailed time.Time
+	lockedUntil time.Time
+}
+
+// loginLimiter in-memory failed attempts counter, size is limited by LRU eviction
+type loginLimiter struct {
+	throttle LoginThrottle
+	now      func() time.Time
+	maxKeys  int
+
+	mu sync.Mutex
+	// attempts elements of order by key
+	attempts map[string]*list.Element
+	// order attempts from least to most recently failed
+	order     *list.List
+	lastPrune time.Time
+}
+
+func newLoginLimiter(throttle LoginThrottle) *loginLimiter {
+	return &loginLimiter{
+		throttle: throttle,
+		now:      time.Now,
+		maxKeys:  maxLoginAttemptsKeys,
+		attempts: make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// lockedFor returns remaining lockout duration of key (zero if not locked)
+func (l *loginLimiter) lockedFor(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.attempts[key]
+	if !ok {
+		return 0
+	}
+
+	return max(element.Value.(*loginAttempts).lockedUntil.Sub(l.now()), 0)
+}
+
+// fail count failed attempt and returns true if key became locked
+func (l *loginLimiter) fail(key string, maxAttempts int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.prune(now)
+
+	var attempts *loginAttempts
+
+	if element, ok := l.attempts[key]; ok {
+		attempts = element.Value.(*loginAttempts)
+		l.order.MoveToBack(element)
+	} else {
+		if l.order.Len() >= l.maxKeys {
+			l.remove(l.order.Front())
+		}
+
+		attempts = &loginAttempts{key: key, firstFailed: now}
+		l.attempts[key] = l.order.PushBack(attempts)
+	}
+
+	if now.Sub(attempts.firstFailed) > l.throttle.Window {
+		attempts.failed = 0
+		attempts.firstFailed = now
+	}
+
+	attempts.failed++
+
+	if attempts.failed < maxAttempts {
+		return false
+	}
+
+	attempts.failed = 0
+	attempts.firstFailed = now
+	attempts.lockedUntil = now.Add(l.throttle.LockoutDuration)
+
+	return true
+}
+
+// reset forget failed attempts of key
+func (l *loginLimiter) reset(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.attempts[key]; ok {
+		l.remove(element)
+	}
+}
+
+func (l *loginLimiter) remove(element *list.Element) {
+	delete(l.attempts, element.Value.(*loginAttempts).key)
+	l.order.Remove(element)
+}
+
+// prune remove outdated attempts not often than once per window
+func (l *loginLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.throttle.Window {
+		return
+	}
+
+	for element := l.order.Front(); element != nil; {
+		next := element.Next()
+
+		attempts := element.Value.(*loginAttempts)
+		if now.Sub(attempts.firstFailed) > l.throttle.Window && now.After(attempts.lockedUntil) {
+			l.remove(element)
+		}
+
+		element = next
+	}
+
+	l.lastPrune = now
+}
@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const (
+	recoveryCodesCount = 10
+	recoveryCodeLength = 5
+)
+
+// SecretCipher encrypt secrets stored in database
+type SecretCipher interface {
+	Encrypt(plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// TwoFactorPolicy TOTP options
+type TwoFactorPolicy struct {
+	// Issuer shown in authenticator app
+	Issuer string
+	Cipher SecretCipher
+	// RequiredRoles users with any of roles must enroll before login
+	RequiredRoles []string
+}
+
+func (t *TwoFactorPolicy) isRequired(user *models.User) bool {
+	if user.Source != models.UserSourceLocal {
+		return false
+	}
+
+	return slices.ContainsFunc(user.Roles, func(role string) bool {
+		return slices.Contains(t.RequiredRoles, role)
+	})
+}
+
+// checkTwoFactor second login step, returns ErrNotFound for invalid code
+func (p *Provider) checkTwoFactor(ctx context.Context, user *models.User, code string) error {
+	if !user.TwoFactorEnabled {
+		if p.twoFactor != nil && p.twoFactor.isRequired(user) {
+			return ErrTwoFactorEnrollmentRequired
+		}
+
+		return nil
+	}
+
+	if code == "" {
+		return ErrTwoFactorRequired
+	}
+
+	storageUser, err := p.twoFactorUser(ctx, user.Username)
+	if err != nil {
+		return err
+	}
+
+	valid, err := p.verifyTwoFactorCode(ctx, storageUser, code)
+	if err != nil {
+		return fmt.Errorf("p.verifyTwoFactorCode: %w", err)
+	}
+
+	if !valid {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// EnrollTOTP generate new secret for user, enrollment must be confirmed by ConfirmTOTP
+func (p *Provider) EnrollTOTP(ctx context.Context, username string) (*models.TOTPEnrollment, error) {
+	user, err := p.twoFactorUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", ErrAlreadyExists)
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("auth.GenerateTOTPSecret: %w", err)
+	}
+
+	encrypted, err := p.twoFactor.Cipher.Encrypt([]byte(secret))
+	if err != nil {
+		return nil, fmt.Errorf("cipher.Encrypt: %w", err)
+	}
+
+	user.TOTPSecret = encrypted
+	user.TOTPRecoveryCodes = []string{}
+
+	// enrollment is started by user itself, also before first login
+	auditRecord, err := encodeAuditRecordUser(models.AuditActionUserTOTPEnrolled, username, username)
+	if err != nil {
+		return nil, fmt.Errorf("encodeAuditRecordUser: %w", err)
+	}
+
+	if err := p.updateUserAudited(ctx, user, auditRecord); err != nil {
+		return nil, fmt.Errorf("p.updateUserAudited: %w", err)
+	}
+
+	return &models.TOTPEnrollment{
+		Secret: secret,
+		URI:    auth.TOTPURI(p.twoFactor.Issuer, username, secret),
+	}, nil
+}
+
+// ConfirmTOTP enable two-factor authentication and returns recovery codes.
+// Recovery codes are not stored and can't be received again.
+func (p *Provider) ConfirmTOTP(ctx context.Context, username, code string) ([]string, error) {
+	user, err := p.twoFactorUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, fmt.Errorf("%w: two-factor authentication is already enabled", ErrAlreadyExists)
+	}
+
+	if user.TOTPSecret == nil {
+		return nil, fmt.Errorf("%w: enrollment is not started", ErrNotValid)
+	}
+
+	secret, err := p.twoFactor.Cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return nil, fmt.Errorf("cipher.Decrypt: %w", err)
+	}
+
+	step, ok := auth.MatchTOTP(string(secret), code, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid code", ErrNotValid)
+	}
+
+	fresh, err := p.useTOTPStep(ctx, user, step)
+	if err != nil {
+		return nil, fmt.Errorf("p.useTOTPStep: %w", err)
+	}
+
+	if !fresh {
+		return nil, fmt.Errorf("%w: code is already used", ErrNotValid)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, fmt.Errorf("generateRecoveryCodes: %w", err)
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPRecoveryCodes = hashes
+
+	auditRecord, err := encodeAuditRecordUser(models.AuditActionUserTOTPEnabled, username, username)
+	if err != nil {
+		return nil, fmt.Errorf("encodeAuditRecordUser: %w", err)
+	}
+
+	if err := p.updateUserAudited(ctx, user, auditRecord); err != nil {
+		return nil, fmt.Errorf("p.updateUserAudited: %w", err)
+	}
+
+	return codes, nil
+}
+
+// DisableTOTP disable two-factor authentication by user, current code or recovery code is required
+func (p *Provider) DisableTOTP(ctx context.Context, username, code string) error {
+	user, err := p.twoFactorUser(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return ErrNotFound
+	}
+
+	valid, err := p.verifyTwoFactorCode(ctx, user, code)
+	if err != nil {
+		return fmt.Errorf("p.verifyTwoFactorCode: %w", err)
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: invalid code", ErrNotValid)
+	}
+
+	auditRecord, err := encodeAuditRecordUser(models.AuditActionUserTOTPDisabled, username, username)
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordUser: %w", err)
+	}
+
+	return p.resetTwoFactor(ctx, user, auditRecord)
+}
+
+// ResetTOTP reset enrollment by admin e.g. if user lost device and recovery codes
+func (p *Provider) ResetTOTP(ctx context.Context, username string) error {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return fmt.Errorf("storage.User: %w", err)
+	}
+
+	auditRecord, err := encodeAuditRecordUser(models.AuditActionUserTOTPReset, auth.UsernameFromContext(ctx), username)
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordUser: %w", err)
+	}
+
+	return p.resetTwoFactor(ctx, user, auditRecord)
+}
+
+func (p *Provider) resetTwoFactor(ctx context.Context, user *storage.User, auditRecord *storage.Audit) error {
+	user.TOTPSecret = nil
+	user.TOTPEnabled = false
+	user.TOTPRecoveryCodes = []string{}
+
+	if err := p.updateUserAudited(ctx, user, auditRecord); err != nil {
+		return fmt.Errorf("p.updateUserAudited: %w", err)
+	}
+
+	return nil
+}
+
+// twoFactorUser returns local user if two-factor authentication is configured
+func (p *Provider) twoFactorUser(ctx context.Context, username string) (*storage.User, error) {
+	if p.twoFactor == nil {
+		return nil, fmt.Errorf("%w: two-factor authentication is not configured", ErrNotValid)
+	}
+
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("storage.User: %w", err)
+	}
+
+	if user.Source != string(models.UserSourceLocal) {
+		return nil, fmt.Errorf("%w: two-factor authentication of %s user is managed externally", ErrNotValid, user.Source)
+	}
+
+	return user, nil
+}
+
+// verifyTwoFactorCode check TOTP code or recovery code, used recovery code is removed
+func (p *Provider) verifyTwoFactorCode(ctx context.Context, user *storage.User, code string) (bool, error) {
+	secret, err := p.twoFactor.Cipher.Decrypt(user.TOTPSecret)
+	if err != nil {
+		return false, fmt.Errorf("cipher.Decrypt: %w", err)
+	}
+
+	if step, ok := auth.MatchTOTP(string(secret), code, time.Now()); ok {
+		return p.useTOTPStep(ctx, user, step)
+	}
+
+	codeHash := hashSecret(normalizeRecoveryCode(code))
+
+	index := slices.Index(user.TOTPRecoveryCodes, codeHash)
+	if index == -1 {
+		return false, nil
+	}
+
+	// code is removed only if it is still unused, other columns are not overwritten by stale user
+	fresh, err := p.storage.UseTOTPRecoveryCode(ctx, user.ID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("storage.UseTOTPRecoveryCode: %w", err)
+	}
+
+	if !fresh {
+		slog.WarnContext(ctx, "recovery code reuse rejected", "username", user.Username)
+		return false, nil
+	}
+
+	user.TOTPRecoveryCodes = slices.Delete(user.TOTPRecoveryCodes, index, index+1)
+
+	return true, nil
+}
+
+// useTOTPStep remember step of valid TOTP code, returns false if code is already used
+func (p *Provider) useTOTPStep(ctx context.Context, user *storage.User, step int64) (bool, error) {
+	fresh, err := p.storage.UseTOTPStep(ctx, user.ID, step)
+	if err != nil {
+		return false, fmt.Errorf("storage.UseTOTPStep: %w", err)
+	}
+
+	if !fresh {
+		slog.WarnContext(ctx, "TOTP code reuse rejected", "username", user.Username)
+	}
+
+	return fresh, nil
+}
+
+// generateRecoveryCodes returns codes like 1a2b3-c4d5e and its hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	for range recoveryCodesCount {
+		buf := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("rand.Read: %w", err)
+		}
+
+		code := hex.EncodeToString(buf)
+
+		codes = append(codes, code[:recoveryCodeLength]+"-"+code[recoveryCodeLength:])
+		hashes = append(hashes, hashSecret(code))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
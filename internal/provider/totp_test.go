@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func newTwoFactorMk(t *testing.T, requiredRoles ...string) (*mk, *auth.AESCipher) {
+	t.Helper()
+
+	cipher, err := auth.NewAESCipher(make([]byte, 32))
+	require.NoError(t, err)
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithTwoFactor(TwoFactorPolicy{
+		Issuer:        "RTC",
+		Cipher:        cipher,
+		RequiredRoles: requiredRoles,
+	}))
+
+	return m, cipher
+}
+
+func newTwoFactorUser(t *testing.T, cipher *auth.AESCipher, secret string, recoveryCodes ...string) *storage.User {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	encrypted, err := cipher.Encrypt([]byte(secret))
+	require.NoError(t, err)
+
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, hashSecret(normalizeRecoveryCode(code)))
+	}
+
+	return &storage.User{
+		ID:                1,
+		Username:          "admin",
+		PasswordHash:      string(hash),
+		IsEnabled:         true,
+		Roles:             []string{"admin"},
+		Source:            "local",
+		TOTPSecret:        encrypted,
+		TOTPEnabled:       true,
+		TOTPRecoveryCodes: hashes,
+	}
+}
+
+func Test_EnrollTOTP_ConfirmTOTP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m, _ := newTwoFactorMk(t)
+
+	user := &storage.User{ID: 1, Username: "admin", IsEnabled: true, Source: "local"}
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), user).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserTOTPEnrolled)).Return(nil).Once()
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction(models.AuditActionUserTOTPEnabled)).Return(nil).Once()
+	m.storage.EXPECT().UseTOTPStep(mock.Anything, uint64(1), mock.Anything).Return(true, nil)
+
+	enrollment, err := m.provider.EnrollTOTP(context.Background(), "admin")
+	require.NoError(t, err)
+	require.Contains(t, enrollment.URI, "otpauth://totp/RTC:admin?")
+	require.NotEqual(t, enrollment.Secret, string(user.TOTPSecret))
+	require.False(t, user.TOTPEnabled)
+
+	code, err := auth.TOTPCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	recoveryCodes, err := m.provider.ConfirmTOTP(context.Background(), "admin", code)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, recoveryCodesCount)
+	require.True(t, user.TOTPEnabled)
+	require.Len(t, user.TOTPRecoveryCodes, recoveryCodesCount)
+}
+
+func Test_ConfirmTOTP_InvalidCode_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	user := newTwoFactorUser(t, cipher, secret)
+	user.TOTPEnabled = false
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+
+	_, err = m.provider.ConfirmTOTP(context.Background(), "admin", "000000")
+	require.ErrorIs(t, err, ErrNotValid)
+}
+
+func Test_DisableTOTP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	user := newTwoFactorUser(t, cipher, secret)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+	m.storage.EXPECT().UseTOTPStep(mock.Anything, uint64(1), mock.Anything).Return(true, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), user).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionUserTOTPDisabled) && audit.Actor == "admin"
+	})).Return(nil)
+
+	code, err := auth.TOTPCode(secret, time.Now())
+	require.NoError(t, err)
+
+	err = m.provider.DisableTOTP(context.Background(), "admin", code)
+	require.NoError(t, err)
+	require.False(t, user.TOTPEnabled)
+	require.Nil(t, user.TOTPSecret)
+}
+
+func Test_ResetTOTP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	user := newTwoFactorUser(t, cipher, secret)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), user).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionUserTOTPReset) && audit.Actor == "root"
+	})).Return(nil)
+
+	ctx := auth.ToContext(context.Background(), &auth.Payload{Username: "root"})
+
+	err = m.provider.ResetTOTP(ctx, "admin")
+	require.NoError(t, err)
+	require.False(t, user.TOTPEnabled)
+}
+
+func Test_Login_TwoFactor_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(newTwoFactorUser(t, cipher, secret), nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.Anything).Return(nil)
+	m.storage.EXPECT().UseTOTPStep(mock.Anything, uint64(1), now.Unix()/30).Return(true, nil).Once()
+
+	_, err = m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret"})
+	require.ErrorIs(t, err, ErrTwoFactorRequired)
+
+	code, err := auth.TOTPCode(secret, now)
+	require.NoError(t, err)
+
+	got, err := m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", OTP: code})
+	require.NoError(t, err)
+	require.True(t, got.TwoFactorEnabled)
+}
+
+func Test_Login_TwoFactor_ReplayedCode_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(newTwoFactorUser(t, cipher, secret), nil)
+	m.storage.EXPECT().UseTOTPStep(mock.Anything, uint64(1), mock.Anything).Return(false, nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction("user_login_failed")).Return(nil).Once()
+
+	code, err := auth.TOTPCode(secret, time.Now())
+	require.NoError(t, err)
+
+	_, err = m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", OTP: code})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Login_TwoFactor_RecoveryCode_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	user := newTwoFactorUser(t, cipher, secret, "1a2b3-c4d5e", "f6a7b-8c9d0")
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+	m.storage.EXPECT().UseTOTPRecoveryCode(mock.Anything, uint64(1), hashSecret("1a2b3c4d5e")).Return(true, nil).Once()
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction("user_login")).Return(nil).Once()
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction("user_login_failed")).Return(nil).Once()
+
+	_, err = m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", OTP: "1A2B3C4D5E"})
+	require.NoError(t, err)
+	require.Len(t, user.TOTPRecoveryCodes, 1)
+
+	// recovery code can be used once
+	_, err = m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", OTP: "1a2b3-c4d5e"})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Login_TwoFactor_RecoveryCodeUsedConcurrently_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t)
+
+	secret, err := auth.GenerateTOTPSecret()
+	require.NoError(t, err)
+
+	user := newTwoFactorUser(t, cipher, secret, "1a2b3-c4d5e")
+
+	// code is in stale user, but already removed by other login
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+	m.storage.EXPECT().UseTOTPRecoveryCode(mock.Anything, uint64(1), hashSecret("1a2b3c4d5e")).Return(false, nil).Once()
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, auditAction("user_login_failed")).Return(nil).Once()
+
+	_, err = m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret", OTP: "1a2b3-c4d5e"})
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_Login_TwoFactorEnrollmentRequired_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m, cipher := newTwoFactorMk(t, "admin")
+
+	user := newTwoFactorUser(t, cipher, "")
+	user.TOTPSecret = nil
+	user.TOTPEnabled = false
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(user, nil)
+
+	_, err := m.provider.Login(context.Background(), LoginAttempt{Username: "admin", Password: "secret"})
+	require.ErrorIs(t, err, ErrTwoFactorEnrollmentRequired)
+}
@@ -5,15 +5,48 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/DesSolo/rtc/internal/auth"
 	"github.com/DesSolo/rtc/internal/models"
 	"github.com/DesSolo/rtc/internal/storage"
 )
 
-// AuthenticateUser ...
+// AuthenticateUser check password by external login backend (if configured) and local users
 func (p *Provider) AuthenticateUser(ctx context.Context, username, password string) (*models.User, error) {
+	if p.loginBackend == nil {
+		return p.authenticateLocalUser(ctx, username, password)
+	}
+
+	external, err := p.loginBackend.AuthenticatePassword(ctx, username, password)
+	switch {
+	case err == nil:
+		// local user with same name can still login with local password
+		user, provisionErr := p.ProvisionUser(ctx, external)
+		if !errors.Is(provisionErr, ErrAlreadyExists) {
+			return user, provisionErr
+		}
+	case errors.Is(err, ErrNotFound):
+		slog.DebugContext(ctx, "external login failed", "username", username)
+	default:
+		if !p.fallbackLocalLogin {
+			return nil, fmt.Errorf("loginBackend.AuthenticatePassword: %w", err)
+		}
+
+		slog.WarnContext(ctx, "loginBackend.AuthenticatePassword", "err", err)
+	}
+
+	if !p.fallbackLocalLogin {
+		return nil, ErrNotFound
+	}
+
+	return p.authenticateLocalUser(ctx, username, password)
+}
+
+func (p *Provider) authenticateLocalUser(ctx context.Context, username, password string) (*models.User, error) {
 	user, err := p.storage.User(ctx, username)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
@@ -28,6 +61,11 @@ func (p *Provider) AuthenticateUser(ctx context.Context, username, password stri
 		return nil, ErrNotFound
 	}
 
+	if user.Source != string(models.UserSourceLocal) {
+		slog.DebugContext(ctx, "user is not local", "username", username, "source", user.Source)
+		return nil, ErrNotFound
+	}
+
 	if !isValidPassword(user.PasswordHash, password) {
 		slog.DebugContext(ctx, "invalid password", "username", username)
 		return nil, ErrNotFound
@@ -44,17 +82,133 @@ func isValidPassword(hash, password string) bool {
 	return true
 }
 
-//// ChangePassword ...
-//func (p *Provider) ChangePassword(ctx context.Context, username, oldPassword, newPassword string) error {
-//	// TODO: implement
-//	return nil
-//}
-//
-//// ResetPassword ...
-//func (p *Provider) ResetPassword(ctx context.Context, username string) error {
-//	// TODO: implement
-//	return nil
-//}
+// ChangePassword change password of local user, old password is required
+func (p *Provider) ChangePassword(ctx context.Context, username, oldPassword, newPassword string) error {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return ErrNotFound
+		}
+
+		return fmt.Errorf("storage.User: %w", err)
+	}
+
+	if !user.IsEnabled || !isValidPassword(user.PasswordHash, oldPassword) {
+		slog.DebugContext(ctx, "invalid old password", "username", username)
+		return ErrNotFound
+	}
+
+	if oldPassword == newPassword {
+		return fmt.Errorf("%w: new password must differ from old password", ErrNotValid)
+	}
+
+	if err := p.passwordPolicy.Validate(newPassword); err != nil {
+		return fmt.Errorf("passwordPolicy.Validate: %w", err)
+	}
+
+	passwordHash, err := p.passwordHash(newPassword)
+	if err != nil {
+		return fmt.Errorf("p.passwordHash: %w", err)
+	}
+
+	now := time.Now()
+
+	user.PasswordHash = passwordHash
+	user.MustChangePassword = false
+	user.PasswordChangedAt = &now
+
+	// password is changed by user itself, also on login with one-time password
+	auditRecord, err := encodeAuditRecordUser(models.AuditActionUserPasswordChanged, username, username)
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordUser: %w", err)
+	}
+
+	// sessions of old password are revoked, session issued on login after change stays valid
+	if err := p.updateUserRevokeTokens(ctx, user, auditRecord); err != nil {
+		return fmt.Errorf("p.updateUserRevokeTokens: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword set one-time password for user, it must be changed on next login
+func (p *Provider) ResetPassword(ctx context.Context, username string) (string, error) {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return "", ErrNotFound
+		}
+
+		return "", fmt.Errorf("storage.User: %w", err)
+	}
+
+	if user.Source != string(models.UserSourceLocal) {
+		return "", fmt.Errorf("%w: password of %s user is managed externally", ErrNotValid, user.Source)
+	}
+
+	password, err := p.passwordPolicy.generatePassword()
+	if err != nil {
+		return "", fmt.Errorf("generatePassword: %w", err)
+	}
+
+	passwordHash, err := p.passwordHash(password)
+	if err != nil {
+		return "", fmt.Errorf("p.passwordHash: %w", err)
+	}
+
+	now := time.Now()
+
+	user.PasswordHash = passwordHash
+	user.MustChangePassword = true
+	user.PasswordChangedAt = &now
+
+	auditRecord, err := encodeAuditRecordUserPasswordReset(auth.UsernameFromContext(ctx), username)
+	if err != nil {
+		return "", fmt.Errorf("encodeAuditRecordUserPasswordReset: %w", err)
+	}
+
+	if err := p.updateUserRevokeTokens(ctx, user, auditRecord); err != nil {
+		return "", fmt.Errorf("p.updateUserRevokeTokens: %w", err)
+	}
+
+	return password, nil
+}
+
+// default admin seeded by migrations of older releases
+const (
+	defaultAdminUsername = "admin"
+	defaultAdminPassword = "rtc"
+)
+
+// ExpireDefaultAdminPassword require seeded admin with publicly known password to change it on next login,
+// true is returned if password is expired
+func (p *Provider) ExpireDefaultAdminPassword(ctx context.Context) (bool, error) {
+	user, err := p.storage.User(ctx, defaultAdminUsername)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("storage.User: %w", err)
+	}
+
+	if user.Source != string(models.UserSourceLocal) || user.MustChangePassword || !isValidPassword(user.PasswordHash, defaultAdminPassword) {
+		return false, nil
+	}
+
+	user.MustChangePassword = true
+
+	auditRecord, err := encodeAuditRecordUserPasswordReset(auditActorSystem, user.Username)
+	if err != nil {
+		return false, fmt.Errorf("encodeAuditRecordUserPasswordReset: %w", err)
+	}
+
+	if err := p.updateUserAudited(ctx, user, auditRecord); err != nil {
+		return false, fmt.Errorf("p.updateUserAudited: %w", err)
+	}
+
+	return true, nil
+}
 
 // ListUsers ...
 func (p *Provider) ListUsers(ctx context.Context, q string, limit, offset uint64) ([]*models.User, uint64, error) {
@@ -66,19 +220,60 @@ func (p *Provider) ListUsers(ctx context.Context, q string, limit, offset uint64
 	return convertUsersToModels(users), total, nil
 }
 
+// User ...
+func (p *Provider) User(ctx context.Context, username string) (*models.User, error) {
+	user, err := p.storage.User(ctx, username)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, fmt.Errorf("storage.User: %w", err)
+	}
+
+	return convertUserToModel(user), nil
+}
+
 // CreateUser ...
 func (p *Provider) CreateUser(ctx context.Context, user *models.User, password string) error {
+	if err := p.passwordPolicy.Validate(password); err != nil {
+		return fmt.Errorf("passwordPolicy.Validate: %w", err)
+	}
+
+	if user.Source == "" {
+		user.Source = models.UserSourceLocal
+	}
+
 	passwordHash, err := p.passwordHash(password)
 	if err != nil {
 		return fmt.Errorf("p.passwordHash: %w", err)
 	}
 
-	if err := p.storage.CreateUser(ctx, convertModelToUser(user, passwordHash)); err != nil {
-		if errors.Is(err, storage.ErrAlreadyExists) {
-			return ErrAlreadyExists
+	storageUser := convertModelToUser(user, passwordHash)
+
+	auditRecord, err := encodeAuditRecordUserCreated(auth.UsernameFromContext(ctx), storageUser)
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordUserCreated: %w", err)
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.CreateUser(ctx, storageUser); err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return ErrAlreadyExists
+			}
+
+			return fmt.Errorf("storage.CreateUser: %w", err)
 		}
 
-		return fmt.Errorf("storage.CreateUser: %w", err)
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return txErr // nolint:wrapcheck
 	}
 
 	return nil
@@ -100,25 +295,221 @@ type UpdateUserFields struct {
 }
 
 // UpdateUser ...
+// Disabling user or changing its roles revokes all issued tokens.
 func (p *Provider) UpdateUser(ctx context.Context, username string, fields *UpdateUserFields) error {
 	user, err := p.storage.User(ctx, username)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return ErrNotFound
 		}
+
+		return fmt.Errorf("storage.User: %w", err)
+	}
+
+	record := &auditRecordUserUpdated{
+		Username:     user.Username,
+		OldIsEnabled: user.IsEnabled,
+		NewIsEnabled: user.IsEnabled,
+		OldRoles:     user.Roles,
+		NewRoles:     user.Roles,
 	}
 
 	if fields.IsEnabled != nil {
+		record.TokensRevoked = user.IsEnabled && !*fields.IsEnabled
+		record.NewIsEnabled = *fields.IsEnabled
 		user.IsEnabled = *fields.IsEnabled
 	}
 
 	if fields.Roles != nil {
+		record.TokensRevoked = record.TokensRevoked || !slices.Equal(user.Roles, fields.Roles)
+		record.NewRoles = fields.Roles
 		user.Roles = fields.Roles
 	}
 
-	if err := p.storage.UpdateUser(ctx, user.ID, user); err != nil {
-		return fmt.Errorf("p.UpdateUser: %w", err)
+	auditRecord, err := encodeAuditRecordUserUpdated(auth.UsernameFromContext(ctx), record)
+	if err != nil {
+		return fmt.Errorf("encodeAuditRecordUserUpdated: %w", err)
+	}
+
+	if record.TokensRevoked {
+		if err := p.updateUserRevokeTokens(ctx, user, auditRecord); err != nil {
+			return fmt.Errorf("p.updateUserRevokeTokens: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := p.updateUserAudited(ctx, user, auditRecord); err != nil {
+		return fmt.Errorf("p.updateUserAudited: %w", err)
+	}
+
+	return nil
+}
+
+// updateUserAudited update user and write audit record in same transaction
+func (p *Provider) updateUserAudited(ctx context.Context, user *storage.User, auditRecord *storage.Audit) error {
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.UpdateUser(ctx, user.ID, user); err != nil {
+			return fmt.Errorf("storage.UpdateUser: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return fmt.Errorf("storage.WithTransaction: %w", txErr)
+	}
+
+	return nil
+}
+
+// updateUserRevokeTokens update user and invalidate all its issued tokens,
+// audit record is written in same transaction
+func (p *Provider) updateUserRevokeTokens(ctx context.Context, user *storage.User, auditRecord *storage.Audit) error {
+	now := time.Now()
+	user.TokensValidAfter = &now
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+			return fmt.Errorf("storage.RevokeUserRefreshTokens: %w", err)
+		}
+
+		if err := p.storage.UpdateUser(ctx, user.ID, user); err != nil {
+			return fmt.Errorf("storage.UpdateUser: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return fmt.Errorf("storage.WithTransaction: %w", txErr)
 	}
 
 	return nil
 }
+
+// ProvisionUser create external user on first login and sync its roles on next logins.
+// Disabled users are not allowed to login.
+func (p *Provider) ProvisionUser(ctx context.Context, external *models.User) (*models.User, error) {
+	user, err := p.storage.User(ctx, external.Username)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("storage.User: %w", err)
+		}
+
+		return p.createExternalUser(ctx, external)
+	}
+
+	if user.Source != string(external.Source) {
+		slog.WarnContext(ctx, "user already exists with other source", "username", user.Username, "source", user.Source)
+		return nil, ErrAlreadyExists
+	}
+
+	if !user.IsEnabled {
+		slog.DebugContext(ctx, "user is disabled", "username", user.Username)
+		return nil, ErrNotFound
+	}
+
+	if !slices.Equal(user.Roles, external.Roles) {
+		// roles are synced on login of user itself
+		auditRecord, err := encodeAuditRecordUserUpdated(user.Username, &auditRecordUserUpdated{
+			Username:      user.Username,
+			OldIsEnabled:  user.IsEnabled,
+			NewIsEnabled:  user.IsEnabled,
+			OldRoles:      user.Roles,
+			NewRoles:      external.Roles,
+			TokensRevoked: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("encodeAuditRecordUserUpdated: %w", err)
+		}
+
+		user.Roles = external.Roles
+
+		if err := p.updateUserRevokeTokens(ctx, user, auditRecord); err != nil {
+			return nil, fmt.Errorf("p.updateUserRevokeTokens: %w", err)
+		}
+	}
+
+	return convertUserToModel(user), nil
+}
+
+// EnsureExternalUser create external user on first use of its bearer token,
+// roles are not synced because they are taken from token on every request.
+// Users of other source with same name and disabled users are rejected.
+func (p *Provider) EnsureExternalUser(ctx context.Context, external *models.User) (*models.User, error) {
+	user, err := p.storage.User(ctx, external.Username)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("storage.User: %w", err)
+		}
+
+		return p.createExternalUser(ctx, external)
+	}
+
+	if user.Source != string(external.Source) {
+		slog.WarnContext(ctx, "user already exists with other source", "username", user.Username, "source", user.Source)
+		return nil, ErrAlreadyExists
+	}
+
+	if !user.IsEnabled {
+		slog.DebugContext(ctx, "user is disabled", "username", user.Username)
+		return nil, ErrNotFound
+	}
+
+	return convertUserToModel(user), nil
+}
+
+func (p *Provider) createExternalUser(ctx context.Context, external *models.User) (*models.User, error) {
+	// external users never login with password, so it is random and unknown
+	password, err := p.passwordPolicy.generatePassword()
+	if err != nil {
+		return nil, fmt.Errorf("generatePassword: %w", err)
+	}
+
+	passwordHash, err := p.passwordHash(password)
+	if err != nil {
+		return nil, fmt.Errorf("p.passwordHash: %w", err)
+	}
+
+	user := convertModelToUser(external, passwordHash)
+	user.IsEnabled = true
+
+	// nobody is logged in yet, user provisions itself
+	auditRecord, err := encodeAuditRecordUserCreated(user.Username, user)
+	if err != nil {
+		return nil, fmt.Errorf("encodeAuditRecordUserCreated: %w", err)
+	}
+
+	txErr := p.storage.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := p.storage.CreateUser(ctx, user); err != nil {
+			if errors.Is(err, storage.ErrAlreadyExists) {
+				return ErrAlreadyExists
+			}
+
+			return fmt.Errorf("storage.CreateUser: %w", err)
+		}
+
+		if err := p.addAuditRecord(ctx, auditRecord); err != nil {
+			return fmt.Errorf("addAuditRecord: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr // nolint:wrapcheck
+	}
+
+	slog.InfoContext(ctx, "user provisioned", "username", user.Username, "source", user.Source)
+
+	return convertUserToModel(user), nil
+}
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+func Test_ProvisionUser_NewUser_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "sso_user").Return(nil, storage.ErrNotFound)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().CreateUser(mock.Anything, mock.MatchedBy(func(user *storage.User) bool {
+		return user.Username == "sso_user" && user.Source == "oidc" && user.IsEnabled && user.PasswordHash != ""
+	})).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionUserCreated) && audit.Actor == "sso_user"
+	})).Return(nil)
+
+	got, err := m.provider.ProvisionUser(context.Background(), &models.User{
+		Username: "sso_user",
+		Roles:    []string{"admin"},
+		Source:   models.UserSourceOIDC,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, &models.User{
+		Username:  "sso_user",
+		IsEnabled: true,
+		Roles:     []string{"admin"},
+		Source:    models.UserSourceOIDC,
+	}, got)
+}
+
+func Test_ProvisionUser_SyncRoles_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "sso_user").Return(&storage.User{
+		ID:        1,
+		Username:  "sso_user",
+		IsEnabled: true,
+		Roles:     []string{"viewer"},
+		Source:    "oidc",
+	}, nil)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().RevokeUserRefreshTokens(mock.Anything, uint64(1)).Return(nil)
+	m.storage.EXPECT().UpdateUser(mock.Anything, uint64(1), mock.MatchedBy(func(user *storage.User) bool {
+		return len(user.Roles) == 1 && user.Roles[0] == "admin" && user.TokensValidAfter != nil
+	})).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionUserUpdated) &&
+			audit.Actor == "sso_user" &&
+			strings.Contains(string(audit.Payload), `"old_roles":["viewer"]`) &&
+			strings.Contains(string(audit.Payload), `"new_roles":["admin"]`)
+	})).Return(nil)
+
+	got, err := m.provider.ProvisionUser(context.Background(), &models.User{
+		Username: "sso_user",
+		Roles:    []string{"admin"},
+		Source:   models.UserSourceOIDC,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"admin"}, got.Roles)
+}
+
+func Test_ProvisionUser_LocalUserExists_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(&storage.User{
+		ID:        1,
+		Username:  "admin",
+		IsEnabled: true,
+		Source:    "local",
+	}, nil)
+
+	_, err := m.provider.ProvisionUser(context.Background(), &models.User{
+		Username: "admin",
+		Source:   models.UserSourceOIDC,
+	})
+
+	require.ErrorIs(t, err, ErrAlreadyExists)
+}
+
+func Test_EnsureExternalUser_NewUser_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "sso_user").Return(nil, storage.ErrNotFound)
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().CreateUser(mock.Anything, mock.MatchedBy(func(user *storage.User) bool {
+		return user.Username == "sso_user" && user.Source == "oidc" && user.IsEnabled
+	})).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(audit *storage.Audit) bool {
+		return audit.Action == string(models.AuditActionUserCreated) && audit.Actor == "sso_user"
+	})).Return(nil)
+
+	got, err := m.provider.EnsureExternalUser(context.Background(), &models.User{
+		Username: "sso_user",
+		Roles:    []string{"viewer"},
+		Source:   models.UserSourceOIDC,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "sso_user", got.Username)
+}
+
+func Test_EnsureExternalUser_ExistingUser_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	// roles are not synced, so user is not updated
+	m.storage.EXPECT().User(mock.Anything, "sso_user").Return(&storage.User{
+		ID:        1,
+		Username:  "sso_user",
+		IsEnabled: true,
+		Roles:     []string{"viewer"},
+		Source:    "oidc",
+	}, nil)
+
+	got, err := m.provider.EnsureExternalUser(context.Background(), &models.User{
+		Username: "sso_user",
+		Roles:    []string{"admin"},
+		Source:   models.UserSourceOIDC,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"viewer"}, got.Roles)
+}
+
+func Test_EnsureExternalUser_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		user *storage.User
+		want error
+	}{
+		{name: "local user", user: &storage.User{ID: 1, Username: "alice", IsEnabled: true, Source: "local"}, want: ErrAlreadyExists},
+		{name: "ldap user", user: &storage.User{ID: 1, Username: "alice", IsEnabled: true, Source: "ldap"}, want: ErrAlreadyExists},
+		{name: "disabled user", user: &storage.User{ID: 1, Username: "alice", Source: "oidc"}, want: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		m := newMk(t)
+
+		m.storage.EXPECT().User(mock.Anything, "alice").Return(tt.user, nil)
+
+		_, err := m.provider.EnsureExternalUser(context.Background(), &models.User{
+			Username: "alice",
+			Source:   models.UserSourceOIDC,
+		})
+
+		require.ErrorIs(t, err, tt.want, tt.name)
+	}
+}
+
+func Test_ProvisionUser_Disabled_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().User(mock.Anything, "sso_user").Return(&storage.User{
+		ID:       1,
+		Username: "sso_user",
+		Source:   "oidc",
+	}, nil)
+
+	_, err := m.provider.ProvisionUser(context.Background(), &models.User{
+		Username: "sso_user",
+		Source:   models.UserSourceOIDC,
+	})
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func newLoginBackendMk(t *testing.T, backend LoginBackendFunc, fallbackLocal bool) *mk {
+	t.Helper()
+
+	m := newMk(t)
+	m.provider = NewProvider(m.storage, m.valuesStorage, WithLoginBackend(backend, fallbackLocal))
+
+	return m
+}
+
+func Test_AuthenticateUser_LoginBackend_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newLoginBackendMk(t, func(_ context.Context, username, _ string) (*models.User, error) {
+		return &models.User{Username: username, Roles: []string{"admin"}, Source: models.UserSourceLDAP}, nil
+	}, false)
+
+	m.storage.EXPECT().User(mock.Anything, "john").Return(&storage.User{
+		ID:        1,
+		Username:  "john",
+		IsEnabled: true,
+		Roles:     []string{"admin"},
+		Source:    "ldap",
+	}, nil)
+
+	got, err := m.provider.AuthenticateUser(context.Background(), "john", "secret")
+
+	require.NoError(t, err)
+	require.Equal(t, models.UserSourceLDAP, got.Source)
+}
+
+func Test_AuthenticateUser_LoginBackend_FallbackLocal_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newLoginBackendMk(t, func(_ context.Context, _, _ string) (*models.User, error) {
+		return nil, errors.New("connection refused")
+	}, true)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	m.storage.EXPECT().User(mock.Anything, "admin").Return(&storage.User{
+		ID:           1,
+		Username:     "admin",
+		PasswordHash: string(hash),
+		IsEnabled:    true,
+		Source:       "local",
+	}, nil)
+
+	got, err := m.provider.AuthenticateUser(context.Background(), "admin", "secret")
+
+	require.NoError(t, err)
+	require.Equal(t, "admin", got.Username)
+}
+
+func Test_AuthenticateUser_LoginBackend_NoFallback_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newLoginBackendMk(t, func(_ context.Context, _, _ string) (*models.User, error) {
+		return nil, ErrNotFound
+	}, false)
+
+	_, err := m.provider.AuthenticateUser(context.Background(), "admin", "secret")
+
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_CreateUser_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, f func(ctx context.Context) error) {
+			require.NoError(t, f(ctx))
+		}).
+		Return(nil)
+	m.storage.EXPECT().CreateUser(mock.Anything, mock.MatchedBy(func(user *storage.User) bool {
+		return user.Username == "new_user" && user.Source == string(models.UserSourceLocal)
+	})).Return(nil)
+	m.storage.EXPECT().AddAuditRecord(mock.Anything, mock.MatchedBy(func(record *storage.Audit) bool {
+		return record.Action == string(models.AuditActionUserCreated)
+	})).Return(nil)
+
+	err := m.provider.CreateUser(context.Background(), &models.User{
+		Username:  "new_user",
+		IsEnabled: true,
+		Roles:     []string{"viewer"},
+	}, "Str0ng-Passw0rd!")
+
+	require.NoError(t, err)
+}
+
+func Test_CreateUser_AlreadyExists_ExpectErr(t *testing.T) {
+	t.Parallel()
+
+	m := newMk(t)
+
+	m.storage.EXPECT().WithTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, f func(ctx context.Context) error) error {
+			return f(ctx)
+		})
+	m.storage.EXPECT().CreateUser(mock.Anything, mock.Anything).Return(storage.ErrAlreadyExists)
+
+	err := m.provider.CreateUser(context.Background(), &models.User{Username: "new_user"}, "Str0ng-Passw0rd!")
+
+	require.ErrorIs(t, err, ErrAlreadyExists)
+}
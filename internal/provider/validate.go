@@ -3,7 +3,9 @@ package provider
 import (
 	"errors"
 	"fmt"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/DesSolo/rtc/internal/models"
 )
@@ -83,6 +85,26 @@ func validateNewValue(config *models.Config, newValue []byte) error {
 	return nil
 }
 
+func (p *Provider) validateChangeReason(envName string, reason models.ChangeReason) error {
+	if strings.TrimSpace(reason.Reason) != "" {
+		return nil
+	}
+
+	for _, pattern := range p.reasonRequiredEnvs {
+		ok, err := path.Match(pattern, envName)
+		if err != nil {
+			// patterns are validated on config load, fail closed anyway
+			return fmt.Errorf("path.Match: %w", err)
+		}
+
+		if ok {
+			return fmt.Errorf("%w: reason is required for changes in environment %s", ErrNotValid, envName)
+		}
+	}
+
+	return nil
+}
+
 func validateUpsert(configs []*models.Config) error {
 	// etcd maximum items in one transaction
 	// TODO: add chunked wrapper
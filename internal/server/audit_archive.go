@@ -0,0 +1,54 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/DesSolo/rtc/internal/auditarchive"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+// auditArchiveMaxBodySize of compressed archive
+const auditArchiveMaxBodySize = 256 * 1024 * 1024
+
+// handleImportAudits body is gzip compressed JSON lines archive
+func (s *Server) handleImportAudits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	reader, err := auditarchive.NewReader(http.MaxBytesReader(w, r.Body, auditArchiveMaxBodySize))
+	if err != nil {
+		respondError(ctx, w, archiveErrorStatus(err), err.Error())
+		return
+	}
+	defer reader.Close()
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		respondError(ctx, w, archiveErrorStatus(err), err.Error())
+		return
+	}
+
+	imported, err := s.provider.ImportAudits(ctx, records, r.URL.Query().Get("file"))
+	if err != nil {
+		if errors.Is(err, provider.ErrNotValid) {
+			respondError(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.ImportAudits", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, convertModelToAuditArchivePayload(imported))
+}
+
+func archiveErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, auditarchive.ErrTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
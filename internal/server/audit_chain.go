@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+)
+
+type auditChainProblem struct {
+	ID           uint64 `json:"id,omitempty"`
+	CheckpointID uint64 `json:"checkpoint_id,omitempty"`
+	Kind         string `json:"kind"`
+	Message      string `json:"message"`
+}
+
+type auditCheckpoint struct {
+	ID     uint64 `json:"id"`
+	LastID uint64 `json:"last_id"`
+	// Hash hex encoded
+	Hash string `json:"hash"`
+	// Signature base64 encoded ed25519 signature
+	Signature string    `json:"signature"`
+	Ts        time.Time `json:"ts"`
+}
+
+type verifyAuditsResponse struct {
+	Valid              bool                `json:"valid"`
+	Checked            uint64              `json:"checked"`
+	Legacy             uint64              `json:"legacy"`
+	Archived           uint64              `json:"archived"`
+	LastID             uint64              `json:"last_id"`
+	LastHash           string              `json:"last_hash"`
+	SignaturesVerified bool                `json:"signatures_verified"`
+	Checkpoints        uint64              `json:"checkpoints"`
+	LatestCheckpoint   *auditCheckpoint    `json:"latest_checkpoint,omitempty"`
+	Problems           []auditChainProblem `json:"problems"`
+}
+
+func (s *Server) handleVerifyAudits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	result, err := s.provider.VerifyAuditChain(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "provider.VerifyAuditChain", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, convertModelToVerifyAuditsResponse(result))
+}
+
+func convertModelToVerifyAuditsResponse(result *models.AuditChainVerification) verifyAuditsResponse {
+	resp := verifyAuditsResponse{
+		Valid:              result.Valid,
+		Checked:            result.Checked,
+		Legacy:             result.Legacy,
+		Archived:           result.Archived,
+		LastID:             result.LastID,
+		LastHash:           hex.EncodeToString(result.LastHash),
+		SignaturesVerified: result.SignaturesVerified,
+		Checkpoints:        result.Checkpoints,
+		Problems:           make([]auditChainProblem, 0, len(result.Problems)),
+	}
+
+	if checkpoint := result.LatestCheckpoint; checkpoint != nil {
+		resp.LatestCheckpoint = &auditCheckpoint{
+			ID:        checkpoint.ID,
+			LastID:    checkpoint.LastID,
+			Hash:      hex.EncodeToString(checkpoint.Hash),
+			Signature: base64.StdEncoding.EncodeToString(checkpoint.Signature),
+			Ts:        checkpoint.Ts,
+		}
+	}
+
+	for _, problem := range result.Problems {
+		resp.Problems = append(resp.Problems, auditChainProblem{
+			ID:           problem.ID,
+			CheckpointID: problem.CheckpointID,
+			Kind:         string(problem.Kind),
+			Message:      problem.Message,
+		})
+	}
+
+	return resp
+}
@@ -1,22 +1,140 @@
 package server
 
 import (
+	"encoding/base64"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/DesSolo/rtc/internal/models"
 )
 
+const (
+	defaultAuditsLimit = 100
+	maxAuditsLimit     = 1000
+)
+
 type audit struct {
-	Action  string    `json:"action"`
-	Actor   string    `json:"actor"`
-	Payload []byte    `json:"payload"`
-	Ts      time.Time `json:"ts"`
+	ID      uint64        `json:"id"`
+	Action  string        `json:"action"`
+	Actor   string        `json:"actor"`
+	Payload any           `json:"payload"`
+	Request *auditRequest `json:"request,omitempty"`
+	Ts      time.Time     `json:"ts"`
+}
+
+type auditRequest struct {
+	ID        string `json:"id,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+type auditConfigUpdatedPayload struct {
+	Project string                   `json:"project"`
+	Env     string                   `json:"env"`
+	Release string                   `json:"release"`
+	Reason  string                   `json:"reason,omitempty"`
+	Ticket  string                   `json:"ticket,omitempty"`
+	Items   []auditConfigValueChange `json:"items"`
+}
+
+type auditConfigValueChange struct {
+	Key      string `json:"key"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type auditConfigsUpsertedPayload struct {
+	Project     string                  `json:"project"`
+	Env         string                  `json:"env"`
+	Release     string                  `json:"release"`
+	Reason      string                  `json:"reason,omitempty"`
+	Ticket      string                  `json:"ticket,omitempty"`
+	Added       []auditConfigTypeChange `json:"added"`
+	TypeChanged []auditConfigTypeChange `json:"type_changed"`
+	Deleted     []auditConfigTypeChange `json:"deleted"`
+}
+
+type auditConfigTypeChange struct {
+	Key     string `json:"key"`
+	OldType string `json:"old_type,omitempty"`
+	NewType string `json:"new_type,omitempty"`
+}
+
+type auditProjectPayload struct {
+	Project        string `json:"project"`
+	Description    string `json:"description,omitempty"`
+	OldDescription string `json:"old_description,omitempty"`
+	NewDescription string `json:"new_description,omitempty"`
+}
+
+type auditReleasePayload struct {
+	Project string `json:"project"`
+	Env     string `json:"env"`
+	Release string `json:"release,omitempty"`
+}
+
+type auditUserLoginPayload struct {
+	Username string `json:"username"`
+	IP       string `json:"ip"`
+	Reason   string `json:"reason,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+type auditMemberPayload struct {
+	Project string `json:"project"`
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Env     string `json:"env"`
+	Role    string `json:"role"`
+}
+
+type auditUserCreatedPayload struct {
+	Username           string   `json:"username"`
+	Source             string   `json:"source"`
+	Roles              []string `json:"roles"`
+	IsEnabled          bool     `json:"is_enabled"`
+	MustChangePassword bool     `json:"must_change_password"`
+}
+
+type auditUserUpdatedPayload struct {
+	Username      string   `json:"username"`
+	OldIsEnabled  bool     `json:"old_is_enabled"`
+	NewIsEnabled  bool     `json:"new_is_enabled"`
+	OldRoles      []string `json:"old_roles"`
+	NewRoles      []string `json:"new_roles"`
+	TokensRevoked bool     `json:"tokens_revoked"`
+}
+
+type auditUserPasswordResetPayload struct {
+	Username string `json:"username"`
+}
+
+type auditUserTOTPPayload struct {
+	Username string `json:"username"`
+}
+
+type auditAPITokenPayload struct {
+	ID        uint64     `json:"token_id"`
+	Owner     string     `json:"owner"`
+	Name      string     `json:"name,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type auditArchivePayload struct {
+	FirstID  uint64 `json:"first_id"`
+	LastID   uint64 `json:"last_id"`
+	LastHash string `json:"last_hash"`
+	Records  uint64 `json:"records"`
+	File     string `json:"file"`
 }
 
 type listAuditsResponse struct {
 	Audits []audit `json:"audits"`
+	// NextCursor is empty on last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func (s *Server) handleListAudits(w http.ResponseWriter, r *http.Request) {
@@ -25,14 +143,22 @@ func (s *Server) handleListAudits(w http.ResponseWriter, r *http.Request) {
 	now := time.Now().UTC()
 
 	queryAction := queryOr(r, "action", "")
-	actor := queryOr(r, "actor", "")
 	fromDate := queryOr(r, "from", now.Add(-24*time.Hour))
 	toDate := queryOr(r, "to", now)
 
 	filter := models.AuditFilter{
-		Actor:    actor,
+		Actor:    queryOr(r, "actor", ""),
+		Project:  queryOr(r, "project", ""),
+		Env:      queryOr(r, "env", ""),
+		Release:  queryOr(r, "release", ""),
+		Key:      queryOr(r, "key", ""),
 		FromDate: fromDate,
 		ToDate:   toDate,
+		Limit:    min(queryOr[uint64](r, "limit", defaultAuditsLimit), maxAuditsLimit),
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditsLimit
 	}
 
 	if queryAction != "" {
@@ -44,16 +170,45 @@ func (s *Server) handleListAudits(w http.ResponseWriter, r *http.Request) {
 		filter.Action = action
 	}
 
-	audits, err := s.provider.AuditsSearch(ctx, filter)
+	if cursor := queryOr(r, "cursor", ""); cursor != "" {
+		id, err := decodeAuditsCursor(cursor)
+		if err != nil {
+			respondError(ctx, w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		filter.Cursor = id
+	}
+
+	audits, nextCursor, err := s.provider.AuditsSearch(ctx, filter)
 	if err != nil {
 		slog.ErrorContext(ctx, "provider.Audits", "err", err, "filter", filter)
 		respondError(ctx, w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	respondData(ctx, w, http.StatusOK, listAuditsResponse{
+	resp := listAuditsResponse{
 		Audits: convertModelsToAudits(audits),
-	})
+	}
+
+	if nextCursor != 0 {
+		resp.NextCursor = encodeAuditsCursor(nextCursor)
+	}
+
+	respondData(ctx, w, http.StatusOK, resp)
+}
+
+// encodeAuditsCursor cursor is opaque for clients
+func encodeAuditsCursor(id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(id, 10)))
+}
+
+func decodeAuditsCursor(cursor string) (uint64, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err // nolint:wrapcheck
+	}
+
+	return strconv.ParseUint(string(data), 10, 64) // nolint:wrapcheck
 }
 
 func (s *Server) handleAuditActions(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+type configHistoryItem struct {
+	ID       uint64    `json:"id"`
+	Actor    string    `json:"actor"`
+	OldValue string    `json:"old_value"`
+	NewValue string    `json:"new_value"`
+	Reason   string    `json:"reason,omitempty"`
+	Ticket   string    `json:"ticket,omitempty"`
+	Ts       time.Time `json:"ts"`
+}
+
+type configHistoryResponse struct {
+	History []configHistoryItem `json:"history"`
+	// NextCursor is empty on last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func (s *Server) handleConfigHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := models.AuditFilter{
+		Project:  chi.URLParam(r, "projectName"),
+		Env:      chi.URLParam(r, "envName"),
+		Release:  chi.URLParam(r, "releaseName"),
+		Key:      chi.URLParam(r, "configKey"),
+		FromDate: queryOr(r, "from", time.Time{}),
+		ToDate:   queryOr(r, "to", time.Time{}),
+		Limit:    min(queryOr[uint64](r, "limit", defaultAuditsLimit), maxAuditsLimit),
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditsLimit
+	}
+
+	if cursor := queryOr(r, "cursor", ""); cursor != "" {
+		id, err := decodeAuditsCursor(cursor)
+		if err != nil {
+			respondError(ctx, w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		filter.Cursor = id
+	}
+
+	history, nextCursor, err := s.provider.ConfigHistory(ctx, filter)
+	if err != nil {
+		slog.ErrorContext(ctx, "provider.ConfigHistory", "err", err, "filter", filter)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := configHistoryResponse{
+		History: convertModelsToConfigHistory(history),
+	}
+
+	if nextCursor != 0 {
+		resp.NextCursor = encodeAuditsCursor(nextCursor)
+	}
+
+	respondData(ctx, w, http.StatusOK, resp)
+}
+
+type revertConfigValueRequest struct {
+	// ID of config_updated audit record from history
+	ID uint64 `json:"id"`
+	// Before restore old value of change instead of new one
+	Before bool   `json:"before"`
+	Reason string `json:"reason"`
+	Ticket string `json:"ticket"`
+}
+
+func (r revertConfigValueRequest) Validate() error {
+	if r.ID == 0 {
+		return errors.New("id is required")
+	}
+
+	return nil
+}
+
+func (s *Server) handleRevertConfigValue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req revertConfigValueRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := s.provider.RevertConfigValue(ctx,
+		chi.URLParam(r, "projectName"),
+		chi.URLParam(r, "envName"),
+		chi.URLParam(r, "releaseName"),
+		chi.URLParam(r, "configKey"),
+		req.ID,
+		req.Before,
+		models.ChangeReason{
+			Reason: req.Reason,
+			Ticket: req.Ticket,
+		},
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, provider.ErrNotFound):
+			respondError(ctx, w, http.StatusNotFound, err.Error())
+		case errors.Is(err, provider.ErrNotValid):
+			respondError(ctx, w, http.StatusBadRequest, err.Error())
+		default:
+			slog.ErrorContext(ctx, "provider.RevertConfigValue", "err", err)
+			respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	respondStatus(w, http.StatusCreated)
+}
+
+// revertChangesInput add reverted key with old and new values to authorization input,
+// so revert is authorized as set_config_values
+func (s *Server) revertChangesInput(r *http.Request, input map[string]any) error {
+	ctx := r.Context()
+
+	var req revertConfigValueRequest
+	if err := peekJSON(r, &req); err != nil {
+		return fmt.Errorf("peekJSON: %w", err)
+	}
+
+	projectName := chi.URLParam(r, "projectName")
+	envName := chi.URLParam(r, "envName")
+	releaseName := chi.URLParam(r, "releaseName")
+	key := chi.URLParam(r, "configKey")
+
+	value, err := s.provider.ConfigHistoryValue(ctx, projectName, envName, releaseName, key, req.ID, req.Before)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			// handler responds not found
+			slog.DebugContext(ctx, "provider.ConfigHistoryValue", "err", err)
+			return nil
+		}
+
+		return fmt.Errorf("provider.ConfigHistoryValue: %w", err)
+	}
+
+	changes, err := s.provider.ConfigChanges(ctx, projectName, envName, releaseName, models.KV{key: value})
+	if err != nil {
+		return fmt.Errorf("provider.ConfigChanges: %w", err)
+	}
+
+	input["changes"] = convertModelsToConfigChanges(changes)
+
+	return nil
+}
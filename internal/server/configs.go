@@ -2,13 +2,16 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/DesSolo/rtc/internal/models"
 	"github.com/DesSolo/rtc/internal/provider"
+	"github.com/DesSolo/rtc/internal/server/middlewares"
 )
 
 type configView struct {
@@ -74,7 +77,12 @@ func (s *Server) handleSetConfigValues(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.provider.SetConfigValues(ctx, projectName, envName, releaseName, convertValuesToModels(req)); err != nil {
+	reason := models.ChangeReason{
+		Reason: queryOr(r, "reason", ""),
+		Ticket: queryOr(r, "ticket", ""),
+	}
+
+	if err := s.provider.SetConfigValues(ctx, projectName, envName, releaseName, convertValuesToModels(req), reason); err != nil {
 		if errors.Is(err, provider.ErrNotFound) {
 			respondError(ctx, w, http.StatusNotFound, err.Error())
 			return
@@ -93,6 +101,38 @@ func (s *Server) handleSetConfigValues(w http.ResponseWriter, r *http.Request) {
 	respondStatus(w, http.StatusCreated)
 }
 
+type configChange struct {
+	Key      string `json:"key"`
+	Group    string `json:"group"`
+	Exists   bool   `json:"exists"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// configChangesInput add keys being set with old and new values to authorization input
+func (s *Server) configChangesInput(r *http.Request, input map[string]any) error {
+	ctx := r.Context()
+
+	var req setConfigValuesRequest
+	if err := peekJSON(r, &req); err != nil {
+		return fmt.Errorf("peekJSON: %w", err)
+	}
+
+	changes, err := s.provider.ConfigChanges(ctx,
+		chi.URLParam(r, "projectName"),
+		chi.URLParam(r, "envName"),
+		chi.URLParam(r, "releaseName"),
+		convertValuesToModels(req),
+	)
+	if err != nil {
+		return fmt.Errorf("provider.ConfigChanges: %w", err)
+	}
+
+	input["changes"] = convertModelsToConfigChanges(changes)
+
+	return nil
+}
+
 type upsertConfigRequest []config
 
 func (u upsertConfigRequest) Validate() error {
@@ -117,6 +157,31 @@ func (u upsertConfigRequest) Validate() error {
 	return nil
 }
 
+type configPlanMetadata struct {
+	Group    string     `json:"group"`
+	Usage    string     `json:"usage"`
+	Writable bool       `json:"writable"`
+	View     configView `json:"view,omitempty"`
+}
+
+type configPlanItem struct {
+	Key         string              `json:"key"`
+	OldType     string              `json:"old_type,omitempty"`
+	NewType     string              `json:"new_type,omitempty"`
+	OldMetadata *configPlanMetadata `json:"old_metadata,omitempty"`
+	NewMetadata *configPlanMetadata `json:"new_metadata,omitempty"`
+	ValueReset  bool                `json:"value_reset"`
+	Value       string              `json:"value,omitempty"`
+}
+
+type configsPlanResponse struct {
+	Added     []configPlanItem `json:"added"`
+	Changed   []configPlanItem `json:"changed"`
+	Removed   []configPlanItem `json:"removed"`
+	Kept      []configPlanItem `json:"kept"`
+	Unchanged []configPlanItem `json:"unchanged"`
+}
+
 func (s *Server) handleUpsertConfigs(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -131,7 +196,29 @@ func (s *Server) handleUpsertConfigs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := s.provider.UpsertConfigs(ctx, projectName, envName, releaseName, convertConfigsToModels(req)); err != nil {
+	dryRun, err := queryBool(r, "dry_run", false)
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	prune, err := queryBool(r, "prune", true)
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	opts := models.UpsertConfigsOptions{
+		DryRun:  dryRun,
+		NoPrune: !prune,
+		Reason: models.ChangeReason{
+			Reason: queryOr(r, "reason", ""),
+			Ticket: queryOr(r, "ticket", ""),
+		},
+	}
+
+	plan, err := s.provider.UpsertConfigs(ctx, projectName, envName, releaseName, convertConfigsToModels(req), opts)
+	if err != nil {
 		if errors.Is(err, provider.ErrNotFound) {
 			respondError(ctx, w, http.StatusNotFound, err.Error())
 			return
@@ -147,5 +234,57 @@ func (s *Server) handleUpsertConfigs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if opts.DryRun {
+		respondData(ctx, w, http.StatusOK, convertModelToConfigsPlan(plan))
+		return
+	}
+
 	respondStatus(w, http.StatusCreated)
 }
+
+// upsertPlanInput add planned changes of upsert to authorization input
+func (s *Server) upsertPlanInput(r *http.Request, input map[string]any) error {
+	ctx := r.Context()
+
+	var req upsertConfigRequest
+	if err := peekJSON(r, &req); err != nil {
+		return fmt.Errorf("peekJSON: %w", err)
+	}
+
+	if _, err := queryBool(r, "dry_run", false); err != nil {
+		return fmt.Errorf("%w: %w", middlewares.ErrBadRequest, err)
+	}
+
+	prune, err := queryBool(r, "prune", true)
+	if err != nil {
+		return fmt.Errorf("%w: %w", middlewares.ErrBadRequest, err)
+	}
+
+	plan, err := s.provider.UpsertConfigs(ctx,
+		chi.URLParam(r, "projectName"),
+		chi.URLParam(r, "envName"),
+		chi.URLParam(r, "releaseName"),
+		convertConfigsToModels(req),
+		models.UpsertConfigsOptions{
+			DryRun:  true,
+			NoPrune: !prune,
+		},
+	)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			// handler responds not found
+			slog.DebugContext(ctx, "provider.UpsertConfigs", "err", err)
+			return nil
+		}
+
+		if errors.Is(err, provider.ErrNotValid) {
+			return fmt.Errorf("%w: %w", middlewares.ErrBadRequest, err)
+		}
+
+		return fmt.Errorf("provider.UpsertConfigs: %w", err)
+	}
+
+	input["plan"] = convertModelToConfigsPlan(plan)
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"encoding/hex"
+
 	"github.com/DesSolo/rtc/internal/auth"
 	"github.com/DesSolo/rtc/internal/models"
 )
@@ -87,6 +89,48 @@ func convertConfigsToModels(configs []config) []*models.Config {
 	return result
 }
 
+func convertModelToConfigsPlan(plan *models.ConfigsPlan) configsPlanResponse {
+	return configsPlanResponse{
+		Added:     convertModelsToConfigPlanItems(plan.Added),
+		Changed:   convertModelsToConfigPlanItems(plan.Changed),
+		Removed:   convertModelsToConfigPlanItems(plan.Removed),
+		Kept:      convertModelsToConfigPlanItems(plan.Kept),
+		Unchanged: convertModelsToConfigPlanItems(plan.Unchanged),
+	}
+}
+
+func convertModelsToConfigPlanItems(items []*models.ConfigPlanItem) []configPlanItem {
+	result := make([]configPlanItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, configPlanItem{
+			Key:         item.Key,
+			OldType:     string(item.OldType),
+			NewType:     string(item.NewType),
+			OldMetadata: convertModelToConfigPlanMetadata(item.OldMetadata),
+			NewMetadata: convertModelToConfigPlanMetadata(item.NewMetadata),
+			ValueReset:  item.ValueReset,
+			Value:       string(item.Value),
+		})
+	}
+
+	return result
+}
+
+func convertModelToConfigPlanMetadata(metadata *models.ConfigMetadata) *configPlanMetadata {
+	if metadata == nil {
+		return nil
+	}
+
+	return &configPlanMetadata{
+		Group:    metadata.Group,
+		Usage:    metadata.Usage,
+		Writable: metadata.Writable,
+		View: configView{
+			Enum: metadata.View.Enum,
+		},
+	}
+}
+
 func convertValuesToModels(values map[string]string) models.KV {
 	result := make(models.KV, len(values))
 	for k, v := range values {
@@ -100,9 +144,11 @@ func convertModelsToAudits(audits []*models.Audit) []audit {
 	result := make([]audit, 0, len(audits))
 	for _, modelAudit := range audits {
 		result = append(result, audit{
+			ID:      modelAudit.ID,
 			Action:  string(modelAudit.Action),
 			Actor:   modelAudit.Actor,
-			Payload: modelAudit.Payload,
+			Payload: convertModelToAuditPayload(modelAudit.Payload),
+			Request: (*auditRequest)(modelAudit.Request),
 			Ts:      modelAudit.Ts,
 		})
 	}
@@ -110,6 +156,91 @@ func convertModelsToAudits(audits []*models.Audit) []audit {
 	return result
 }
 
+func convertModelToAuditPayload(payload any) any {
+	switch p := payload.(type) {
+	case *models.AuditConfigUpdated:
+		items := make([]auditConfigValueChange, 0, len(p.Items))
+		for _, item := range p.Items {
+			items = append(items, auditConfigValueChange(item))
+		}
+
+		return auditConfigUpdatedPayload{
+			Project: p.Project,
+			Env:     p.Env,
+			Release: p.Release,
+			Reason:  p.Reason,
+			Ticket:  p.Ticket,
+			Items:   items,
+		}
+	case *models.AuditConfigsUpserted:
+		return auditConfigsUpsertedPayload{
+			Project:     p.Project,
+			Env:         p.Env,
+			Release:     p.Release,
+			Reason:      p.Reason,
+			Ticket:      p.Ticket,
+			Added:       convertModelsToAuditConfigTypeChanges(p.Added),
+			TypeChanged: convertModelsToAuditConfigTypeChanges(p.TypeChanged),
+			Deleted:     convertModelsToAuditConfigTypeChanges(p.Deleted),
+		}
+	case *models.AuditProject:
+		return auditProjectPayload(*p)
+	case *models.AuditRelease:
+		return auditReleasePayload(*p)
+	case *models.AuditUserLogin:
+		return auditUserLoginPayload(*p)
+	case *models.AuditMember:
+		return auditMemberPayload{
+			Project: p.Project,
+			Kind:    string(p.Kind),
+			Subject: p.Subject,
+			Env:     p.Env,
+			Role:    string(p.Role),
+		}
+	case *models.AuditUserCreated:
+		return auditUserCreatedPayload(*p)
+	case *models.AuditUserUpdated:
+		return auditUserUpdatedPayload(*p)
+	case *models.AuditUserPasswordReset:
+		return auditUserPasswordResetPayload(*p)
+	case *models.AuditUserTOTP:
+		return auditUserTOTPPayload(*p)
+	case *models.AuditAPIToken:
+		return auditAPITokenPayload(*p)
+	case *models.AuditArchive:
+		return convertModelToAuditArchivePayload(p)
+	default:
+		// raw payload of unknown action
+		return payload
+	}
+}
+
+func convertModelsToAuditConfigTypeChanges(changes []models.AuditConfigTypeChange) []auditConfigTypeChange {
+	result := make([]auditConfigTypeChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, auditConfigTypeChange(change))
+	}
+
+	return result
+}
+
+func convertModelsToConfigHistory(history []*models.ConfigHistoryItem) []configHistoryItem {
+	result := make([]configHistoryItem, 0, len(history))
+	for _, item := range history {
+		result = append(result, configHistoryItem{
+			ID:       item.ID,
+			Actor:    item.Actor,
+			OldValue: string(item.OldValue),
+			NewValue: string(item.NewValue),
+			Reason:   item.Reason,
+			Ticket:   item.Ticket,
+			Ts:       item.Ts,
+		})
+	}
+
+	return result
+}
+
 func convertModelToAuth(user *models.User) *auth.Payload {
 	return &auth.Payload{
 		Username: user.Username,
@@ -121,10 +252,13 @@ func convertModelsToUsers(users []*models.User) []user {
 	result := make([]user, 0, len(users))
 	for _, modelUser := range users {
 		result = append(result, user{
-			Username:  modelUser.Username,
-			IsEnabled: modelUser.IsEnabled,
-			Roles:     modelUser.Roles,
-			CreatedAt: modelUser.CreatedAt,
+			Username:           modelUser.Username,
+			IsEnabled:          modelUser.IsEnabled,
+			Roles:              modelUser.Roles,
+			MustChangePassword: modelUser.MustChangePassword,
+			Source:             string(modelUser.Source),
+			TwoFactorEnabled:   modelUser.TwoFactorEnabled,
+			CreatedAt:          modelUser.CreatedAt,
 		})
 	}
 
@@ -139,3 +273,92 @@ func convertModelsToAuditActions(actions []models.AuditAction) []string {
 
 	return result
 }
+
+func convertModelsToAPITokens(tokens []*models.APIToken) []apiToken {
+	result := make([]apiToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, convertModelToAPIToken(token))
+	}
+
+	return result
+}
+
+func convertModelToAPIToken(token *models.APIToken) apiToken {
+	return apiToken{
+		ID:         token.ID,
+		Name:       token.Name,
+		Owner:      token.Owner,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		RevokedAt:  token.RevokedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+func convertModelToTOTPEnrollment(enrollment *models.TOTPEnrollment) totpEnrollmentResponse {
+	return totpEnrollmentResponse{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+	}
+}
+
+func convertModelsToConfigChanges(changes []*models.ConfigChange) []configChange {
+	result := make([]configChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, configChange{
+			Key:      change.Key,
+			Group:    change.Group,
+			Exists:   change.Exists,
+			OldValue: string(change.OldValue),
+			NewValue: string(change.NewValue),
+		})
+	}
+
+	return result
+}
+
+func convertModelsToMembers(members []*models.Member) []member {
+	result := make([]member, 0, len(members))
+	for _, m := range members {
+		result = append(result, convertModelToMember(m))
+	}
+
+	return result
+}
+
+func convertModelToMember(m *models.Member) member {
+	return member{
+		ID:        m.ID,
+		Kind:      string(m.Kind),
+		Subject:   m.Subject,
+		Env:       m.Env,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func convertModelsToRoleBindings(members []*models.Member) []auth.RoleBinding {
+	bindings := make([]auth.RoleBinding, 0, len(members))
+	for _, m := range members {
+		bindings = append(bindings, auth.RoleBinding{
+			Kind:    string(m.Kind),
+			Subject: m.Subject,
+			Project: m.Project,
+			Env:     m.Env,
+			Role:    auth.Role(m.Role),
+		})
+	}
+
+	return bindings
+}
+
+func convertModelToAuditArchivePayload(archive *models.AuditArchive) auditArchivePayload {
+	return auditArchivePayload{
+		FirstID:  archive.FirstID,
+		LastID:   archive.LastID,
+		LastHash: hex.EncodeToString(archive.LastHash),
+		Records:  archive.Records,
+		File:     archive.File,
+	}
+}
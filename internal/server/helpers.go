@@ -1,13 +1,23 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/provider"
+	"github.com/DesSolo/rtc/internal/server/middlewares"
 )
 
 func respondStatus(w http.ResponseWriter, code int) {
@@ -50,10 +60,37 @@ type validator interface {
 }
 
 func bindJSON(r *http.Request, payload any) error {
-	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+	return decodeJSON(r.Body, payload)
+}
+
+// peekJSON bind body like handler does and keep body for handler, used by authorization inputs
+func peekJSON(r *http.Request, payload any) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("io.ReadAll: %w", err)
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := decodeJSON(bytes.NewReader(body), payload); err != nil {
+		return fmt.Errorf("%w: %w", middlewares.ErrBadRequest, err)
+	}
+
+	return nil
+}
+
+// decodeJSON decode and validate single JSON value, trailing data is not allowed
+func decodeJSON(body io.Reader, payload any) error {
+	decoder := json.NewDecoder(body)
+
+	if err := decoder.Decode(payload); err != nil {
 		return fmt.Errorf("json.Decode: %w", err)
 	}
 
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("json.Decode: unexpected data after top-level value")
+	}
+
 	if v, ok := payload.(validator); ok {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("validate: %w", err)
@@ -72,6 +109,10 @@ func queryOr[T any](r *http.Request, key string, bo T) T {
 	switch any(bo).(type) {
 	case string:
 		return any(val).(T)
+	case bool:
+		if boolVal, err := strconv.ParseBool(val); err == nil {
+			return any(boolVal).(T)
+		}
 	case int:
 		if intVal, err := strconv.Atoi(val); err == nil {
 			return any(intVal).(T)
@@ -88,3 +129,43 @@ func queryOr[T any](r *http.Request, key string, bo T) T {
 
 	return bo
 }
+
+// queryBool default is used only if value is missing, invalid value is error
+// so a typo does not change behaviour of unsafe operations
+func queryBool(r *http.Request, key string, bo bool) (bool, error) {
+	val := r.URL.Query().Get(key)
+	if val == "" {
+		return bo, nil
+	}
+
+	boolVal, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, fmt.Errorf("invalid query parameter %s: %w", key, err)
+	}
+
+	return boolVal, nil
+}
+
+// clientIP remote address without port, headers of trusted proxies are handled by middleware
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// requestMeta adds request id, client ip and user agent to context for audit records,
+// remote address is taken from forwarded headers only for trusted proxies by middlewares.RealIP
+func requestMeta(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := provider.RequestMetaToContext(r.Context(), &models.RequestMeta{
+			ID:        middleware.GetReqID(r.Context()),
+			IP:        clientIP(r),
+			UserAgent: r.UserAgent(),
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,13 @@
+package server
+
+import (
+	"net/http"
+)
+
+const jwksCacheControl = "public, max-age=300"
+
+// handleJWKS publish public keys so other services can verify issued tokens
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", jwksCacheControl)
+	respondJSON(r.Context(), w, http.StatusOK, s.jwt.JWKS())
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+type member struct {
+	ID        uint64    `json:"id"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject"`
+	Env       string    `json:"env"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type listMembersResponse struct {
+	Members []member `json:"members"`
+}
+
+func (s *Server) handleListMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	members, err := s.provider.Members(ctx, chi.URLParam(r, "projectName"))
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusNotFound, "project not found")
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.Members", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, listMembersResponse{
+		Members: convertModelsToMembers(members),
+	})
+}
+
+type addMemberRequest struct {
+	Kind    string `json:"kind"`
+	Subject string `json:"subject"`
+	Env     string `json:"env"`
+	Role    string `json:"role"`
+}
+
+func (s *Server) handleAddMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req addMemberRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	added, err := s.provider.AddMember(ctx, &models.Member{
+		Project: chi.URLParam(r, "projectName"),
+		Kind:    models.MemberKind(req.Kind),
+		Subject: req.Subject,
+		Env:     req.Env,
+		Role:    models.MemberRole(req.Role),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, provider.ErrNotValid):
+			respondError(ctx, w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, provider.ErrNotFound):
+			respondError(ctx, w, http.StatusNotFound, "project not found")
+		case errors.Is(err, provider.ErrAlreadyExists):
+			respondError(ctx, w, http.StatusConflict, "member already exists")
+		default:
+			slog.ErrorContext(ctx, "provider.AddMember", "err", err)
+			respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		}
+
+		return
+	}
+
+	respondData(ctx, w, http.StatusCreated, convertModelToMember(added))
+}
+
+func (s *Server) handleRemoveMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	memberID, err := strconv.ParseUint(chi.URLParam(r, "memberID"), 10, 64)
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, "invalid member id")
+		return
+	}
+
+	if err := s.provider.RemoveMember(ctx, chi.URLParam(r, "projectName"), memberID); err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusNotFound, "member not found")
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.RemoveMember", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondStatus(w, http.StatusNoContent)
+}
+
+// membershipInput add role of user in project and environment of route to authorization input,
+// role bindings are not queried if authorizer doesn't use membership
+func (s *Server) membershipInput(r *http.Request, input map[string]any) error {
+	if !auth.UsesInput(s.authorizer, "membership") {
+		return nil
+	}
+
+	ctx := r.Context()
+
+	payload := auth.FromContext(ctx)
+
+	members, err := s.provider.UserMemberships(ctx, payload.Username, payload.Roles)
+	if err != nil {
+		return fmt.Errorf("provider.UserMemberships: %w", err)
+	}
+
+	input["membership"] = auth.NewMembership(
+		convertModelsToRoleBindings(members),
+		chi.URLParam(r, "projectName"),
+		chi.URLParam(r, "envName"),
+	)
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"crypto/x509"
 	"net/http"
 	"strings"
 
@@ -11,10 +12,19 @@ import (
 func Authenticate(authenticators map[string]auth.Authenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
 			kind, token := parseAuthorization(r)
 			if kind == "" {
-				w.WriteHeader(http.StatusUnauthorized)
-				return
+				// requests without authorization header are authenticated by client certificate
+				cert := verifiedClientCertificate(r)
+				if cert == nil {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				kind = "cert"
+				ctx = auth.CertificateToContext(ctx, cert)
 			}
 
 			authAlgo, ok := authenticators[kind]
@@ -23,14 +33,14 @@ func Authenticate(authenticators map[string]auth.Authenticator) func(http.Handle
 				return
 			}
 
-			payload, err := authAlgo.Authenticate(token)
+			payload, err := authAlgo.Authenticate(ctx, token)
 			if err != nil {
 				w.WriteHeader(http.StatusUnauthorized)
 				return
 			}
 
 			next.ServeHTTP(w, r.WithContext(
-				auth.ToContext(r.Context(), payload),
+				auth.ToContext(ctx, payload),
 			))
 		})
 	}
@@ -47,5 +57,15 @@ func parseAuthorization(r *http.Request) (string, string) {
 		return "", ""
 	}
 
-	return parts[0], parts[1]
+	// kind is case-insensitive e.g. "Bearer" and "bearer"
+	return strings.ToLower(parts[0]), parts[1]
+}
+
+// verifiedClientCertificate leaf certificate verified by server client CA
+func verifiedClientCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	return r.TLS.VerifiedChains[0][0]
 }
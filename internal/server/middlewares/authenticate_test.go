@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auth"
+)
+
+func Test_Authenticate_ClientCert_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "billing"}}
+
+	handler := Authenticate(map[string]auth.Authenticator{
+		"cert": auth.AuthenticatorFunc(func(_ context.Context, _ string) (*auth.Payload, error) {
+			return &auth.Payload{Username: "billing"}, nil
+		}),
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "billing", auth.UsernameFromContext(r.Context()))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/projects", nil)
+	r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	// certificate is not verified
+	r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,29 +1,95 @@
 package middlewares
 
 import (
+	"errors"
+	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+
 	"github.com/DesSolo/rtc/internal/auth"
 )
 
-// Authorize ...
-func Authorize(authorizer auth.Authorizer) func(http.Handler) http.Handler {
+// InputFunc add request specific fields to authorization input
+type InputFunc func(r *http.Request, input map[string]any) error
+
+// ErrBadRequest returned by InputFunc if input can't be built from request e.g. malformed body,
+// request is rejected with bad request
+var ErrBadRequest = errors.New("bad request")
+
+// routeParams names of route params in authorization input
+var routeParams = map[string]string{
+	"projectName": "project",
+	"envName":     "env",
+	"releaseName": "release",
+	"username":    "username",
+	"tokenID":     "token_id",
+	"memberID":    "member_id",
+	"configKey":   "key",
+}
+
+// Authorize check access to route with action name e.g. set_config_values
+func Authorize(authorizer auth.Authorizer, action string, inputs ...InputFunc) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
 			payload := auth.FromContext(ctx)
+			evaluation := evaluationFromContext(ctx)
+
+			if err := auth.CheckScopes(payload.Scopes, r.Method, chi.URLParam(r, "projectName")); err != nil {
+				slog.DebugContext(ctx, "auth.CheckScopes", "err", err)
+
+				if evaluation != nil {
+					evaluation.Decision = &auth.Decision{Reason: err.Error()}
+					return
+				}
+
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
 
 			input := map[string]any{
 				"method": r.Method,
 				"path":   r.URL.Path,
+				"action": action,
+				"params": paramsFromRoute(r),
 				"user": map[string]any{
 					"username": payload.Username,
 					"roles":    payload.Roles,
+					"scopes":   payload.Scopes,
 				},
 			}
 
+			for _, inputFunc := range inputs {
+				err := inputFunc(r, input)
+				if errors.Is(err, ErrBadRequest) {
+					slog.DebugContext(ctx, "authorization input", "action", action, "err", err)
+
+					if evaluation != nil {
+						evaluation.Decision = &auth.Decision{Reason: err.Error()}
+						return
+					}
+
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				if err != nil {
+					slog.ErrorContext(ctx, "authorization input", "action", action, "err", err)
+					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+					return
+				}
+			}
+
+			if evaluation != nil {
+				evaluation.Input = input
+				evaluation.Decision = auth.Explain(ctx, authorizer, input, evaluation.Mode)
+				return
+			}
+
 			if err := authorizer.Authorize(ctx, input); err != nil {
+				slog.DebugContext(ctx, "authorizer.Authorize", "action", action, "err", err)
 				http.Error(w, "Forbidden", http.StatusForbidden)
 				return
 			}
@@ -32,3 +98,23 @@ func Authorize(authorizer auth.Authorizer) func(http.Handler) http.Handler {
 		})
 	}
 }
+
+func paramsFromRoute(r *http.Request) map[string]any {
+	params := make(map[string]any)
+
+	routeCtx := chi.RouteContext(r.Context())
+	if routeCtx == nil {
+		return params
+	}
+
+	for i, key := range routeCtx.URLParams.Keys {
+		name, ok := routeParams[key]
+		if !ok {
+			continue
+		}
+
+		params[name] = routeCtx.URLParams.Values[i]
+	}
+
+	return params
+}
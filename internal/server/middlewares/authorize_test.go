@@ -0,0 +1,122 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/auth/mocks"
+)
+
+func newAuthorizeRouter(authorizer auth.Authorizer, inputs ...InputFunc) http.Handler {
+	router := chi.NewRouter()
+
+	router.Route("/api/v1", func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(w, r.WithContext(auth.ToContext(r.Context(), &auth.Payload{
+					Username: "test",
+					Roles:    []string{"editor"},
+				})))
+			})
+		})
+
+		r.With(Authorize(authorizer, "set_config_values", inputs...)).
+			Put("/projects/{projectName}/envs/{envName}/releases/{releaseName}/configs", func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+			})
+	})
+
+	return router
+}
+
+func Test_Authorize_Input_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	authorizer := mocks.NewMockAuthorizer(t)
+	authorizer.EXPECT().Authorize(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, input map[string]any) {
+			require.Equal(t, "set_config_values", input["action"])
+			require.Equal(t, map[string]any{
+				"project": "example",
+				"env":     "prod",
+				"release": "v1",
+			}, input["params"])
+			require.Equal(t, []string{"limits.rps"}, input["changes"])
+		}).
+		Return(nil)
+
+	router := newAuthorizeRouter(authorizer, func(_ *http.Request, input map[string]any) error {
+		input["changes"] = []string{"limits.rps"}
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/projects/example/envs/prod/releases/v1/configs", nil))
+
+	require.Equal(t, http.StatusCreated, w.Code)
+}
+
+func Test_Authorize_Denied_ExpectForbidden(t *testing.T) {
+	t.Parallel()
+
+	authorizer := mocks.NewMockAuthorizer(t)
+	authorizer.EXPECT().Authorize(mock.Anything, mock.Anything).Return(errors.New("denied"))
+
+	w := httptest.NewRecorder()
+	newAuthorizeRouter(authorizer).ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/projects/example/envs/prod/releases/v1/configs", nil))
+
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func Test_Authorize_Evaluation_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	authorizer := mocks.NewMockAuthorizer(t)
+	authorizer.EXPECT().Authorize(mock.Anything, mock.Anything).Return(errors.New("denied"))
+
+	evaluation := &Evaluation{Mode: auth.ExplainFull}
+
+	r := httptest.NewRequest(http.MethodPut, "/api/v1/projects/example/envs/prod/releases/v1/configs", nil)
+
+	w := httptest.NewRecorder()
+	newAuthorizeRouter(authorizer).ServeHTTP(w, r.WithContext(WithEvaluation(r.Context(), evaluation)))
+
+	// handler is not called
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "set_config_values", evaluation.Input["action"])
+	require.Equal(t, &auth.Decision{Reason: "denied"}, evaluation.Decision)
+}
+
+func Test_Authorize_InputBadRequest_ExpectBadRequest(t *testing.T) {
+	t.Parallel()
+
+	// authorizer is not called
+	authorizer := mocks.NewMockAuthorizer(t)
+
+	router := newAuthorizeRouter(authorizer, func(_ *http.Request, _ map[string]any) error {
+		return fmt.Errorf("%w: unexpected data after top-level value", ErrBadRequest)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/projects/example/envs/prod/releases/v1/configs", nil))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	evaluation := &Evaluation{Mode: auth.ExplainFull}
+
+	r := httptest.NewRequest(http.MethodPut, "/api/v1/projects/example/envs/prod/releases/v1/configs", nil)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r.WithContext(WithEvaluation(r.Context(), evaluation)))
+
+	require.Equal(t, &auth.Decision{Reason: "bad request: unexpected data after top-level value"}, evaluation.Decision)
+}
@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/DesSolo/rtc/internal/auth"
+)
+
+type ctxKeyEvaluation struct{}
+
+// Evaluation dry run of authorization for user from context, route handler is not called
+type Evaluation struct {
+	Mode auth.ExplainMode
+
+	// Input and Decision are filled by Authorize, nil if route is not authorized
+	Input    map[string]any
+	Decision *auth.Decision
+}
+
+// WithEvaluation request is evaluated by Authorize instead of handling
+func WithEvaluation(ctx context.Context, evaluation *Evaluation) context.Context {
+	return context.WithValue(ctx, ctxKeyEvaluation{}, evaluation)
+}
+
+func evaluationFromContext(ctx context.Context) *Evaluation {
+	evaluation, ok := ctx.Value(ctxKeyEvaluation{}).(*Evaluation)
+	if !ok {
+		return nil
+	}
+
+	return evaluation
+}
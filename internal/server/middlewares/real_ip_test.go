@@ -0,0 +1,98 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RealIP_ExpectOk(t *testing.T) {
+	t.Parallel()
+
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.1/32"),
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		want       string
+	}{
+		{
+			name:       "untrusted remote ignores headers",
+			remoteAddr: "203.0.113.5:4000",
+			forwarded:  []string{"198.51.100.1"},
+			realIP:     "198.51.100.2",
+			want:       "203.0.113.5:4000",
+		},
+		{
+			name:       "trusted remote uses forwarded",
+			remoteAddr: "10.0.0.1:4000",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "spoofed leftmost address is skipped",
+			remoteAddr: "10.0.0.1:4000",
+			forwarded:  []string{"1.1.1.1, 198.51.100.1, 192.168.1.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "multiple headers",
+			remoteAddr: "10.0.0.1:4000",
+			forwarded:  []string{"1.1.1.1", "198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+		{
+			name:       "trusted remote uses real ip",
+			remoteAddr: "10.0.0.1:4000",
+			realIP:     "198.51.100.2",
+			want:       "198.51.100.2",
+		},
+		{
+			name:       "invalid forwarded falls back to real ip",
+			remoteAddr: "10.0.0.1:4000",
+			forwarded:  []string{"unknown"},
+			realIP:     "198.51.100.2",
+			want:       "198.51.100.2",
+		},
+		{
+			name:       "trusted remote without headers",
+			remoteAddr: "10.0.0.1:4000",
+			want:       "10.0.0.1:4000",
+		},
+		{
+			name:       "ipv4 mapped remote",
+			remoteAddr: "[::ffff:10.0.0.1]:4000",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "198.51.100.1",
+		},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+
+		for _, value := range tt.forwarded {
+			r.Header.Add("X-Forwarded-For", value)
+		}
+
+		if tt.realIP != "" {
+			r.Header.Set("X-Real-IP", tt.realIP)
+		}
+
+		var got string
+
+		RealIP(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			got = r.RemoteAddr
+		})).ServeHTTP(httptest.NewRecorder(), r)
+
+		require.Equal(t, tt.want, got, tt.name)
+	}
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+const (
+	oidcStateCookie    = "rtc_oidc_state"
+	oidcVerifierCookie = "rtc_oidc_verifier"
+	oidcNonceCookie    = "rtc_oidc_nonce"
+	oidcCookiePath     = "/api/v1/oidc"
+	oidcCookieTTL      = 10 * time.Minute
+
+	// oidcSuccessRedirect UI page receives tokens in url fragment
+	oidcSuccessRedirect = "/ui/login#"
+)
+
+type loginMethodsResponse struct {
+	Password bool `json:"password"`
+	OIDC     bool `json:"oidc"`
+}
+
+func (s *Server) handleLoginMethods(w http.ResponseWriter, r *http.Request) {
+	respondData(r.Context(), w, http.StatusOK, loginMethodsResponse{
+		Password: true,
+		OIDC:     s.oidc != nil,
+	})
+}
+
+func (s *Server) handleOIDCLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	state, err := randomString()
+	if err != nil {
+		slog.ErrorContext(ctx, "randomString", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	verifier, err := randomString()
+	if err != nil {
+		slog.ErrorContext(ctx, "randomString", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		slog.ErrorContext(ctx, "randomString", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	setOIDCCookie(w, r, oidcStateCookie, state, oidcCookieTTL)
+	setOIDCCookie(w, r, oidcVerifierCookie, verifier, oidcCookieTTL)
+	setOIDCCookie(w, r, oidcNonceCookie, nonce, oidcCookieTTL)
+
+	http.Redirect(w, r, s.oidc.AuthCodeURL(state, verifier, nonce), http.StatusFound)
+}
+
+func (s *Server) handleOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	if errCode := query.Get("error"); errCode != "" {
+		slog.WarnContext(ctx, "oidc callback error", "error", errCode, "description", query.Get("error_description"))
+		respondError(ctx, w, http.StatusUnauthorized, errCode)
+		return
+	}
+
+	state, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(state.Value), []byte(query.Get("state"))) != 1 {
+		respondError(ctx, w, http.StatusBadRequest, "invalid state")
+		return
+	}
+
+	verifier, err := r.Cookie(oidcVerifierCookie)
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, "missing code verifier")
+		return
+	}
+
+	nonce, err := r.Cookie(oidcNonceCookie)
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, "missing nonce")
+		return
+	}
+
+	setOIDCCookie(w, r, oidcStateCookie, "", -1)
+	setOIDCCookie(w, r, oidcVerifierCookie, "", -1)
+	setOIDCCookie(w, r, oidcNonceCookie, "", -1)
+
+	payload, err := s.oidc.Exchange(ctx, query.Get("code"), verifier.Value, nonce.Value)
+	if err != nil {
+		slog.WarnContext(ctx, "oidc.Exchange", "err", err)
+		respondError(ctx, w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		return
+	}
+
+	user, err := s.provider.ProvisionUser(ctx, &models.User{
+		Username: payload.Username,
+		Roles:    payload.Roles,
+		Source:   models.UserSourceOIDC,
+	})
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) || errors.Is(err, provider.ErrAlreadyExists) {
+			respondError(ctx, w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.ProvisionUser", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	session, err := s.issueSession(ctx, user)
+	if err != nil {
+		slog.ErrorContext(ctx, "issueSession", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	fragment := url.Values{
+		"token":         {session.Token},
+		"refresh_token": {session.RefreshToken},
+	}
+
+	http.Redirect(w, r, oidcSuccessRedirect+fragment.Encode(), http.StatusFound)
+}
+
+func setOIDCCookie(w http.ResponseWriter, r *http.Request, name, value string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     oidcCookiePath,
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// randomString returns 43 url safe characters, suitable for state, nonce and PKCE verifier
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("rand.Read: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"crypto/tls"
+	"net/netip"
 	"time"
 
 	"github.com/DesSolo/rtc/internal/auth"
@@ -41,3 +43,24 @@ func WithAuthorizer(authorizer auth.Authorizer) OptionFunc {
 		s.authorizer = authorizer
 	}
 }
+
+// WithOIDC enable OpenID Connect login
+func WithOIDC(oidc *auth.OIDC) OptionFunc {
+	return func(s *Server) {
+		s.oidc = oidc
+	}
+}
+
+// WithTLS serve HTTPS, plain HTTP if config is nil
+func WithTLS(tlsConfig *tls.Config) OptionFunc {
+	return func(s *Server) {
+		s.tlsConfig = tlsConfig
+	}
+}
+
+// WithTrustedProxies honor X-Forwarded-For and X-Real-IP headers from these addresses
+func WithTrustedProxies(prefixes []netip.Prefix) OptionFunc {
+	return func(s *Server) {
+		s.trustedProxies = prefixes
+	}
+}
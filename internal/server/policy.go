@@ -0,0 +1,127 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/provider"
+	"github.com/DesSolo/rtc/internal/server/middlewares"
+)
+
+type evalPolicyRequest struct {
+	Username string `json:"username"`
+	// Roles of user, roles of stored user are used if not set
+	Roles   []string        `json:"roles"`
+	Method  string          `json:"method"`
+	Path    string          `json:"path"`
+	Body    json.RawMessage `json:"body"`
+	Explain string          `json:"explain"`
+}
+
+func (r *evalPolicyRequest) Validate() error {
+	if r.Username == "" {
+		return errors.New("username is required")
+	}
+
+	if r.Method == "" {
+		return errors.New("method is required")
+	}
+
+	if !strings.HasPrefix(r.Path, "/api/v1/") {
+		return errors.New("path must start with /api/v1/")
+	}
+
+	if _, err := auth.ParseExplainMode(r.Explain); err != nil {
+		return err // nolint:wrapcheck
+	}
+
+	return nil
+}
+
+type evalPolicyResponse struct {
+	Allowed     bool           `json:"allowed"`
+	Reason      string         `json:"reason,omitempty"`
+	Action      string         `json:"action"`
+	Input       map[string]any `json:"input"`
+	Explanation []string       `json:"explanation"`
+}
+
+func (s *Server) handleEvalPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req evalPolicyRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roles := req.Roles
+	if roles == nil {
+		user, err := s.provider.User(ctx, req.Username)
+		if err != nil {
+			if errors.Is(err, provider.ErrNotFound) {
+				respondError(ctx, w, http.StatusNotFound, "user not found, set roles explicitly")
+				return
+			}
+
+			slog.ErrorContext(ctx, "provider.User", "err", err)
+			respondError(ctx, w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		roles = user.Roles
+	}
+
+	mode, _ := auth.ParseExplainMode(req.Explain)
+
+	evaluation := &middlewares.Evaluation{Mode: mode}
+
+	// route context of current request must not be reused by router
+	evalCtx := middlewares.WithEvaluation(context.WithValue(ctx, chi.RouteCtxKey, nil), evaluation)
+	evalCtx = auth.ToContext(evalCtx, &auth.Payload{
+		Username: req.Username,
+		Roles:    roles,
+	})
+
+	evalReq, err := http.NewRequestWithContext(evalCtx, strings.ToUpper(req.Method), req.Path, bytes.NewReader(req.Body))
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// only routes with authorization action are evaluated, other routes e.g. login are never served
+	if !s.policyMux.Match(chi.NewRouteContext(), evalReq.Method, evalReq.URL.Path) {
+		respondError(ctx, w, http.StatusNotFound, "route is not found or not authorized")
+		return
+	}
+
+	// authorize middleware evaluates policy and returns without calling handler
+	recorder := httptest.NewRecorder()
+	s.policyMux.ServeHTTP(recorder, evalReq)
+
+	if evaluation.Decision == nil {
+		slog.ErrorContext(ctx, "policy evaluation failed", "status", recorder.Code)
+		respondError(ctx, w, http.StatusInternalServerError, "failed to build authorization input")
+		return
+	}
+
+	action, _ := evaluation.Input["action"].(string)
+
+	respondData(ctx, w, http.StatusOK, evalPolicyResponse{
+		Allowed:     evaluation.Decision.Allowed,
+		Reason:      evaluation.Decision.Reason,
+		Action:      action,
+		Input:       evaluation.Input,
+		Explanation: evaluation.Decision.Explanation,
+	})
+}
@@ -2,12 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io/fs"
 	"log"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"strings"
 	"time"
 
@@ -37,10 +39,16 @@ type Server struct {
 	jwt        *auth.JWT
 	auth       map[string]auth.Authenticator
 	authorizer auth.Authorizer
+	oidc       *auth.OIDC
+	tlsConfig  *tls.Config
+	// trustedProxies forwarded client address headers are honored only from these addresses
+	trustedProxies []netip.Prefix
 
 	address           string
 	readHeaderTimeout time.Duration
 	mux               chi.Router
+	// policyMux routes of policy evaluation
+	policyMux chi.Router
 }
 
 // NewServer ...
@@ -55,6 +63,7 @@ func NewServer(provider *provider.Provider, jwt *auth.JWT, options ...OptionFunc
 		address:           defaultAddress,
 		readHeaderTimeout: defaultReadHeaderTimeout,
 		mux:               chi.NewRouter(),
+		policyMux:         chi.NewRouter(),
 	}
 
 	for _, option := range options {
@@ -72,6 +81,7 @@ func (s *Server) Run(ctx context.Context) error {
 		Addr:        s.address,
 		ReadTimeout: s.readHeaderTimeout,
 		Handler:     s.mux,
+		TLSConfig:   s.tlsConfig,
 	}
 
 	go func() {
@@ -84,6 +94,17 @@ func (s *Server) Run(ctx context.Context) error {
 		}
 	}()
 
+	if s.tlsConfig != nil {
+		slog.InfoContext(ctx, "https server running", "address", s.address)
+
+		// certificates are already loaded to config
+		if err := srv.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("server.ListenAndServeTLS: %w", err)
+		}
+
+		return nil
+	}
+
 	slog.InfoContext(ctx, "http server running", "address", s.address)
 
 	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -96,7 +117,8 @@ func (s *Server) Run(ctx context.Context) error {
 func (s *Server) initRoutes() {
 	s.mux.Use(
 		middleware.RequestID,
-		middleware.RealIP,
+		middlewares.RealIP(s.trustedProxies),
+		requestMeta,
 		middleware.Logger,
 		middleware.Recoverer,
 	)
@@ -105,38 +127,106 @@ func (s *Server) initRoutes() {
 		slog.Warn("initUI", "err", err)
 	}
 
+	s.mux.Get("/.well-known/jwks.json", s.handleJWKS)
+
 	s.mux.Route("/api/v1", func(r chi.Router) {
 		r.Post("/login", s.handleLogin)
+		r.Get("/login/methods", s.handleLoginMethods)
+		r.Post("/login/totp", s.handleLoginEnrollTOTP)
+		r.Post("/login/totp/confirm", s.handleLoginConfirmTOTP)
+		r.Post("/refresh", s.handleRefresh)
+		r.Post("/logout", s.handleLogout)
+
+		if s.oidc != nil {
+			r.Get("/oidc/login", s.handleOIDCLogin)
+			r.Get("/oidc/callback", s.handleOIDCCallback)
+		}
 
 		r.Group(func(r chi.Router) {
 			r.Use(middlewares.Authenticate(s.auth))
-			r.Use(middlewares.Authorize(s.authorizer))
 
-			r.Get("/projects", s.handleListProjects)
-			r.Post("/projects", s.handleCreateProject)
-			r.Put("/projects/{projectName}", s.handleUpdateProject)
-			r.Delete("/projects/{projectName}", s.handleDeleteProject)
+			for _, route := range s.authorizedRoutes() {
+				r.With(s.authorize(route.action, route.inputs...)).Method(route.method, route.pattern, route.handler)
+			}
+		})
 
-			r.Get("/projects/{projectName}/envs", s.handleListEnvironments)
+		r.Get("/health", s.handleHealth)
+	})
 
-			r.Get("/projects/{projectName}/envs/{envName}/releases", s.handleListReleases)
+	// policy evaluation router has authorized routes only, handlers are never called
+	s.policyMux.Route("/api/v1", func(r chi.Router) {
+		for _, route := range s.authorizedRoutes() {
+			r.With(s.authorize(route.action, route.inputs...)).Method(route.method, route.pattern, http.NotFoundHandler())
+		}
+	})
+}
 
-			r.Delete("/projects/{projectName}/envs/{envName}/releases/{releaseName}", s.handleDeleteRelease)
+// authorizedRoute route of /api/v1 with authorization action
+type authorizedRoute struct {
+	method  string
+	pattern string
+	action  string
+	handler http.HandlerFunc
+	inputs  []middlewares.InputFunc
+}
 
-			r.Get("/projects/{projectName}/envs/{envName}/releases/{releaseName}/configs", s.handleListConfigs)
-			r.Put("/projects/{projectName}/envs/{envName}/releases/{releaseName}/configs", s.handleSetConfigValues)
-			r.Post("/projects/{projectName}/envs/{envName}/releases/{releaseName}/configs", s.handleUpsertConfigs)
+func (s *Server) authorizedRoutes() []authorizedRoute {
+	const (
+		project = "/projects/{projectName}"
+		release = project + "/envs/{envName}/releases/{releaseName}"
+	)
 
-			r.Get("/audits", s.handleListAudits)
-			r.Get("/audits/actions", s.handleAuditActions)
+	return []authorizedRoute{
+		{http.MethodGet, "/projects", "list_projects", s.handleListProjects, nil},
+		{http.MethodPost, "/projects", "create_project", s.handleCreateProject, nil},
+		{http.MethodPut, project, "update_project", s.handleUpdateProject, nil},
+		{http.MethodDelete, project, "delete_project", s.handleDeleteProject, nil},
 
-			r.Get("/users", s.handleListUsers)
-			r.Post("/users", s.handleCreateUser)
-			r.Patch("/users/{username}", s.handleUpdateUser)
-		})
+		{http.MethodGet, project + "/members", "list_members", s.handleListMembers, nil},
+		{http.MethodPost, project + "/members", "add_member", s.handleAddMember, nil},
+		{http.MethodDelete, project + "/members/{memberID}", "remove_member", s.handleRemoveMember, nil},
 
-		r.Get("/health", s.handleHealth)
-	})
+		{http.MethodGet, project + "/envs", "list_envs", s.handleListEnvironments, nil},
+
+		{http.MethodGet, project + "/envs/{envName}/releases", "list_releases", s.handleListReleases, nil},
+
+		{http.MethodDelete, release, "delete_release", s.handleDeleteRelease, nil},
+
+		{http.MethodGet, release + "/configs", "list_configs", s.handleListConfigs, nil},
+		{http.MethodPut, release + "/configs", "set_config_values", s.handleSetConfigValues, []middlewares.InputFunc{s.configChangesInput}},
+		{http.MethodPost, release + "/configs", "upsert_configs", s.handleUpsertConfigs, []middlewares.InputFunc{s.upsertPlanInput}},
+		{http.MethodGet, release + "/configs/{configKey}/history", "list_config_history", s.handleConfigHistory, nil},
+		{http.MethodPost, release + "/configs/{configKey}/revert", "set_config_values", s.handleRevertConfigValue, []middlewares.InputFunc{s.revertChangesInput}},
+
+		{http.MethodGet, "/audits", "list_audits", s.handleListAudits, nil},
+		{http.MethodGet, "/audits/actions", "list_audit_actions", s.handleAuditActions, nil},
+		{http.MethodGet, "/audits/verify", "verify_audits", s.handleVerifyAudits, nil},
+		{http.MethodPost, "/audits/import", "import_audits", s.handleImportAudits, nil},
+
+		{http.MethodPost, "/policy/eval", "eval_policy", s.handleEvalPolicy, nil},
+
+		{http.MethodGet, "/users", "list_users", s.handleListUsers, nil},
+		{http.MethodPost, "/users", "create_user", s.handleCreateUser, nil},
+		{http.MethodPatch, "/users/{username}", "update_user", s.handleUpdateUser, nil},
+		{http.MethodPost, "/users/{username}/password/reset", "reset_user_password", s.handleResetPassword, nil},
+		{http.MethodDelete, "/users/{username}/totp", "reset_user_totp", s.handleResetTOTP, nil},
+		{http.MethodGet, "/users/{username}/tokens", "list_user_tokens", s.handleListUserTokens, nil},
+		{http.MethodDelete, "/users/{username}/tokens/{tokenID}", "revoke_user_token", s.handleRevokeUserToken, nil},
+
+		{http.MethodPut, "/me/password", "change_password", s.handleChangePassword, nil},
+		{http.MethodPost, "/me/totp", "enroll_totp", s.handleEnrollTOTP, nil},
+		{http.MethodPost, "/me/totp/confirm", "confirm_totp", s.handleConfirmTOTP, nil},
+		{http.MethodDelete, "/me/totp", "disable_totp", s.handleDisableTOTP, nil},
+
+		{http.MethodGet, "/tokens", "list_tokens", s.handleListTokens, nil},
+		{http.MethodPost, "/tokens", "create_token", s.handleCreateToken, nil},
+		{http.MethodDelete, "/tokens/{tokenID}", "revoke_token", s.handleRevokeToken, nil},
+	}
+}
+
+// authorize check access to route, action name and project membership are passed to authorizer input
+func (s *Server) authorize(action string, inputs ...middlewares.InputFunc) func(http.Handler) http.Handler {
+	return middlewares.Authorize(s.authorizer, action, append([]middlewares.InputFunc{s.membershipInput}, inputs...)...)
 }
 
 func (s *Server) initUI() error {
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/DesSolo/rtc/internal/models"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+// issueSession returns short-lived access token and refresh token
+func (s *Server) issueSession(ctx context.Context, user *models.User) (*loginResponse, error) {
+	refreshToken, err := s.provider.CreateSession(ctx, user.Username)
+	if err != nil {
+		return nil, fmt.Errorf("provider.CreateSession: %w", err)
+	}
+
+	return s.encodeSession(user, refreshToken)
+}
+
+func (s *Server) encodeSession(user *models.User, refreshToken string) (*loginResponse, error) {
+	token, err := s.jwt.Encode(convertModelToAuth(user))
+	if err != nil {
+		return nil, fmt.Errorf("jwt.Encode: %w", err)
+	}
+
+	return &loginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.jwt.TTL().Seconds()),
+	}, nil
+}
+
+func (s *Server) respondSession(w http.ResponseWriter, r *http.Request, user *models.User) {
+	ctx := r.Context()
+
+	session, err := s.issueSession(ctx, user)
+	if err != nil {
+		slog.ErrorContext(ctx, "issueSession", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, session)
+}
+
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (r *refreshRequest) Validate() error {
+	if r.RefreshToken == "" {
+		return errors.New("refresh_token is required")
+	}
+
+	return nil
+}
+
+func (s *Server) handleRefresh(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req refreshRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, refreshToken, err := s.provider.RefreshSession(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.RefreshSession", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	session, err := s.encodeSession(user, refreshToken)
+	if err != nil {
+		slog.ErrorContext(ctx, "encodeSession", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, session)
+}
+
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req refreshRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.provider.DeleteSession(ctx, req.RefreshToken); err != nil {
+		if !errors.Is(err, provider.ErrNotFound) {
+			slog.ErrorContext(ctx, "provider.DeleteSession", "err", err)
+			respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+	}
+
+	respondStatus(w, http.StatusNoContent)
+}
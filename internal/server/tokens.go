@@ -0,0 +1,142 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+type apiToken struct {
+	ID         uint64     `json:"id"`
+	Name       string     `json:"name"`
+	Owner      string     `json:"owner"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type listTokensResponse struct {
+	Tokens []apiToken `json:"tokens"`
+}
+
+func (s *Server) handleListTokens(w http.ResponseWriter, r *http.Request) {
+	s.listTokens(w, r, auth.FromContext(r.Context()).Username)
+}
+
+func (s *Server) handleListUserTokens(w http.ResponseWriter, r *http.Request) {
+	s.listTokens(w, r, chi.URLParam(r, "username"))
+}
+
+func (s *Server) listTokens(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	tokens, err := s.provider.ListAPITokens(ctx, username)
+	if err != nil {
+		slog.ErrorContext(ctx, "provider.ListAPITokens", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, listTokensResponse{
+		Tokens: convertModelsToAPITokens(tokens),
+	})
+}
+
+type createTokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (r *createTokenRequest) Validate() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if r.ExpiresAt != nil && r.ExpiresAt.Before(time.Now()) {
+		return errors.New("expires_at must be in the future")
+	}
+
+	if err := auth.ValidateScopes(r.Scopes); err != nil {
+		return fmt.Errorf("scopes: %w", err)
+	}
+
+	return nil
+}
+
+type createTokenResponse struct {
+	apiToken
+	Token string `json:"token"`
+}
+
+func (s *Server) handleCreateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload := auth.FromContext(ctx)
+
+	var req createTokenRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// scoped tokens are rejected by authorize middleware, so caller has no scopes to narrow
+	secret, token, err := s.provider.CreateAPIToken(ctx, payload.Username, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusBadRequest, "api tokens are available only for local users")
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.CreateAPIToken", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondData(ctx, w, http.StatusCreated, createTokenResponse{
+		apiToken: convertModelToAPIToken(token),
+		Token:    secret,
+	})
+}
+
+func (s *Server) handleRevokeToken(w http.ResponseWriter, r *http.Request) {
+	s.revokeToken(w, r, auth.FromContext(r.Context()).Username)
+}
+
+func (s *Server) handleRevokeUserToken(w http.ResponseWriter, r *http.Request) {
+	s.revokeToken(w, r, chi.URLParam(r, "username"))
+}
+
+func (s *Server) revokeToken(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	tokenID, err := strconv.ParseUint(chi.URLParam(r, "tokenID"), 10, 64)
+	if err != nil {
+		respondError(ctx, w, http.StatusBadRequest, "invalid token id")
+		return
+	}
+
+	if err := s.provider.RevokeAPIToken(ctx, username, tokenID); err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusNotFound, "token not found")
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.RevokeAPIToken", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondStatus(w, http.StatusNoContent)
+}
@@ -0,0 +1,184 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/DesSolo/rtc/internal/auth"
+	"github.com/DesSolo/rtc/internal/provider"
+)
+
+type totpEnrollmentResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type totpCodeRequest struct {
+	OTP string `json:"otp"`
+}
+
+func (r *totpCodeRequest) Validate() error {
+	if r.OTP == "" {
+		return errors.New("otp is required")
+	}
+
+	return nil
+}
+
+type totpRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (s *Server) handleEnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	s.enrollTOTP(w, r, auth.FromContext(r.Context()).Username)
+}
+
+func (s *Server) handleConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req totpCodeRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.confirmTOTP(w, r, auth.FromContext(ctx).Username, req.OTP)
+}
+
+func (s *Server) handleDisableTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req totpCodeRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.provider.DisableTOTP(ctx, auth.FromContext(ctx).Username, req.OTP); err != nil {
+		respondTOTPError(w, r, "provider.DisableTOTP", err)
+		return
+	}
+
+	respondStatus(w, http.StatusNoContent)
+}
+
+func (s *Server) handleResetTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := s.provider.ResetTOTP(ctx, chi.URLParam(r, "username")); err != nil {
+		respondTOTPError(w, r, "provider.ResetTOTP", err)
+		return
+	}
+
+	respondStatus(w, http.StatusNoContent)
+}
+
+// handleLoginEnrollTOTP enrollment before first login of users required to use two-factor authentication
+func (s *Server) handleLoginEnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req loginRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !s.requireTOTPEnrollment(w, r, &req) {
+		return
+	}
+
+	s.enrollTOTP(w, r, req.Username)
+}
+
+// handleLoginConfirmTOTP confirm enrollment started by handleLoginEnrollTOTP
+func (s *Server) handleLoginConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req loginRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.OTP == "" {
+		respondError(ctx, w, http.StatusBadRequest, "otp is required")
+		return
+	}
+
+	if !s.requireTOTPEnrollment(w, r, &req) {
+		return
+	}
+
+	s.confirmTOTP(w, r, req.Username, req.OTP)
+}
+
+// requireTOTPEnrollment check credentials, enrollment without session is allowed only if it is required
+func (s *Server) requireTOTPEnrollment(w http.ResponseWriter, r *http.Request, req *loginRequest) bool {
+	ctx := r.Context()
+
+	_, err := s.provider.Login(ctx, provider.LoginAttempt{
+		Username: req.Username,
+		Password: req.Password,
+		ClientIP: clientIP(r),
+	})
+
+	switch {
+	case errors.Is(err, provider.ErrTwoFactorEnrollmentRequired):
+		return true
+	case err == nil:
+		respondError(ctx, w, http.StatusBadRequest, "two-factor enrollment is not required, login and use /api/v1/me/totp")
+	default:
+		s.respondLoginError(w, r, err)
+	}
+
+	return false
+}
+
+func (s *Server) enrollTOTP(w http.ResponseWriter, r *http.Request, username string) {
+	ctx := r.Context()
+
+	enrollment, err := s.provider.EnrollTOTP(ctx, username)
+	if err != nil {
+		respondTOTPError(w, r, "provider.EnrollTOTP", err)
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, convertModelToTOTPEnrollment(enrollment))
+}
+
+func (s *Server) confirmTOTP(w http.ResponseWriter, r *http.Request, username, code string) {
+	ctx := r.Context()
+
+	recoveryCodes, err := s.provider.ConfirmTOTP(ctx, username, code)
+	if err != nil {
+		respondTOTPError(w, r, "provider.ConfirmTOTP", err)
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, totpRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+func respondTOTPError(w http.ResponseWriter, r *http.Request, operation string, err error) {
+	ctx := r.Context()
+
+	switch {
+	case errors.Is(err, provider.ErrNotFound):
+		respondError(ctx, w, http.StatusNotFound, err.Error())
+	case errors.Is(err, provider.ErrAlreadyExists):
+		respondError(ctx, w, http.StatusConflict, err.Error())
+	case errors.Is(err, provider.ErrNotValid):
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+	default:
+		slog.ErrorContext(ctx, operation, "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	}
+}
@@ -3,11 +3,14 @@ package server
 import (
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/DesSolo/rtc/internal/auth"
 	"github.com/DesSolo/rtc/internal/models"
 	"github.com/DesSolo/rtc/internal/provider"
 )
@@ -15,10 +18,16 @@ import (
 type loginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// NewPassword required when password must be changed on login
+	NewPassword string `json:"new_password,omitempty"`
+	// OTP TOTP or recovery code required when two-factor authentication is enabled
+	OTP string `json:"otp,omitempty"`
 }
 
 type loginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
@@ -31,35 +40,70 @@ func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := s.provider.AuthenticateUser(ctx, req.Username, req.Password)
+	user, err := s.provider.Login(ctx, provider.LoginAttempt{
+		Username: req.Username,
+		Password: req.Password,
+		OTP:      req.OTP,
+		ClientIP: clientIP(r),
+	})
 	if err != nil {
-		if errors.Is(err, provider.ErrNotFound) {
-			respondError(ctx, w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+		s.respondLoginError(w, r, err)
+		return
+	}
+
+	if user.MustChangePassword {
+		if req.NewPassword == "" {
+			respondError(ctx, w, http.StatusForbidden, errPasswordChangeRequired)
 			return
 		}
 
-		slog.ErrorContext(ctx, "provider.AuthenticateUser", "err", err)
-		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
+		if err := s.provider.ChangePassword(ctx, req.Username, req.Password, req.NewPassword); err != nil {
+			if errors.Is(err, provider.ErrNotValid) {
+				respondError(ctx, w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			slog.ErrorContext(ctx, "provider.ChangePassword", "err", err)
+			respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
 	}
 
-	token, err := s.jwt.Encode(convertModelToAuth(user))
-	if err != nil {
-		slog.ErrorContext(ctx, "auth.Encode", "err", err)
+	s.respondSession(w, r, user)
+}
+
+// errPasswordChangeRequired returned on login with one-time password
+const errPasswordChangeRequired = "password change required"
+
+func (s *Server) respondLoginError(w http.ResponseWriter, r *http.Request, err error) {
+	ctx := r.Context()
+
+	var lockedErr *provider.LockedError
+
+	switch {
+	case errors.Is(err, provider.ErrNotFound):
+		respondError(ctx, w, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
+	case errors.As(err, &lockedErr):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		respondError(ctx, w, http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+	case errors.Is(err, provider.ErrTwoFactorRequired):
+		respondError(ctx, w, http.StatusForbidden, provider.ErrTwoFactorRequired.Error())
+	case errors.Is(err, provider.ErrTwoFactorEnrollmentRequired):
+		respondError(ctx, w, http.StatusForbidden, provider.ErrTwoFactorEnrollmentRequired.Error())
+	default:
+		slog.ErrorContext(ctx, "provider.Login", "err", err)
 		respondError(ctx, w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
-		return
 	}
-
-	respondData(ctx, w, http.StatusOK, loginResponse{
-		Token: token,
-	})
 }
 
 type user struct {
-	Username  string    `json:"username"`
-	IsEnabled bool      `json:"is_enabled"`
-	Roles     []string  `json:"roles"`
-	CreatedAt time.Time `json:"created_at"`
+	Username           string    `json:"username"`
+	IsEnabled          bool      `json:"is_enabled"`
+	Roles              []string  `json:"roles"`
+	MustChangePassword bool      `json:"must_change_password"`
+	Source             string    `json:"source"`
+	TwoFactorEnabled   bool      `json:"two_factor_enabled"`
+	CreatedAt          time.Time `json:"created_at"`
 }
 
 type listUsersResponse struct {
@@ -103,10 +147,6 @@ func (r *createUserRequest) Validate() error {
 		return errors.New("password is required")
 	}
 
-	if len(r.Password) < 8 {
-		return errors.New("password must be at least 8 characters")
-	}
-
 	return nil
 }
 
@@ -132,6 +172,11 @@ func (s *Server) handleCreateUser(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, provider.ErrNotValid) {
+			respondError(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		slog.ErrorContext(ctx, "provider.CreateUser", "err", err)
 		respondError(ctx, w, http.StatusInternalServerError, err.Error())
 		return
@@ -173,3 +218,73 @@ func (s *Server) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 
 	respondStatus(w, http.StatusOK)
 }
+
+type changePasswordRequest struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+func (r *changePasswordRequest) Validate() error {
+	if r.OldPassword == "" {
+		return errors.New("old_password is required")
+	}
+
+	if r.NewPassword == "" {
+		return errors.New("new_password is required")
+	}
+
+	return nil
+}
+
+func (s *Server) handleChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req changePasswordRequest
+	if err := bindJSON(r, &req); err != nil {
+		slog.ErrorContext(ctx, "bindJSON", "err", err)
+		respondError(ctx, w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.provider.ChangePassword(ctx, auth.FromContext(ctx).Username, req.OldPassword, req.NewPassword); err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusForbidden, "invalid old password")
+			return
+		}
+
+		if errors.Is(err, provider.ErrNotValid) {
+			respondError(ctx, w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.ChangePassword", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondStatus(w, http.StatusNoContent)
+}
+
+type resetPasswordResponse struct {
+	Password string `json:"password"`
+}
+
+func (s *Server) handleResetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	password, err := s.provider.ResetPassword(ctx, chi.URLParam(r, "username"))
+	if err != nil {
+		if errors.Is(err, provider.ErrNotFound) {
+			respondError(ctx, w, http.StatusNotFound, err.Error())
+			return
+		}
+
+		slog.ErrorContext(ctx, "provider.ResetPassword", "err", err)
+		respondError(ctx, w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondData(ctx, w, http.StatusOK, resetPasswordResponse{
+		Password: password,
+	})
+}
@@ -6,12 +6,12 @@ import (
 	"fmt"
 )
 
-// GetOrCreateEnvironment ...
-func GetOrCreateEnvironment(ctx context.Context, s Storage, projectID uint64, name string) (*Environment, error) {
+// GetOrCreateEnvironment created is true if environment did not exist
+func GetOrCreateEnvironment(ctx context.Context, s Storage, projectID uint64, name string) (*Environment, bool, error) {
 	environment, err := s.Environment(ctx, projectID, name)
 	if err != nil {
 		if !errors.Is(err, ErrNotFound) {
-			return nil, fmt.Errorf("s.Environment: %w", err)
+			return nil, false, fmt.Errorf("s.Environment: %w", err)
 		}
 
 		environment = &Environment{
@@ -19,21 +19,21 @@ func GetOrCreateEnvironment(ctx context.Context, s Storage, projectID uint64, na
 			Name:      name,
 		}
 		if err := s.CreateEnvironment(ctx, environment); err != nil {
-			return nil, fmt.Errorf("s.CreateEnvironment: %w", err)
+			return nil, false, fmt.Errorf("s.CreateEnvironment: %w", err)
 		}
 
-		return environment, nil
+		return environment, true, nil
 	}
 
-	return environment, nil
+	return environment, false, nil
 }
 
-// GetOrCreateRelease ...
-func GetOrCreateRelease(ctx context.Context, s Storage, envID uint64, name string) (*Release, error) {
+// GetOrCreateRelease created is true if release did not exist
+func GetOrCreateRelease(ctx context.Context, s Storage, envID uint64, name string) (*Release, bool, error) {
 	release, err := s.Release(ctx, envID, name)
 	if err != nil {
 		if !errors.Is(err, ErrNotFound) {
-			return nil, fmt.Errorf("s.Release: %w", err)
+			return nil, false, fmt.Errorf("s.Release: %w", err)
 		}
 
 		release = &Release{
@@ -41,11 +41,11 @@ func GetOrCreateRelease(ctx context.Context, s Storage, envID uint64, name strin
 			Name:          name,
 		}
 		if err := s.CreateRelease(ctx, release); err != nil {
-			return nil, fmt.Errorf("s.CreateRelease: %w", err)
+			return nil, false, fmt.Errorf("s.CreateRelease: %w", err)
 		}
 
-		return release, nil
+		return release, true, nil
 	}
 
-	return release, nil
+	return release, false, nil
 }
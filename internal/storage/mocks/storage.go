@@ -38,44 +38,55 @@ func (_m *MockStorage) EXPECT() *MockStorage_Expecter {
 	return &MockStorage_Expecter{mock: &_m.Mock}
 }
 
-// AddAuditRecord provides a mock function for the type MockStorage
-func (_mock *MockStorage) AddAuditRecord(ctx context.Context, audit *storage.Audit) error {
-	ret := _mock.Called(ctx, audit)
+// APITokenByHash provides a mock function for the type MockStorage
+func (_mock *MockStorage) APITokenByHash(ctx context.Context, tokenHash string) (*storage.APIToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddAuditRecord")
+		panic("no return value specified for APITokenByHash")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Audit) error); ok {
-		r0 = returnFunc(ctx, audit)
+	var r0 *storage.APIToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*storage.APIToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *storage.APIToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.APIToken)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_AddAuditRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditRecord'
-type MockStorage_AddAuditRecord_Call struct {
+// MockStorage_APITokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APITokenByHash'
+type MockStorage_APITokenByHash_Call struct {
 	*mock.Call
 }
 
-// AddAuditRecord is a helper method to define mock.On call
+// APITokenByHash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - audit *storage.Audit
-func (_e *MockStorage_Expecter) AddAuditRecord(ctx interface{}, audit interface{}) *MockStorage_AddAuditRecord_Call {
-	return &MockStorage_AddAuditRecord_Call{Call: _e.mock.On("AddAuditRecord", ctx, audit)}
+//   - tokenHash string
+func (_e *MockStorage_Expecter) APITokenByHash(ctx interface{}, tokenHash interface{}) *MockStorage_APITokenByHash_Call {
+	return &MockStorage_APITokenByHash_Call{Call: _e.mock.On("APITokenByHash", ctx, tokenHash)}
 }
 
-func (_c *MockStorage_AddAuditRecord_Call) Run(run func(ctx context.Context, audit *storage.Audit)) *MockStorage_AddAuditRecord_Call {
+func (_c *MockStorage_APITokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockStorage_APITokenByHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *storage.Audit
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*storage.Audit)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -85,65 +96,65 @@ func (_c *MockStorage_AddAuditRecord_Call) Run(run func(ctx context.Context, aud
 	return _c
 }
 
-func (_c *MockStorage_AddAuditRecord_Call) Return(err error) *MockStorage_AddAuditRecord_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_APITokenByHash_Call) Return(aPIToken *storage.APIToken, err error) *MockStorage_APITokenByHash_Call {
+	_c.Call.Return(aPIToken, err)
 	return _c
 }
 
-func (_c *MockStorage_AddAuditRecord_Call) RunAndReturn(run func(ctx context.Context, audit *storage.Audit) error) *MockStorage_AddAuditRecord_Call {
+func (_c *MockStorage_APITokenByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*storage.APIToken, error)) *MockStorage_APITokenByHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AuditsSearch provides a mock function for the type MockStorage
-func (_mock *MockStorage) AuditsSearch(ctx context.Context, filter storage.AuditFilter) ([]*storage.Audit, error) {
-	ret := _mock.Called(ctx, filter)
+// APITokens provides a mock function for the type MockStorage
+func (_mock *MockStorage) APITokens(ctx context.Context, username string) ([]*storage.APIToken, error) {
+	ret := _mock.Called(ctx, username)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AuditsSearch")
+		panic("no return value specified for APITokens")
 	}
 
-	var r0 []*storage.Audit
+	var r0 []*storage.APIToken
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, storage.AuditFilter) ([]*storage.Audit, error)); ok {
-		return returnFunc(ctx, filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*storage.APIToken, error)); ok {
+		return returnFunc(ctx, username)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, storage.AuditFilter) []*storage.Audit); ok {
-		r0 = returnFunc(ctx, filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*storage.APIToken); ok {
+		r0 = returnFunc(ctx, username)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Audit)
+			r0 = ret.Get(0).([]*storage.APIToken)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, storage.AuditFilter) error); ok {
-		r1 = returnFunc(ctx, filter)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_AuditsSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditsSearch'
-type MockStorage_AuditsSearch_Call struct {
+// MockStorage_APITokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'APITokens'
+type MockStorage_APITokens_Call struct {
 	*mock.Call
 }
 
-// AuditsSearch is a helper method to define mock.On call
+// APITokens is a helper method to define mock.On call
 //   - ctx context.Context
-//   - filter storage.AuditFilter
-func (_e *MockStorage_Expecter) AuditsSearch(ctx interface{}, filter interface{}) *MockStorage_AuditsSearch_Call {
-	return &MockStorage_AuditsSearch_Call{Call: _e.mock.On("AuditsSearch", ctx, filter)}
+//   - username string
+func (_e *MockStorage_Expecter) APITokens(ctx interface{}, username interface{}) *MockStorage_APITokens_Call {
+	return &MockStorage_APITokens_Call{Call: _e.mock.On("APITokens", ctx, username)}
 }
 
-func (_c *MockStorage_AuditsSearch_Call) Run(run func(ctx context.Context, filter storage.AuditFilter)) *MockStorage_AuditsSearch_Call {
+func (_c *MockStorage_APITokens_Call) Run(run func(ctx context.Context, username string)) *MockStorage_APITokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 storage.AuditFilter
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(storage.AuditFilter)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -153,407 +164,1907 @@ func (_c *MockStorage_AuditsSearch_Call) Run(run func(ctx context.Context, filte
 	return _c
 }
 
-func (_c *MockStorage_AuditsSearch_Call) Return(audits []*storage.Audit, err error) *MockStorage_AuditsSearch_Call {
-	_c.Call.Return(audits, err)
+func (_c *MockStorage_APITokens_Call) Return(aPITokens []*storage.APIToken, err error) *MockStorage_APITokens_Call {
+	_c.Call.Return(aPITokens, err)
 	return _c
 }
 
-func (_c *MockStorage_AuditsSearch_Call) RunAndReturn(run func(ctx context.Context, filter storage.AuditFilter) ([]*storage.Audit, error)) *MockStorage_AuditsSearch_Call {
+func (_c *MockStorage_APITokens_Call) RunAndReturn(run func(ctx context.Context, username string) ([]*storage.APIToken, error)) *MockStorage_APITokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Close provides a mock function for the type MockStorage
-func (_mock *MockStorage) Close() error {
-	ret := _mock.Called()
+// AddAuditCheckpoint provides a mock function for the type MockStorage
+func (_mock *MockStorage) AddAuditCheckpoint(ctx context.Context, checkpoint *storage.AuditCheckpoint) error {
+	ret := _mock.Called(ctx, checkpoint)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Close")
+		panic("no return value specified for AddAuditCheckpoint")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func() error); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.AuditCheckpoint) error); ok {
+		r0 = returnFunc(ctx, checkpoint)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
-type MockStorage_Close_Call struct {
+// MockStorage_AddAuditCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditCheckpoint'
+type MockStorage_AddAuditCheckpoint_Call struct {
 	*mock.Call
 }
 
-// Close is a helper method to define mock.On call
-func (_e *MockStorage_Expecter) Close() *MockStorage_Close_Call {
-	return &MockStorage_Close_Call{Call: _e.mock.On("Close")}
+// AddAuditCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - checkpoint *storage.AuditCheckpoint
+func (_e *MockStorage_Expecter) AddAuditCheckpoint(ctx interface{}, checkpoint interface{}) *MockStorage_AddAuditCheckpoint_Call {
+	return &MockStorage_AddAuditCheckpoint_Call{Call: _e.mock.On("AddAuditCheckpoint", ctx, checkpoint)}
 }
 
-func (_c *MockStorage_Close_Call) Run(run func()) *MockStorage_Close_Call {
+func (_c *MockStorage_AddAuditCheckpoint_Call) Run(run func(ctx context.Context, checkpoint *storage.AuditCheckpoint)) *MockStorage_AddAuditCheckpoint_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.AuditCheckpoint
+		if args[1] != nil {
+			arg1 = args[1].(*storage.AuditCheckpoint)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_Close_Call) Return(err error) *MockStorage_Close_Call {
+func (_c *MockStorage_AddAuditCheckpoint_Call) Return(err error) *MockStorage_AddAuditCheckpoint_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_Close_Call) RunAndReturn(run func() error) *MockStorage_Close_Call {
+func (_c *MockStorage_AddAuditCheckpoint_Call) RunAndReturn(run func(ctx context.Context, checkpoint *storage.AuditCheckpoint) error) *MockStorage_AddAuditCheckpoint_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Config provides a mock function for the type MockStorage
-func (_mock *MockStorage) Config(ctx context.Context, projectName string, envName string, releaseName string, key string) (*storage.Config, error) {
-	ret := _mock.Called(ctx, projectName, envName, releaseName, key)
+// AddAuditRecord provides a mock function for the type MockStorage
+func (_mock *MockStorage) AddAuditRecord(ctx context.Context, audit *storage.Audit) error {
+	ret := _mock.Called(ctx, audit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Config")
+		panic("no return value specified for AddAuditRecord")
 	}
 
-	var r0 *storage.Config
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*storage.Config, error)); ok {
-		return returnFunc(ctx, projectName, envName, releaseName, key)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *storage.Config); ok {
-		r0 = returnFunc(ctx, projectName, envName, releaseName, key)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.Config)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
-		r1 = returnFunc(ctx, projectName, envName, releaseName, key)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Audit) error); ok {
+		r0 = returnFunc(ctx, audit)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_Config_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Config'
-type MockStorage_Config_Call struct {
+// MockStorage_AddAuditRecord_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuditRecord'
+type MockStorage_AddAuditRecord_Call struct {
 	*mock.Call
 }
 
-// Config is a helper method to define mock.On call
+// AddAuditRecord is a helper method to define mock.On call
 //   - ctx context.Context
-//   - projectName string
-//   - envName string
-//   - releaseName string
-//   - key string
-func (_e *MockStorage_Expecter) Config(ctx interface{}, projectName interface{}, envName interface{}, releaseName interface{}, key interface{}) *MockStorage_Config_Call {
-	return &MockStorage_Config_Call{Call: _e.mock.On("Config", ctx, projectName, envName, releaseName, key)}
+//   - audit *storage.Audit
+func (_e *MockStorage_Expecter) AddAuditRecord(ctx interface{}, audit interface{}) *MockStorage_AddAuditRecord_Call {
+	return &MockStorage_AddAuditRecord_Call{Call: _e.mock.On("AddAuditRecord", ctx, audit)}
 }
 
-func (_c *MockStorage_Config_Call) Run(run func(ctx context.Context, projectName string, envName string, releaseName string, key string)) *MockStorage_Config_Call {
+func (_c *MockStorage_AddAuditRecord_Call) Run(run func(ctx context.Context, audit *storage.Audit)) *MockStorage_AddAuditRecord_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *storage.Audit
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
+			arg1 = args[1].(*storage.Audit)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_Config_Call) Return(config *storage.Config, err error) *MockStorage_Config_Call {
-	_c.Call.Return(config, err)
+func (_c *MockStorage_AddAuditRecord_Call) Return(err error) *MockStorage_AddAuditRecord_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_Config_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string, releaseName string, key string) (*storage.Config, error)) *MockStorage_Config_Call {
+func (_c *MockStorage_AddAuditRecord_Call) RunAndReturn(run func(ctx context.Context, audit *storage.Audit) error) *MockStorage_AddAuditRecord_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Configs provides a mock function for the type MockStorage
-func (_mock *MockStorage) Configs(ctx context.Context, projectName string, envName string, releaseName string) ([]*storage.Config, error) {
-	ret := _mock.Called(ctx, projectName, envName, releaseName)
+// Audit provides a mock function for the type MockStorage
+func (_mock *MockStorage) Audit(ctx context.Context, id uint64) (*storage.Audit, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Configs")
+		panic("no return value specified for Audit")
 	}
 
-	var r0 []*storage.Config
+	var r0 *storage.Audit
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) ([]*storage.Config, error)); ok {
-		return returnFunc(ctx, projectName, envName, releaseName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (*storage.Audit, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) []*storage.Config); ok {
-		r0 = returnFunc(ctx, projectName, envName, releaseName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) *storage.Audit); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Config)
+			r0 = ret.Get(0).(*storage.Audit)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, projectName, envName, releaseName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_Configs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Configs'
-type MockStorage_Configs_Call struct {
+// MockStorage_Audit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Audit'
+type MockStorage_Audit_Call struct {
 	*mock.Call
 }
 
-// Configs is a helper method to define mock.On call
+// Audit is a helper method to define mock.On call
 //   - ctx context.Context
-//   - projectName string
-//   - envName string
-//   - releaseName string
-func (_e *MockStorage_Expecter) Configs(ctx interface{}, projectName interface{}, envName interface{}, releaseName interface{}) *MockStorage_Configs_Call {
-	return &MockStorage_Configs_Call{Call: _e.mock.On("Configs", ctx, projectName, envName, releaseName)}
+//   - id uint64
+func (_e *MockStorage_Expecter) Audit(ctx interface{}, id interface{}) *MockStorage_Audit_Call {
+	return &MockStorage_Audit_Call{Call: _e.mock.On("Audit", ctx, id)}
 }
 
-func (_c *MockStorage_Configs_Call) Run(run func(ctx context.Context, projectName string, envName string, releaseName string)) *MockStorage_Configs_Call {
+func (_c *MockStorage_Audit_Call) Run(run func(ctx context.Context, id uint64)) *MockStorage_Audit_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uint64
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg1 = args[1].(uint64)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_Configs_Call) Return(configs []*storage.Config, err error) *MockStorage_Configs_Call {
-	_c.Call.Return(configs, err)
+func (_c *MockStorage_Audit_Call) Return(audit *storage.Audit, err error) *MockStorage_Audit_Call {
+	_c.Call.Return(audit, err)
 	return _c
 }
 
-func (_c *MockStorage_Configs_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string, releaseName string) ([]*storage.Config, error)) *MockStorage_Configs_Call {
+func (_c *MockStorage_Audit_Call) RunAndReturn(run func(ctx context.Context, id uint64) (*storage.Audit, error)) *MockStorage_Audit_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ConfigsByKeys provides a mock function for the type MockStorage
-func (_mock *MockStorage) ConfigsByKeys(ctx context.Context, projectName string, envName string, releaseName string, keys []string) ([]*storage.Config, error) {
-	ret := _mock.Called(ctx, projectName, envName, releaseName, keys)
+// AuditChain provides a mock function for the type MockStorage
+func (_mock *MockStorage) AuditChain(ctx context.Context, afterID uint64, limit uint64) ([]*storage.Audit, error) {
+	ret := _mock.Called(ctx, afterID, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ConfigsByKeys")
+		panic("no return value specified for AuditChain")
 	}
 
-	var r0 []*storage.Config
+	var r0 []*storage.Audit
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []string) ([]*storage.Config, error)); ok {
-		return returnFunc(ctx, projectName, envName, releaseName, keys)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) ([]*storage.Audit, error)); ok {
+		return returnFunc(ctx, afterID, limit)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []string) []*storage.Config); ok {
-		r0 = returnFunc(ctx, projectName, envName, releaseName, keys)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) []*storage.Audit); ok {
+		r0 = returnFunc(ctx, afterID, limit)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Config)
+			r0 = ret.Get(0).([]*storage.Audit)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
-		r1 = returnFunc(ctx, projectName, envName, releaseName, keys)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, afterID, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_ConfigsByKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigsByKeys'
-type MockStorage_ConfigsByKeys_Call struct {
+// MockStorage_AuditChain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditChain'
+type MockStorage_AuditChain_Call struct {
 	*mock.Call
 }
 
-// ConfigsByKeys is a helper method to define mock.On call
+// AuditChain is a helper method to define mock.On call
 //   - ctx context.Context
-//   - projectName string
-//   - envName string
-//   - releaseName string
-//   - keys []string
-func (_e *MockStorage_Expecter) ConfigsByKeys(ctx interface{}, projectName interface{}, envName interface{}, releaseName interface{}, keys interface{}) *MockStorage_ConfigsByKeys_Call {
-	return &MockStorage_ConfigsByKeys_Call{Call: _e.mock.On("ConfigsByKeys", ctx, projectName, envName, releaseName, keys)}
+//   - afterID uint64
+//   - limit uint64
+func (_e *MockStorage_Expecter) AuditChain(ctx interface{}, afterID interface{}, limit interface{}) *MockStorage_AuditChain_Call {
+	return &MockStorage_AuditChain_Call{Call: _e.mock.On("AuditChain", ctx, afterID, limit)}
 }
 
-func (_c *MockStorage_ConfigsByKeys_Call) Run(run func(ctx context.Context, projectName string, envName string, releaseName string, keys []string)) *MockStorage_ConfigsByKeys_Call {
+func (_c *MockStorage_AuditChain_Call) Run(run func(ctx context.Context, afterID uint64, limit uint64)) *MockStorage_AuditChain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uint64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(uint64)
 		}
-		var arg2 string
+		var arg2 uint64
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 []string
-		if args[4] != nil {
-			arg4 = args[4].([]string)
+			arg2 = args[2].(uint64)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_ConfigsByKeys_Call) Return(configs []*storage.Config, err error) *MockStorage_ConfigsByKeys_Call {
-	_c.Call.Return(configs, err)
+func (_c *MockStorage_AuditChain_Call) Return(audits []*storage.Audit, err error) *MockStorage_AuditChain_Call {
+	_c.Call.Return(audits, err)
 	return _c
 }
 
-func (_c *MockStorage_ConfigsByKeys_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string, releaseName string, keys []string) ([]*storage.Config, error)) *MockStorage_ConfigsByKeys_Call {
+func (_c *MockStorage_AuditChain_Call) RunAndReturn(run func(ctx context.Context, afterID uint64, limit uint64) ([]*storage.Audit, error)) *MockStorage_AuditChain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateEnvironment provides a mock function for the type MockStorage
-func (_mock *MockStorage) CreateEnvironment(ctx context.Context, env *storage.Environment) error {
-	ret := _mock.Called(ctx, env)
+// AuditCheckpoints provides a mock function for the type MockStorage
+func (_mock *MockStorage) AuditCheckpoints(ctx context.Context) ([]*storage.AuditCheckpoint, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateEnvironment")
+		panic("no return value specified for AuditCheckpoints")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Environment) error); ok {
-		r0 = returnFunc(ctx, env)
+	var r0 []*storage.AuditCheckpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*storage.AuditCheckpoint, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*storage.AuditCheckpoint); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.AuditCheckpoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_AuditCheckpoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditCheckpoints'
+type MockStorage_AuditCheckpoints_Call struct {
+	*mock.Call
+}
+
+// AuditCheckpoints is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) AuditCheckpoints(ctx interface{}) *MockStorage_AuditCheckpoints_Call {
+	return &MockStorage_AuditCheckpoints_Call{Call: _e.mock.On("AuditCheckpoints", ctx)}
+}
+
+func (_c *MockStorage_AuditCheckpoints_Call) Run(run func(ctx context.Context)) *MockStorage_AuditCheckpoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_AuditCheckpoints_Call) Return(auditCheckpoints []*storage.AuditCheckpoint, err error) *MockStorage_AuditCheckpoints_Call {
+	_c.Call.Return(auditCheckpoints, err)
+	return _c
+}
+
+func (_c *MockStorage_AuditCheckpoints_Call) RunAndReturn(run func(ctx context.Context) ([]*storage.AuditCheckpoint, error)) *MockStorage_AuditCheckpoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuditSinkCursor provides a mock function for the type MockStorage
+func (_mock *MockStorage) AuditSinkCursor(ctx context.Context, name string) (uint64, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditSinkCursor")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_AuditSinkCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditSinkCursor'
+type MockStorage_AuditSinkCursor_Call struct {
+	*mock.Call
+}
+
+// AuditSinkCursor is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockStorage_Expecter) AuditSinkCursor(ctx interface{}, name interface{}) *MockStorage_AuditSinkCursor_Call {
+	return &MockStorage_AuditSinkCursor_Call{Call: _e.mock.On("AuditSinkCursor", ctx, name)}
+}
+
+func (_c *MockStorage_AuditSinkCursor_Call) Run(run func(ctx context.Context, name string)) *MockStorage_AuditSinkCursor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_AuditSinkCursor_Call) Return(v uint64, err error) *MockStorage_AuditSinkCursor_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockStorage_AuditSinkCursor_Call) RunAndReturn(run func(ctx context.Context, name string) (uint64, error)) *MockStorage_AuditSinkCursor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AuditsSearch provides a mock function for the type MockStorage
+func (_mock *MockStorage) AuditsSearch(ctx context.Context, filter storage.AuditFilter) ([]*storage.Audit, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditsSearch")
+	}
+
+	var r0 []*storage.Audit
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, storage.AuditFilter) ([]*storage.Audit, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, storage.AuditFilter) []*storage.Audit); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Audit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, storage.AuditFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_AuditsSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AuditsSearch'
+type MockStorage_AuditsSearch_Call struct {
+	*mock.Call
+}
+
+// AuditsSearch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter storage.AuditFilter
+func (_e *MockStorage_Expecter) AuditsSearch(ctx interface{}, filter interface{}) *MockStorage_AuditsSearch_Call {
+	return &MockStorage_AuditsSearch_Call{Call: _e.mock.On("AuditsSearch", ctx, filter)}
+}
+
+func (_c *MockStorage_AuditsSearch_Call) Run(run func(ctx context.Context, filter storage.AuditFilter)) *MockStorage_AuditsSearch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 storage.AuditFilter
+		if args[1] != nil {
+			arg1 = args[1].(storage.AuditFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_AuditsSearch_Call) Return(audits []*storage.Audit, err error) *MockStorage_AuditsSearch_Call {
+	_c.Call.Return(audits, err)
+	return _c
+}
+
+func (_c *MockStorage_AuditsSearch_Call) RunAndReturn(run func(ctx context.Context, filter storage.AuditFilter) ([]*storage.Audit, error)) *MockStorage_AuditsSearch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Close provides a mock function for the type MockStorage
+func (_mock *MockStorage) Close() error {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func() error); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockStorage_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockStorage_Expecter) Close() *MockStorage_Close_Call {
+	return &MockStorage_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockStorage_Close_Call) Run(run func()) *MockStorage_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStorage_Close_Call) Return(err error) *MockStorage_Close_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_Close_Call) RunAndReturn(run func() error) *MockStorage_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Config provides a mock function for the type MockStorage
+func (_mock *MockStorage) Config(ctx context.Context, projectName string, envName string, releaseName string, key string) (*storage.Config, error) {
+	ret := _mock.Called(ctx, projectName, envName, releaseName, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Config")
+	}
+
+	var r0 *storage.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (*storage.Config, error)); ok {
+		return returnFunc(ctx, projectName, envName, releaseName, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) *storage.Config); ok {
+		r0 = returnFunc(ctx, projectName, envName, releaseName, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Config)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, projectName, envName, releaseName, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Config_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Config'
+type MockStorage_Config_Call struct {
+	*mock.Call
+}
+
+// Config is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectName string
+//   - envName string
+//   - releaseName string
+//   - key string
+func (_e *MockStorage_Expecter) Config(ctx interface{}, projectName interface{}, envName interface{}, releaseName interface{}, key interface{}) *MockStorage_Config_Call {
+	return &MockStorage_Config_Call{Call: _e.mock.On("Config", ctx, projectName, envName, releaseName, key)}
+}
+
+func (_c *MockStorage_Config_Call) Run(run func(ctx context.Context, projectName string, envName string, releaseName string, key string)) *MockStorage_Config_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Config_Call) Return(config *storage.Config, err error) *MockStorage_Config_Call {
+	_c.Call.Return(config, err)
+	return _c
+}
+
+func (_c *MockStorage_Config_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string, releaseName string, key string) (*storage.Config, error)) *MockStorage_Config_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Configs provides a mock function for the type MockStorage
+func (_mock *MockStorage) Configs(ctx context.Context, projectName string, envName string, releaseName string) ([]*storage.Config, error) {
+	ret := _mock.Called(ctx, projectName, envName, releaseName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Configs")
+	}
+
+	var r0 []*storage.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) ([]*storage.Config, error)); ok {
+		return returnFunc(ctx, projectName, envName, releaseName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) []*storage.Config); ok {
+		r0 = returnFunc(ctx, projectName, envName, releaseName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Config)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, projectName, envName, releaseName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Configs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Configs'
+type MockStorage_Configs_Call struct {
+	*mock.Call
+}
+
+// Configs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectName string
+//   - envName string
+//   - releaseName string
+func (_e *MockStorage_Expecter) Configs(ctx interface{}, projectName interface{}, envName interface{}, releaseName interface{}) *MockStorage_Configs_Call {
+	return &MockStorage_Configs_Call{Call: _e.mock.On("Configs", ctx, projectName, envName, releaseName)}
+}
+
+func (_c *MockStorage_Configs_Call) Run(run func(ctx context.Context, projectName string, envName string, releaseName string)) *MockStorage_Configs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Configs_Call) Return(configs []*storage.Config, err error) *MockStorage_Configs_Call {
+	_c.Call.Return(configs, err)
+	return _c
+}
+
+func (_c *MockStorage_Configs_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string, releaseName string) ([]*storage.Config, error)) *MockStorage_Configs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfigsByKeys provides a mock function for the type MockStorage
+func (_mock *MockStorage) ConfigsByKeys(ctx context.Context, projectName string, envName string, releaseName string, keys []string) ([]*storage.Config, error) {
+	ret := _mock.Called(ctx, projectName, envName, releaseName, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfigsByKeys")
+	}
+
+	var r0 []*storage.Config
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []string) ([]*storage.Config, error)); ok {
+		return returnFunc(ctx, projectName, envName, releaseName, keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, []string) []*storage.Config); ok {
+		r0 = returnFunc(ctx, projectName, envName, releaseName, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Config)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, []string) error); ok {
+		r1 = returnFunc(ctx, projectName, envName, releaseName, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ConfigsByKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfigsByKeys'
+type MockStorage_ConfigsByKeys_Call struct {
+	*mock.Call
+}
+
+// ConfigsByKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectName string
+//   - envName string
+//   - releaseName string
+//   - keys []string
+func (_e *MockStorage_Expecter) ConfigsByKeys(ctx interface{}, projectName interface{}, envName interface{}, releaseName interface{}, keys interface{}) *MockStorage_ConfigsByKeys_Call {
+	return &MockStorage_ConfigsByKeys_Call{Call: _e.mock.On("ConfigsByKeys", ctx, projectName, envName, releaseName, keys)}
+}
+
+func (_c *MockStorage_ConfigsByKeys_Call) Run(run func(ctx context.Context, projectName string, envName string, releaseName string, keys []string)) *MockStorage_ConfigsByKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 []string
+		if args[4] != nil {
+			arg4 = args[4].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ConfigsByKeys_Call) Return(configs []*storage.Config, err error) *MockStorage_ConfigsByKeys_Call {
+	_c.Call.Return(configs, err)
+	return _c
+}
+
+func (_c *MockStorage_ConfigsByKeys_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string, releaseName string, keys []string) ([]*storage.Config, error)) *MockStorage_ConfigsByKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAPIToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateAPIToken(ctx context.Context, token *storage.APIToken) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.APIToken) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAPIToken'
+type MockStorage_CreateAPIToken_Call struct {
+	*mock.Call
+}
+
+// CreateAPIToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *storage.APIToken
+func (_e *MockStorage_Expecter) CreateAPIToken(ctx interface{}, token interface{}) *MockStorage_CreateAPIToken_Call {
+	return &MockStorage_CreateAPIToken_Call{Call: _e.mock.On("CreateAPIToken", ctx, token)}
+}
+
+func (_c *MockStorage_CreateAPIToken_Call) Run(run func(ctx context.Context, token *storage.APIToken)) *MockStorage_CreateAPIToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.APIToken
+		if args[1] != nil {
+			arg1 = args[1].(*storage.APIToken)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateAPIToken_Call) Return(err error) *MockStorage_CreateAPIToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateAPIToken_Call) RunAndReturn(run func(ctx context.Context, token *storage.APIToken) error) *MockStorage_CreateAPIToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEnvironment provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateEnvironment(ctx context.Context, env *storage.Environment) error {
+	ret := _mock.Called(ctx, env)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEnvironment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Environment) error); ok {
+		r0 = returnFunc(ctx, env)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateEnvironment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEnvironment'
+type MockStorage_CreateEnvironment_Call struct {
+	*mock.Call
+}
+
+// CreateEnvironment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - env *storage.Environment
+func (_e *MockStorage_Expecter) CreateEnvironment(ctx interface{}, env interface{}) *MockStorage_CreateEnvironment_Call {
+	return &MockStorage_CreateEnvironment_Call{Call: _e.mock.On("CreateEnvironment", ctx, env)}
+}
+
+func (_c *MockStorage_CreateEnvironment_Call) Run(run func(ctx context.Context, env *storage.Environment)) *MockStorage_CreateEnvironment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.Environment
+		if args[1] != nil {
+			arg1 = args[1].(*storage.Environment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateEnvironment_Call) Return(err error) *MockStorage_CreateEnvironment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateEnvironment_Call) RunAndReturn(run func(ctx context.Context, env *storage.Environment) error) *MockStorage_CreateEnvironment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateProject provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateProject(ctx context.Context, project *storage.Project) error {
+	ret := _mock.Called(ctx, project)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProject")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Project) error); ok {
+		r0 = returnFunc(ctx, project)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProject'
+type MockStorage_CreateProject_Call struct {
+	*mock.Call
+}
+
+// CreateProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - project *storage.Project
+func (_e *MockStorage_Expecter) CreateProject(ctx interface{}, project interface{}) *MockStorage_CreateProject_Call {
+	return &MockStorage_CreateProject_Call{Call: _e.mock.On("CreateProject", ctx, project)}
+}
+
+func (_c *MockStorage_CreateProject_Call) Run(run func(ctx context.Context, project *storage.Project)) *MockStorage_CreateProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.Project
+		if args[1] != nil {
+			arg1 = args[1].(*storage.Project)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateProject_Call) Return(err error) *MockStorage_CreateProject_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateProject_Call) RunAndReturn(run func(ctx context.Context, project *storage.Project) error) *MockStorage_CreateProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRefreshToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateRefreshToken(ctx context.Context, token *storage.RefreshToken) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRefreshToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.RefreshToken) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRefreshToken'
+type MockStorage_CreateRefreshToken_Call struct {
+	*mock.Call
+}
+
+// CreateRefreshToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - token *storage.RefreshToken
+func (_e *MockStorage_Expecter) CreateRefreshToken(ctx interface{}, token interface{}) *MockStorage_CreateRefreshToken_Call {
+	return &MockStorage_CreateRefreshToken_Call{Call: _e.mock.On("CreateRefreshToken", ctx, token)}
+}
+
+func (_c *MockStorage_CreateRefreshToken_Call) Run(run func(ctx context.Context, token *storage.RefreshToken)) *MockStorage_CreateRefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.RefreshToken
+		if args[1] != nil {
+			arg1 = args[1].(*storage.RefreshToken)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateRefreshToken_Call) Return(err error) *MockStorage_CreateRefreshToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateRefreshToken_Call) RunAndReturn(run func(ctx context.Context, token *storage.RefreshToken) error) *MockStorage_CreateRefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRelease provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateRelease(ctx context.Context, release *storage.Release) error {
+	ret := _mock.Called(ctx, release)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRelease")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Release) error); ok {
+		r0 = returnFunc(ctx, release)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRelease'
+type MockStorage_CreateRelease_Call struct {
+	*mock.Call
+}
+
+// CreateRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - release *storage.Release
+func (_e *MockStorage_Expecter) CreateRelease(ctx interface{}, release interface{}) *MockStorage_CreateRelease_Call {
+	return &MockStorage_CreateRelease_Call{Call: _e.mock.On("CreateRelease", ctx, release)}
+}
+
+func (_c *MockStorage_CreateRelease_Call) Run(run func(ctx context.Context, release *storage.Release)) *MockStorage_CreateRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.Release
+		if args[1] != nil {
+			arg1 = args[1].(*storage.Release)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateRelease_Call) Return(err error) *MockStorage_CreateRelease_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateRelease_Call) RunAndReturn(run func(ctx context.Context, release *storage.Release) error) *MockStorage_CreateRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRoleBinding provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateRoleBinding(ctx context.Context, binding *storage.RoleBinding) error {
+	ret := _mock.Called(ctx, binding)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoleBinding")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.RoleBinding) error); ok {
+		r0 = returnFunc(ctx, binding)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateRoleBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRoleBinding'
+type MockStorage_CreateRoleBinding_Call struct {
+	*mock.Call
+}
+
+// CreateRoleBinding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - binding *storage.RoleBinding
+func (_e *MockStorage_Expecter) CreateRoleBinding(ctx interface{}, binding interface{}) *MockStorage_CreateRoleBinding_Call {
+	return &MockStorage_CreateRoleBinding_Call{Call: _e.mock.On("CreateRoleBinding", ctx, binding)}
+}
+
+func (_c *MockStorage_CreateRoleBinding_Call) Run(run func(ctx context.Context, binding *storage.RoleBinding)) *MockStorage_CreateRoleBinding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.RoleBinding
+		if args[1] != nil {
+			arg1 = args[1].(*storage.RoleBinding)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateRoleBinding_Call) Return(err error) *MockStorage_CreateRoleBinding_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateRoleBinding_Call) RunAndReturn(run func(ctx context.Context, binding *storage.RoleBinding) error) *MockStorage_CreateRoleBinding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockStorage
+func (_mock *MockStorage) CreateUser(ctx context.Context, user *storage.User) error {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.User) error); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockStorage_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *storage.User
+func (_e *MockStorage_Expecter) CreateUser(ctx interface{}, user interface{}) *MockStorage_CreateUser_Call {
+	return &MockStorage_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *MockStorage_CreateUser_Call) Run(run func(ctx context.Context, user *storage.User)) *MockStorage_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.User
+		if args[1] != nil {
+			arg1 = args[1].(*storage.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_CreateUser_Call) Return(err error) *MockStorage_CreateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user *storage.User) error) *MockStorage_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAudits provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteAudits(ctx context.Context, afterID uint64, lastID uint64) (uint64, error) {
+	ret := _mock.Called(ctx, afterID, lastID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAudits")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) (uint64, error)); ok {
+		return returnFunc(ctx, afterID, lastID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) uint64); ok {
+		r0 = returnFunc(ctx, afterID, lastID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, afterID, lastID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_DeleteAudits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAudits'
+type MockStorage_DeleteAudits_Call struct {
+	*mock.Call
+}
+
+// DeleteAudits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID uint64
+//   - lastID uint64
+func (_e *MockStorage_Expecter) DeleteAudits(ctx interface{}, afterID interface{}, lastID interface{}) *MockStorage_DeleteAudits_Call {
+	return &MockStorage_DeleteAudits_Call{Call: _e.mock.On("DeleteAudits", ctx, afterID, lastID)}
+}
+
+func (_c *MockStorage_DeleteAudits_Call) Run(run func(ctx context.Context, afterID uint64, lastID uint64)) *MockStorage_DeleteAudits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteAudits_Call) Return(v uint64, err error) *MockStorage_DeleteAudits_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteAudits_Call) RunAndReturn(run func(ctx context.Context, afterID uint64, lastID uint64) (uint64, error)) *MockStorage_DeleteAudits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteConfigs provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteConfigs(ctx context.Context, IDs []uint64) error {
+	ret := _mock.Called(ctx, IDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteConfigs")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uint64) error); ok {
+		r0 = returnFunc(ctx, IDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_DeleteConfigs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConfigs'
+type MockStorage_DeleteConfigs_Call struct {
+	*mock.Call
+}
+
+// DeleteConfigs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - IDs []uint64
+func (_e *MockStorage_Expecter) DeleteConfigs(ctx interface{}, IDs interface{}) *MockStorage_DeleteConfigs_Call {
+	return &MockStorage_DeleteConfigs_Call{Call: _e.mock.On("DeleteConfigs", ctx, IDs)}
+}
+
+func (_c *MockStorage_DeleteConfigs_Call) Run(run func(ctx context.Context, IDs []uint64)) *MockStorage_DeleteConfigs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []uint64
+		if args[1] != nil {
+			arg1 = args[1].([]uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteConfigs_Call) Return(err error) *MockStorage_DeleteConfigs_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteConfigs_Call) RunAndReturn(run func(ctx context.Context, IDs []uint64) error) *MockStorage_DeleteConfigs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProject provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteProject(ctx context.Context, ID uint64) error {
+	ret := _mock.Called(ctx, ID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProject")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = returnFunc(ctx, ID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_DeleteProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProject'
+type MockStorage_DeleteProject_Call struct {
+	*mock.Call
+}
+
+// DeleteProject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ID uint64
+func (_e *MockStorage_Expecter) DeleteProject(ctx interface{}, ID interface{}) *MockStorage_DeleteProject_Call {
+	return &MockStorage_DeleteProject_Call{Call: _e.mock.On("DeleteProject", ctx, ID)}
+}
+
+func (_c *MockStorage_DeleteProject_Call) Run(run func(ctx context.Context, ID uint64)) *MockStorage_DeleteProject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteProject_Call) Return(err error) *MockStorage_DeleteProject_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteProject_Call) RunAndReturn(run func(ctx context.Context, ID uint64) error) *MockStorage_DeleteProject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRelease provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteRelease(ctx context.Context, envID uint64, releaseName string) error {
+	ret := _mock.Called(ctx, envID, releaseName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRelease")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
+		r0 = returnFunc(ctx, envID, releaseName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStorage_DeleteRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelease'
+type MockStorage_DeleteRelease_Call struct {
+	*mock.Call
+}
+
+// DeleteRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - envID uint64
+//   - releaseName string
+func (_e *MockStorage_Expecter) DeleteRelease(ctx interface{}, envID interface{}, releaseName interface{}) *MockStorage_DeleteRelease_Call {
+	return &MockStorage_DeleteRelease_Call{Call: _e.mock.On("DeleteRelease", ctx, envID, releaseName)}
+}
+
+func (_c *MockStorage_DeleteRelease_Call) Run(run func(ctx context.Context, envID uint64, releaseName string)) *MockStorage_DeleteRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteRelease_Call) Return(err error) *MockStorage_DeleteRelease_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteRelease_Call) RunAndReturn(run func(ctx context.Context, envID uint64, releaseName string) error) *MockStorage_DeleteRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRoleBinding provides a mock function for the type MockStorage
+func (_mock *MockStorage) DeleteRoleBinding(ctx context.Context, projectID *uint64, id uint64) (*storage.RoleBinding, error) {
+	ret := _mock.Called(ctx, projectID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoleBinding")
+	}
+
+	var r0 *storage.RoleBinding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uint64, uint64) (*storage.RoleBinding, error)); ok {
+		return returnFunc(ctx, projectID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uint64, uint64) *storage.RoleBinding); ok {
+		r0 = returnFunc(ctx, projectID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.RoleBinding)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, projectID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_DeleteRoleBinding_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoleBinding'
+type MockStorage_DeleteRoleBinding_Call struct {
+	*mock.Call
+}
+
+// DeleteRoleBinding is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID *uint64
+//   - id uint64
+func (_e *MockStorage_Expecter) DeleteRoleBinding(ctx interface{}, projectID interface{}, id interface{}) *MockStorage_DeleteRoleBinding_Call {
+	return &MockStorage_DeleteRoleBinding_Call{Call: _e.mock.On("DeleteRoleBinding", ctx, projectID, id)}
+}
+
+func (_c *MockStorage_DeleteRoleBinding_Call) Run(run func(ctx context.Context, projectID *uint64, id uint64)) *MockStorage_DeleteRoleBinding_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *uint64
+		if args[1] != nil {
+			arg1 = args[1].(*uint64)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_DeleteRoleBinding_Call) Return(roleBinding *storage.RoleBinding, err error) *MockStorage_DeleteRoleBinding_Call {
+	_c.Call.Return(roleBinding, err)
+	return _c
+}
+
+func (_c *MockStorage_DeleteRoleBinding_Call) RunAndReturn(run func(ctx context.Context, projectID *uint64, id uint64) (*storage.RoleBinding, error)) *MockStorage_DeleteRoleBinding_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Environment provides a mock function for the type MockStorage
+func (_mock *MockStorage) Environment(ctx context.Context, projectID uint64, envName string) (*storage.Environment, error) {
+	ret := _mock.Called(ctx, projectID, envName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Environment")
+	}
+
+	var r0 *storage.Environment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (*storage.Environment, error)); ok {
+		return returnFunc(ctx, projectID, envName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) *storage.Environment); ok {
+		r0 = returnFunc(ctx, projectID, envName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Environment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, projectID, envName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Environment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Environment'
+type MockStorage_Environment_Call struct {
+	*mock.Call
+}
+
+// Environment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectID uint64
+//   - envName string
+func (_e *MockStorage_Expecter) Environment(ctx interface{}, projectID interface{}, envName interface{}) *MockStorage_Environment_Call {
+	return &MockStorage_Environment_Call{Call: _e.mock.On("Environment", ctx, projectID, envName)}
+}
+
+func (_c *MockStorage_Environment_Call) Run(run func(ctx context.Context, projectID uint64, envName string)) *MockStorage_Environment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Environment_Call) Return(environment *storage.Environment, err error) *MockStorage_Environment_Call {
+	_c.Call.Return(environment, err)
+	return _c
+}
+
+func (_c *MockStorage_Environment_Call) RunAndReturn(run func(ctx context.Context, projectID uint64, envName string) (*storage.Environment, error)) *MockStorage_Environment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Environments provides a mock function for the type MockStorage
+func (_mock *MockStorage) Environments(ctx context.Context, projectName string) ([]*storage.Environment, error) {
+	ret := _mock.Called(ctx, projectName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Environments")
+	}
+
+	var r0 []*storage.Environment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*storage.Environment, error)); ok {
+		return returnFunc(ctx, projectName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*storage.Environment); ok {
+		r0 = returnFunc(ctx, projectName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Environment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, projectName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_Environments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Environments'
+type MockStorage_Environments_Call struct {
+	*mock.Call
+}
+
+// Environments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - projectName string
+func (_e *MockStorage_Expecter) Environments(ctx interface{}, projectName interface{}) *MockStorage_Environments_Call {
+	return &MockStorage_Environments_Call{Call: _e.mock.On("Environments", ctx, projectName)}
+}
+
+func (_c *MockStorage_Environments_Call) Run(run func(ctx context.Context, projectName string)) *MockStorage_Environments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_Environments_Call) Return(environments []*storage.Environment, err error) *MockStorage_Environments_Call {
+	_c.Call.Return(environments, err)
+	return _c
+}
+
+func (_c *MockStorage_Environments_Call) RunAndReturn(run func(ctx context.Context, projectName string) ([]*storage.Environment, error)) *MockStorage_Environments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportAudit provides a mock function for the type MockStorage
+func (_mock *MockStorage) ImportAudit(ctx context.Context, audit *storage.Audit) (bool, error) {
+	ret := _mock.Called(ctx, audit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportAudit")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Audit) (bool, error)); ok {
+		return returnFunc(ctx, audit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Audit) bool); ok {
+		r0 = returnFunc(ctx, audit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *storage.Audit) error); ok {
+		r1 = returnFunc(ctx, audit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_ImportAudit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportAudit'
+type MockStorage_ImportAudit_Call struct {
+	*mock.Call
+}
+
+// ImportAudit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - audit *storage.Audit
+func (_e *MockStorage_Expecter) ImportAudit(ctx interface{}, audit interface{}) *MockStorage_ImportAudit_Call {
+	return &MockStorage_ImportAudit_Call{Call: _e.mock.On("ImportAudit", ctx, audit)}
+}
+
+func (_c *MockStorage_ImportAudit_Call) Run(run func(ctx context.Context, audit *storage.Audit)) *MockStorage_ImportAudit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *storage.Audit
+		if args[1] != nil {
+			arg1 = args[1].(*storage.Audit)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ImportAudit_Call) Return(b bool, err error) *MockStorage_ImportAudit_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStorage_ImportAudit_Call) RunAndReturn(run func(ctx context.Context, audit *storage.Audit) (bool, error)) *MockStorage_ImportAudit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastAuditCheckpoint provides a mock function for the type MockStorage
+func (_mock *MockStorage) LastAuditCheckpoint(ctx context.Context) (*storage.AuditCheckpoint, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastAuditCheckpoint")
+	}
+
+	var r0 *storage.AuditCheckpoint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*storage.AuditCheckpoint, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *storage.AuditCheckpoint); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.AuditCheckpoint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_LastAuditCheckpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastAuditCheckpoint'
+type MockStorage_LastAuditCheckpoint_Call struct {
+	*mock.Call
+}
+
+// LastAuditCheckpoint is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) LastAuditCheckpoint(ctx interface{}) *MockStorage_LastAuditCheckpoint_Call {
+	return &MockStorage_LastAuditCheckpoint_Call{Call: _e.mock.On("LastAuditCheckpoint", ctx)}
+}
+
+func (_c *MockStorage_LastAuditCheckpoint_Call) Run(run func(ctx context.Context)) *MockStorage_LastAuditCheckpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_LastAuditCheckpoint_Call) Return(auditCheckpoint *storage.AuditCheckpoint, err error) *MockStorage_LastAuditCheckpoint_Call {
+	_c.Call.Return(auditCheckpoint, err)
+	return _c
+}
+
+func (_c *MockStorage_LastAuditCheckpoint_Call) RunAndReturn(run func(ctx context.Context) (*storage.AuditCheckpoint, error)) *MockStorage_LastAuditCheckpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LastChainedAudit provides a mock function for the type MockStorage
+func (_mock *MockStorage) LastChainedAudit(ctx context.Context) (*storage.Audit, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastChainedAudit")
+	}
+
+	var r0 *storage.Audit
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*storage.Audit, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *storage.Audit); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Audit)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_LastChainedAudit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LastChainedAudit'
+type MockStorage_LastChainedAudit_Call struct {
+	*mock.Call
+}
+
+// LastChainedAudit is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStorage_Expecter) LastChainedAudit(ctx interface{}) *MockStorage_LastChainedAudit_Call {
+	return &MockStorage_LastChainedAudit_Call{Call: _e.mock.On("LastChainedAudit", ctx)}
+}
+
+func (_c *MockStorage_LastChainedAudit_Call) Run(run func(ctx context.Context)) *MockStorage_LastChainedAudit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_LastChainedAudit_Call) Return(audit *storage.Audit, err error) *MockStorage_LastChainedAudit_Call {
+	_c.Call.Return(audit, err)
+	return _c
+}
+
+func (_c *MockStorage_LastChainedAudit_Call) RunAndReturn(run func(ctx context.Context) (*storage.Audit, error)) *MockStorage_LastChainedAudit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockAuditArchive provides a mock function for the type MockStorage
+func (_mock *MockStorage) LockAuditArchive(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockAuditArchive")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_CreateEnvironment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEnvironment'
-type MockStorage_CreateEnvironment_Call struct {
+// MockStorage_LockAuditArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockAuditArchive'
+type MockStorage_LockAuditArchive_Call struct {
 	*mock.Call
 }
 
-// CreateEnvironment is a helper method to define mock.On call
+// LockAuditArchive is a helper method to define mock.On call
 //   - ctx context.Context
-//   - env *storage.Environment
-func (_e *MockStorage_Expecter) CreateEnvironment(ctx interface{}, env interface{}) *MockStorage_CreateEnvironment_Call {
-	return &MockStorage_CreateEnvironment_Call{Call: _e.mock.On("CreateEnvironment", ctx, env)}
+func (_e *MockStorage_Expecter) LockAuditArchive(ctx interface{}) *MockStorage_LockAuditArchive_Call {
+	return &MockStorage_LockAuditArchive_Call{Call: _e.mock.On("LockAuditArchive", ctx)}
 }
 
-func (_c *MockStorage_CreateEnvironment_Call) Run(run func(ctx context.Context, env *storage.Environment)) *MockStorage_CreateEnvironment_Call {
+func (_c *MockStorage_LockAuditArchive_Call) Run(run func(ctx context.Context)) *MockStorage_LockAuditArchive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *storage.Environment
-		if args[1] != nil {
-			arg1 = args[1].(*storage.Environment)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_CreateEnvironment_Call) Return(err error) *MockStorage_CreateEnvironment_Call {
+func (_c *MockStorage_LockAuditArchive_Call) Return(err error) *MockStorage_LockAuditArchive_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_CreateEnvironment_Call) RunAndReturn(run func(ctx context.Context, env *storage.Environment) error) *MockStorage_CreateEnvironment_Call {
+func (_c *MockStorage_LockAuditArchive_Call) RunAndReturn(run func(ctx context.Context) error) *MockStorage_LockAuditArchive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateProject provides a mock function for the type MockStorage
-func (_mock *MockStorage) CreateProject(ctx context.Context, project *storage.Project) error {
-	ret := _mock.Called(ctx, project)
+// MarkAPITokenUsed provides a mock function for the type MockStorage
+func (_mock *MockStorage) MarkAPITokenUsed(ctx context.Context, id uint64) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateProject")
+		panic("no return value specified for MarkAPITokenUsed")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Project) error); ok {
-		r0 = returnFunc(ctx, project)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_CreateProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProject'
-type MockStorage_CreateProject_Call struct {
+// MockStorage_MarkAPITokenUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAPITokenUsed'
+type MockStorage_MarkAPITokenUsed_Call struct {
 	*mock.Call
 }
 
-// CreateProject is a helper method to define mock.On call
+// MarkAPITokenUsed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - project *storage.Project
-func (_e *MockStorage_Expecter) CreateProject(ctx interface{}, project interface{}) *MockStorage_CreateProject_Call {
-	return &MockStorage_CreateProject_Call{Call: _e.mock.On("CreateProject", ctx, project)}
+//   - id uint64
+func (_e *MockStorage_Expecter) MarkAPITokenUsed(ctx interface{}, id interface{}) *MockStorage_MarkAPITokenUsed_Call {
+	return &MockStorage_MarkAPITokenUsed_Call{Call: _e.mock.On("MarkAPITokenUsed", ctx, id)}
 }
 
-func (_c *MockStorage_CreateProject_Call) Run(run func(ctx context.Context, project *storage.Project)) *MockStorage_CreateProject_Call {
+func (_c *MockStorage_MarkAPITokenUsed_Call) Run(run func(ctx context.Context, id uint64)) *MockStorage_MarkAPITokenUsed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *storage.Project
+		var arg1 uint64
 		if args[1] != nil {
-			arg1 = args[1].(*storage.Project)
+			arg1 = args[1].(uint64)
 		}
 		run(
 			arg0,
@@ -563,54 +2074,54 @@ func (_c *MockStorage_CreateProject_Call) Run(run func(ctx context.Context, proj
 	return _c
 }
 
-func (_c *MockStorage_CreateProject_Call) Return(err error) *MockStorage_CreateProject_Call {
+func (_c *MockStorage_MarkAPITokenUsed_Call) Return(err error) *MockStorage_MarkAPITokenUsed_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_CreateProject_Call) RunAndReturn(run func(ctx context.Context, project *storage.Project) error) *MockStorage_CreateProject_Call {
+func (_c *MockStorage_MarkAPITokenUsed_Call) RunAndReturn(run func(ctx context.Context, id uint64) error) *MockStorage_MarkAPITokenUsed_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateRelease provides a mock function for the type MockStorage
-func (_mock *MockStorage) CreateRelease(ctx context.Context, release *storage.Release) error {
-	ret := _mock.Called(ctx, release)
+// MarkConfigsUpdated provides a mock function for the type MockStorage
+func (_mock *MockStorage) MarkConfigsUpdated(ctx context.Context, IDs []uint64) error {
+	ret := _mock.Called(ctx, IDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateRelease")
+		panic("no return value specified for MarkConfigsUpdated")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.Release) error); ok {
-		r0 = returnFunc(ctx, release)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uint64) error); ok {
+		r0 = returnFunc(ctx, IDs)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_CreateRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRelease'
-type MockStorage_CreateRelease_Call struct {
+// MockStorage_MarkConfigsUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkConfigsUpdated'
+type MockStorage_MarkConfigsUpdated_Call struct {
 	*mock.Call
 }
 
-// CreateRelease is a helper method to define mock.On call
+// MarkConfigsUpdated is a helper method to define mock.On call
 //   - ctx context.Context
-//   - release *storage.Release
-func (_e *MockStorage_Expecter) CreateRelease(ctx interface{}, release interface{}) *MockStorage_CreateRelease_Call {
-	return &MockStorage_CreateRelease_Call{Call: _e.mock.On("CreateRelease", ctx, release)}
+//   - IDs []uint64
+func (_e *MockStorage_Expecter) MarkConfigsUpdated(ctx interface{}, IDs interface{}) *MockStorage_MarkConfigsUpdated_Call {
+	return &MockStorage_MarkConfigsUpdated_Call{Call: _e.mock.On("MarkConfigsUpdated", ctx, IDs)}
 }
 
-func (_c *MockStorage_CreateRelease_Call) Run(run func(ctx context.Context, release *storage.Release)) *MockStorage_CreateRelease_Call {
+func (_c *MockStorage_MarkConfigsUpdated_Call) Run(run func(ctx context.Context, IDs []uint64)) *MockStorage_MarkConfigsUpdated_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *storage.Release
+		var arg1 []uint64
 		if args[1] != nil {
-			arg1 = args[1].(*storage.Release)
+			arg1 = args[1].([]uint64)
 		}
 		run(
 			arg0,
@@ -620,111 +2131,219 @@ func (_c *MockStorage_CreateRelease_Call) Run(run func(ctx context.Context, rele
 	return _c
 }
 
-func (_c *MockStorage_CreateRelease_Call) Return(err error) *MockStorage_CreateRelease_Call {
+func (_c *MockStorage_MarkConfigsUpdated_Call) Return(err error) *MockStorage_MarkConfigsUpdated_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_CreateRelease_Call) RunAndReturn(run func(ctx context.Context, release *storage.Release) error) *MockStorage_CreateRelease_Call {
+func (_c *MockStorage_MarkConfigsUpdated_Call) RunAndReturn(run func(ctx context.Context, IDs []uint64) error) *MockStorage_MarkConfigsUpdated_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUser provides a mock function for the type MockStorage
-func (_mock *MockStorage) CreateUser(ctx context.Context, user *storage.User) error {
-	ret := _mock.Called(ctx, user)
+// ProjectByName provides a mock function for the type MockStorage
+func (_mock *MockStorage) ProjectByName(ctx context.Context, name string) (*storage.Project, error) {
+	ret := _mock.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for ProjectByName")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *storage.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	var r0 *storage.Project
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*storage.Project, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *storage.Project); ok {
+		r0 = returnFunc(ctx, name)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Project)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type MockStorage_CreateUser_Call struct {
+// MockStorage_ProjectByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectByName'
+type MockStorage_ProjectByName_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// ProjectByName is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *storage.User
-func (_e *MockStorage_Expecter) CreateUser(ctx interface{}, user interface{}) *MockStorage_CreateUser_Call {
-	return &MockStorage_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+//   - name string
+func (_e *MockStorage_Expecter) ProjectByName(ctx interface{}, name interface{}) *MockStorage_ProjectByName_Call {
+	return &MockStorage_ProjectByName_Call{Call: _e.mock.On("ProjectByName", ctx, name)}
 }
 
-func (_c *MockStorage_CreateUser_Call) Run(run func(ctx context.Context, user *storage.User)) *MockStorage_CreateUser_Call {
+func (_c *MockStorage_ProjectByName_Call) Run(run func(ctx context.Context, name string)) *MockStorage_ProjectByName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *storage.User
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*storage.User)
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_ProjectByName_Call) Return(project *storage.Project, err error) *MockStorage_ProjectByName_Call {
+	_c.Call.Return(project, err)
+	return _c
+}
+
+func (_c *MockStorage_ProjectByName_Call) RunAndReturn(run func(ctx context.Context, name string) (*storage.Project, error)) *MockStorage_ProjectByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Projects provides a mock function for the type MockStorage
+func (_mock *MockStorage) Projects(ctx context.Context, q string, limit uint64, offset uint64) ([]*storage.Project, uint64, error) {
+	ret := _mock.Called(ctx, q, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Projects")
+	}
+
+	var r0 []*storage.Project
+	var r1 uint64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) ([]*storage.Project, uint64, error)); ok {
+		return returnFunc(ctx, q, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) []*storage.Project); ok {
+		r0 = returnFunc(ctx, q, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Project)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) uint64); ok {
+		r1 = returnFunc(ctx, q, limit, offset)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, uint64, uint64) error); ok {
+		r2 = returnFunc(ctx, q, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockStorage_Projects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Projects'
+type MockStorage_Projects_Call struct {
+	*mock.Call
+}
+
+// Projects is a helper method to define mock.On call
+//   - ctx context.Context
+//   - q string
+//   - limit uint64
+//   - offset uint64
+func (_e *MockStorage_Expecter) Projects(ctx interface{}, q interface{}, limit interface{}, offset interface{}) *MockStorage_Projects_Call {
+	return &MockStorage_Projects_Call{Call: _e.mock.On("Projects", ctx, q, limit, offset)}
+}
+
+func (_c *MockStorage_Projects_Call) Run(run func(ctx context.Context, q string, limit uint64, offset uint64)) *MockStorage_Projects_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 uint64
+		if args[3] != nil {
+			arg3 = args[3].(uint64)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_CreateUser_Call) Return(err error) *MockStorage_CreateUser_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_Projects_Call) Return(projects []*storage.Project, v uint64, err error) *MockStorage_Projects_Call {
+	_c.Call.Return(projects, v, err)
 	return _c
 }
 
-func (_c *MockStorage_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user *storage.User) error) *MockStorage_CreateUser_Call {
+func (_c *MockStorage_Projects_Call) RunAndReturn(run func(ctx context.Context, q string, limit uint64, offset uint64) ([]*storage.Project, uint64, error)) *MockStorage_Projects_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteConfigs provides a mock function for the type MockStorage
-func (_mock *MockStorage) DeleteConfigs(ctx context.Context, IDs []uint64) error {
-	ret := _mock.Called(ctx, IDs)
+// RefreshTokenByHash provides a mock function for the type MockStorage
+func (_mock *MockStorage) RefreshTokenByHash(ctx context.Context, tokenHash string) (*storage.RefreshToken, error) {
+	ret := _mock.Called(ctx, tokenHash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteConfigs")
+		panic("no return value specified for RefreshTokenByHash")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uint64) error); ok {
-		r0 = returnFunc(ctx, IDs)
+	var r0 *storage.RefreshToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*storage.RefreshToken, error)); ok {
+		return returnFunc(ctx, tokenHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *storage.RefreshToken); ok {
+		r0 = returnFunc(ctx, tokenHash)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.RefreshToken)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_DeleteConfigs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteConfigs'
-type MockStorage_DeleteConfigs_Call struct {
+// MockStorage_RefreshTokenByHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshTokenByHash'
+type MockStorage_RefreshTokenByHash_Call struct {
 	*mock.Call
 }
 
-// DeleteConfigs is a helper method to define mock.On call
+// RefreshTokenByHash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - IDs []uint64
-func (_e *MockStorage_Expecter) DeleteConfigs(ctx interface{}, IDs interface{}) *MockStorage_DeleteConfigs_Call {
-	return &MockStorage_DeleteConfigs_Call{Call: _e.mock.On("DeleteConfigs", ctx, IDs)}
+//   - tokenHash string
+func (_e *MockStorage_Expecter) RefreshTokenByHash(ctx interface{}, tokenHash interface{}) *MockStorage_RefreshTokenByHash_Call {
+	return &MockStorage_RefreshTokenByHash_Call{Call: _e.mock.On("RefreshTokenByHash", ctx, tokenHash)}
 }
 
-func (_c *MockStorage_DeleteConfigs_Call) Run(run func(ctx context.Context, IDs []uint64)) *MockStorage_DeleteConfigs_Call {
+func (_c *MockStorage_RefreshTokenByHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockStorage_RefreshTokenByHash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []uint64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]uint64)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -734,46 +2353,58 @@ func (_c *MockStorage_DeleteConfigs_Call) Run(run func(ctx context.Context, IDs
 	return _c
 }
 
-func (_c *MockStorage_DeleteConfigs_Call) Return(err error) *MockStorage_DeleteConfigs_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_RefreshTokenByHash_Call) Return(refreshToken *storage.RefreshToken, err error) *MockStorage_RefreshTokenByHash_Call {
+	_c.Call.Return(refreshToken, err)
 	return _c
 }
 
-func (_c *MockStorage_DeleteConfigs_Call) RunAndReturn(run func(ctx context.Context, IDs []uint64) error) *MockStorage_DeleteConfigs_Call {
+func (_c *MockStorage_RefreshTokenByHash_Call) RunAndReturn(run func(ctx context.Context, tokenHash string) (*storage.RefreshToken, error)) *MockStorage_RefreshTokenByHash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteProject provides a mock function for the type MockStorage
-func (_mock *MockStorage) DeleteProject(ctx context.Context, ID uint64) error {
-	ret := _mock.Called(ctx, ID)
+// Release provides a mock function for the type MockStorage
+func (_mock *MockStorage) Release(ctx context.Context, envID uint64, releaseName string) (*storage.Release, error) {
+	ret := _mock.Called(ctx, envID, releaseName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteProject")
+		panic("no return value specified for Release")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
-		r0 = returnFunc(ctx, ID)
+	var r0 *storage.Release
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (*storage.Release, error)); ok {
+		return returnFunc(ctx, envID, releaseName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) *storage.Release); ok {
+		r0 = returnFunc(ctx, envID, releaseName)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*storage.Release)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, envID, releaseName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_DeleteProject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProject'
-type MockStorage_DeleteProject_Call struct {
+// MockStorage_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockStorage_Release_Call struct {
 	*mock.Call
 }
 
-// DeleteProject is a helper method to define mock.On call
+// Release is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ID uint64
-func (_e *MockStorage_Expecter) DeleteProject(ctx interface{}, ID interface{}) *MockStorage_DeleteProject_Call {
-	return &MockStorage_DeleteProject_Call{Call: _e.mock.On("DeleteProject", ctx, ID)}
+//   - envID uint64
+//   - releaseName string
+func (_e *MockStorage_Expecter) Release(ctx interface{}, envID interface{}, releaseName interface{}) *MockStorage_Release_Call {
+	return &MockStorage_Release_Call{Call: _e.mock.On("Release", ctx, envID, releaseName)}
 }
 
-func (_c *MockStorage_DeleteProject_Call) Run(run func(ctx context.Context, ID uint64)) *MockStorage_DeleteProject_Call {
+func (_c *MockStorage_Release_Call) Run(run func(ctx context.Context, envID uint64, releaseName string)) *MockStorage_Release_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -783,63 +2414,79 @@ func (_c *MockStorage_DeleteProject_Call) Run(run func(ctx context.Context, ID u
 		if args[1] != nil {
 			arg1 = args[1].(uint64)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_DeleteProject_Call) Return(err error) *MockStorage_DeleteProject_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_Release_Call) Return(release *storage.Release, err error) *MockStorage_Release_Call {
+	_c.Call.Return(release, err)
 	return _c
 }
 
-func (_c *MockStorage_DeleteProject_Call) RunAndReturn(run func(ctx context.Context, ID uint64) error) *MockStorage_DeleteProject_Call {
+func (_c *MockStorage_Release_Call) RunAndReturn(run func(ctx context.Context, envID uint64, releaseName string) (*storage.Release, error)) *MockStorage_Release_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteRelease provides a mock function for the type MockStorage
-func (_mock *MockStorage) DeleteRelease(ctx context.Context, envID uint64, releaseName string) error {
-	ret := _mock.Called(ctx, envID, releaseName)
+// Releases provides a mock function for the type MockStorage
+func (_mock *MockStorage) Releases(ctx context.Context, projectName string, envName string) ([]*storage.Release, error) {
+	ret := _mock.Called(ctx, projectName, envName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteRelease")
+		panic("no return value specified for Releases")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
-		r0 = returnFunc(ctx, envID, releaseName)
+	var r0 []*storage.Release
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*storage.Release, error)); ok {
+		return returnFunc(ctx, projectName, envName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*storage.Release); ok {
+		r0 = returnFunc(ctx, projectName, envName)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Release)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, projectName, envName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockStorage_DeleteRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRelease'
-type MockStorage_DeleteRelease_Call struct {
+// MockStorage_Releases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Releases'
+type MockStorage_Releases_Call struct {
 	*mock.Call
 }
 
-// DeleteRelease is a helper method to define mock.On call
+// Releases is a helper method to define mock.On call
 //   - ctx context.Context
-//   - envID uint64
-//   - releaseName string
-func (_e *MockStorage_Expecter) DeleteRelease(ctx interface{}, envID interface{}, releaseName interface{}) *MockStorage_DeleteRelease_Call {
-	return &MockStorage_DeleteRelease_Call{Call: _e.mock.On("DeleteRelease", ctx, envID, releaseName)}
+//   - projectName string
+//   - envName string
+func (_e *MockStorage_Expecter) Releases(ctx interface{}, projectName interface{}, envName interface{}) *MockStorage_Releases_Call {
+	return &MockStorage_Releases_Call{Call: _e.mock.On("Releases", ctx, projectName, envName)}
 }
 
-func (_c *MockStorage_DeleteRelease_Call) Run(run func(ctx context.Context, envID uint64, releaseName string)) *MockStorage_DeleteRelease_Call {
+func (_c *MockStorage_Releases_Call) Run(run func(ctx context.Context, projectName string, envName string)) *MockStorage_Releases_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uint64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(uint64)
+			arg1 = args[1].(string)
 		}
 		var arg2 string
 		if args[2] != nil {
@@ -854,70 +2501,59 @@ func (_c *MockStorage_DeleteRelease_Call) Run(run func(ctx context.Context, envI
 	return _c
 }
 
-func (_c *MockStorage_DeleteRelease_Call) Return(err error) *MockStorage_DeleteRelease_Call {
-	_c.Call.Return(err)
+func (_c *MockStorage_Releases_Call) Return(releases []*storage.Release, err error) *MockStorage_Releases_Call {
+	_c.Call.Return(releases, err)
 	return _c
 }
 
-func (_c *MockStorage_DeleteRelease_Call) RunAndReturn(run func(ctx context.Context, envID uint64, releaseName string) error) *MockStorage_DeleteRelease_Call {
+func (_c *MockStorage_Releases_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string) ([]*storage.Release, error)) *MockStorage_Releases_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Environment provides a mock function for the type MockStorage
-func (_mock *MockStorage) Environment(ctx context.Context, projectID uint64, envName string) (*storage.Environment, error) {
-	ret := _mock.Called(ctx, projectID, envName)
+// RevokeAPIToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) RevokeAPIToken(ctx context.Context, username string, id uint64) error {
+	ret := _mock.Called(ctx, username, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Environment")
+		panic("no return value specified for RevokeAPIToken")
 	}
 
-	var r0 *storage.Environment
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (*storage.Environment, error)); ok {
-		return returnFunc(ctx, projectID, envName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) *storage.Environment); ok {
-		r0 = returnFunc(ctx, projectID, envName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.Environment)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
-		r1 = returnFunc(ctx, projectID, envName)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = returnFunc(ctx, username, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_Environment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Environment'
-type MockStorage_Environment_Call struct {
+// MockStorage_RevokeAPIToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIToken'
+type MockStorage_RevokeAPIToken_Call struct {
 	*mock.Call
 }
 
-// Environment is a helper method to define mock.On call
+// RevokeAPIToken is a helper method to define mock.On call
 //   - ctx context.Context
-//   - projectID uint64
-//   - envName string
-func (_e *MockStorage_Expecter) Environment(ctx interface{}, projectID interface{}, envName interface{}) *MockStorage_Environment_Call {
-	return &MockStorage_Environment_Call{Call: _e.mock.On("Environment", ctx, projectID, envName)}
+//   - username string
+//   - id uint64
+func (_e *MockStorage_Expecter) RevokeAPIToken(ctx interface{}, username interface{}, id interface{}) *MockStorage_RevokeAPIToken_Call {
+	return &MockStorage_RevokeAPIToken_Call{Call: _e.mock.On("RevokeAPIToken", ctx, username, id)}
 }
 
-func (_c *MockStorage_Environment_Call) Run(run func(ctx context.Context, projectID uint64, envName string)) *MockStorage_Environment_Call {
+func (_c *MockStorage_RevokeAPIToken_Call) Run(run func(ctx context.Context, username string, id uint64)) *MockStorage_RevokeAPIToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uint64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(uint64)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 uint64
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(uint64)
 		}
 		run(
 			arg0,
@@ -928,65 +2564,54 @@ func (_c *MockStorage_Environment_Call) Run(run func(ctx context.Context, projec
 	return _c
 }
 
-func (_c *MockStorage_Environment_Call) Return(environment *storage.Environment, err error) *MockStorage_Environment_Call {
-	_c.Call.Return(environment, err)
+func (_c *MockStorage_RevokeAPIToken_Call) Return(err error) *MockStorage_RevokeAPIToken_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_Environment_Call) RunAndReturn(run func(ctx context.Context, projectID uint64, envName string) (*storage.Environment, error)) *MockStorage_Environment_Call {
+func (_c *MockStorage_RevokeAPIToken_Call) RunAndReturn(run func(ctx context.Context, username string, id uint64) error) *MockStorage_RevokeAPIToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Environments provides a mock function for the type MockStorage
-func (_mock *MockStorage) Environments(ctx context.Context, projectName string) ([]*storage.Environment, error) {
-	ret := _mock.Called(ctx, projectName)
+// RevokeRefreshToken provides a mock function for the type MockStorage
+func (_mock *MockStorage) RevokeRefreshToken(ctx context.Context, id uint64) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Environments")
+		panic("no return value specified for RevokeRefreshToken")
 	}
 
-	var r0 []*storage.Environment
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*storage.Environment, error)); ok {
-		return returnFunc(ctx, projectName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*storage.Environment); ok {
-		r0 = returnFunc(ctx, projectName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Environment)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, projectName)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_Environments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Environments'
-type MockStorage_Environments_Call struct {
+// MockStorage_RevokeRefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRefreshToken'
+type MockStorage_RevokeRefreshToken_Call struct {
 	*mock.Call
 }
 
-// Environments is a helper method to define mock.On call
+// RevokeRefreshToken is a helper method to define mock.On call
 //   - ctx context.Context
-//   - projectName string
-func (_e *MockStorage_Expecter) Environments(ctx interface{}, projectName interface{}) *MockStorage_Environments_Call {
-	return &MockStorage_Environments_Call{Call: _e.mock.On("Environments", ctx, projectName)}
+//   - id uint64
+func (_e *MockStorage_Expecter) RevokeRefreshToken(ctx interface{}, id interface{}) *MockStorage_RevokeRefreshToken_Call {
+	return &MockStorage_RevokeRefreshToken_Call{Call: _e.mock.On("RevokeRefreshToken", ctx, id)}
 }
 
-func (_c *MockStorage_Environments_Call) Run(run func(ctx context.Context, projectName string)) *MockStorage_Environments_Call {
+func (_c *MockStorage_RevokeRefreshToken_Call) Run(run func(ctx context.Context, id uint64)) *MockStorage_RevokeRefreshToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uint64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(uint64)
 		}
 		run(
 			arg0,
@@ -996,54 +2621,54 @@ func (_c *MockStorage_Environments_Call) Run(run func(ctx context.Context, proje
 	return _c
 }
 
-func (_c *MockStorage_Environments_Call) Return(environments []*storage.Environment, err error) *MockStorage_Environments_Call {
-	_c.Call.Return(environments, err)
+func (_c *MockStorage_RevokeRefreshToken_Call) Return(err error) *MockStorage_RevokeRefreshToken_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_Environments_Call) RunAndReturn(run func(ctx context.Context, projectName string) ([]*storage.Environment, error)) *MockStorage_Environments_Call {
+func (_c *MockStorage_RevokeRefreshToken_Call) RunAndReturn(run func(ctx context.Context, id uint64) error) *MockStorage_RevokeRefreshToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MarkConfigsUpdated provides a mock function for the type MockStorage
-func (_mock *MockStorage) MarkConfigsUpdated(ctx context.Context, IDs []uint64) error {
-	ret := _mock.Called(ctx, IDs)
+// RevokeRefreshTokenFamily provides a mock function for the type MockStorage
+func (_mock *MockStorage) RevokeRefreshTokenFamily(ctx context.Context, family string) error {
+	ret := _mock.Called(ctx, family)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkConfigsUpdated")
+		panic("no return value specified for RevokeRefreshTokenFamily")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []uint64) error); ok {
-		r0 = returnFunc(ctx, IDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, family)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockStorage_MarkConfigsUpdated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkConfigsUpdated'
-type MockStorage_MarkConfigsUpdated_Call struct {
+// MockStorage_RevokeRefreshTokenFamily_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeRefreshTokenFamily'
+type MockStorage_RevokeRefreshTokenFamily_Call struct {
 	*mock.Call
 }
 
-// MarkConfigsUpdated is a helper method to define mock.On call
+// RevokeRefreshTokenFamily is a helper method to define mock.On call
 //   - ctx context.Context
-//   - IDs []uint64
-func (_e *MockStorage_Expecter) MarkConfigsUpdated(ctx interface{}, IDs interface{}) *MockStorage_MarkConfigsUpdated_Call {
-	return &MockStorage_MarkConfigsUpdated_Call{Call: _e.mock.On("MarkConfigsUpdated", ctx, IDs)}
+//   - family string
+func (_e *MockStorage_Expecter) RevokeRefreshTokenFamily(ctx interface{}, family interface{}) *MockStorage_RevokeRefreshTokenFamily_Call {
+	return &MockStorage_RevokeRefreshTokenFamily_Call{Call: _e.mock.On("RevokeRefreshTokenFamily", ctx, family)}
 }
 
-func (_c *MockStorage_MarkConfigsUpdated_Call) Run(run func(ctx context.Context, IDs []uint64)) *MockStorage_MarkConfigsUpdated_Call {
+func (_c *MockStorage_RevokeRefreshTokenFamily_Call) Run(run func(ctx context.Context, family string)) *MockStorage_RevokeRefreshTokenFamily_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []uint64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]uint64)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1053,65 +2678,54 @@ func (_c *MockStorage_MarkConfigsUpdated_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockStorage_MarkConfigsUpdated_Call) Return(err error) *MockStorage_MarkConfigsUpdated_Call {
+func (_c *MockStorage_RevokeRefreshTokenFamily_Call) Return(err error) *MockStorage_RevokeRefreshTokenFamily_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_MarkConfigsUpdated_Call) RunAndReturn(run func(ctx context.Context, IDs []uint64) error) *MockStorage_MarkConfigsUpdated_Call {
+func (_c *MockStorage_RevokeRefreshTokenFamily_Call) RunAndReturn(run func(ctx context.Context, family string) error) *MockStorage_RevokeRefreshTokenFamily_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ProjectByName provides a mock function for the type MockStorage
-func (_mock *MockStorage) ProjectByName(ctx context.Context, name string) (*storage.Project, error) {
-	ret := _mock.Called(ctx, name)
+// RevokeUserRefreshTokens provides a mock function for the type MockStorage
+func (_mock *MockStorage) RevokeUserRefreshTokens(ctx context.Context, userID uint64) error {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ProjectByName")
+		panic("no return value specified for RevokeUserRefreshTokens")
 	}
 
-	var r0 *storage.Project
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*storage.Project, error)); ok {
-		return returnFunc(ctx, name)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *storage.Project); ok {
-		r0 = returnFunc(ctx, name)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.Project)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, name)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_ProjectByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProjectByName'
-type MockStorage_ProjectByName_Call struct {
+// MockStorage_RevokeUserRefreshTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeUserRefreshTokens'
+type MockStorage_RevokeUserRefreshTokens_Call struct {
 	*mock.Call
 }
 
-// ProjectByName is a helper method to define mock.On call
+// RevokeUserRefreshTokens is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-func (_e *MockStorage_Expecter) ProjectByName(ctx interface{}, name interface{}) *MockStorage_ProjectByName_Call {
-	return &MockStorage_ProjectByName_Call{Call: _e.mock.On("ProjectByName", ctx, name)}
+//   - userID uint64
+func (_e *MockStorage_Expecter) RevokeUserRefreshTokens(ctx interface{}, userID interface{}) *MockStorage_RevokeUserRefreshTokens_Call {
+	return &MockStorage_RevokeUserRefreshTokens_Call{Call: _e.mock.On("RevokeUserRefreshTokens", ctx, userID)}
 }
 
-func (_c *MockStorage_ProjectByName_Call) Run(run func(ctx context.Context, name string)) *MockStorage_ProjectByName_Call {
+func (_c *MockStorage_RevokeUserRefreshTokens_Call) Run(run func(ctx context.Context, userID uint64)) *MockStorage_RevokeUserRefreshTokens_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 uint64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(uint64)
 		}
 		run(
 			arg0,
@@ -1121,156 +2735,127 @@ func (_c *MockStorage_ProjectByName_Call) Run(run func(ctx context.Context, name
 	return _c
 }
 
-func (_c *MockStorage_ProjectByName_Call) Return(project *storage.Project, err error) *MockStorage_ProjectByName_Call {
-	_c.Call.Return(project, err)
+func (_c *MockStorage_RevokeUserRefreshTokens_Call) Return(err error) *MockStorage_RevokeUserRefreshTokens_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_ProjectByName_Call) RunAndReturn(run func(ctx context.Context, name string) (*storage.Project, error)) *MockStorage_ProjectByName_Call {
+func (_c *MockStorage_RevokeUserRefreshTokens_Call) RunAndReturn(run func(ctx context.Context, userID uint64) error) *MockStorage_RevokeUserRefreshTokens_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Projects provides a mock function for the type MockStorage
-func (_mock *MockStorage) Projects(ctx context.Context, q string, limit uint64, offset uint64) ([]*storage.Project, uint64, error) {
-	ret := _mock.Called(ctx, q, limit, offset)
+// RoleBindings provides a mock function for the type MockStorage
+func (_mock *MockStorage) RoleBindings(ctx context.Context, projectID *uint64) ([]*storage.RoleBinding, error) {
+	ret := _mock.Called(ctx, projectID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Projects")
+		panic("no return value specified for RoleBindings")
 	}
 
-	var r0 []*storage.Project
-	var r1 uint64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) ([]*storage.Project, uint64, error)); ok {
-		return returnFunc(ctx, q, limit, offset)
+	var r0 []*storage.RoleBinding
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uint64) ([]*storage.RoleBinding, error)); ok {
+		return returnFunc(ctx, projectID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64, uint64) []*storage.Project); ok {
-		r0 = returnFunc(ctx, q, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *uint64) []*storage.RoleBinding); ok {
+		r0 = returnFunc(ctx, projectID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Project)
+			r0 = ret.Get(0).([]*storage.RoleBinding)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64, uint64) uint64); ok {
-		r1 = returnFunc(ctx, q, limit, offset)
-	} else {
-		r1 = ret.Get(1).(uint64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, uint64, uint64) error); ok {
-		r2 = returnFunc(ctx, q, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *uint64) error); ok {
+		r1 = returnFunc(ctx, projectID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
-// MockStorage_Projects_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Projects'
-type MockStorage_Projects_Call struct {
+// MockStorage_RoleBindings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RoleBindings'
+type MockStorage_RoleBindings_Call struct {
 	*mock.Call
 }
 
-// Projects is a helper method to define mock.On call
+// RoleBindings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - q string
-//   - limit uint64
-//   - offset uint64
-func (_e *MockStorage_Expecter) Projects(ctx interface{}, q interface{}, limit interface{}, offset interface{}) *MockStorage_Projects_Call {
-	return &MockStorage_Projects_Call{Call: _e.mock.On("Projects", ctx, q, limit, offset)}
+//   - projectID *uint64
+func (_e *MockStorage_Expecter) RoleBindings(ctx interface{}, projectID interface{}) *MockStorage_RoleBindings_Call {
+	return &MockStorage_RoleBindings_Call{Call: _e.mock.On("RoleBindings", ctx, projectID)}
 }
 
-func (_c *MockStorage_Projects_Call) Run(run func(ctx context.Context, q string, limit uint64, offset uint64)) *MockStorage_Projects_Call {
+func (_c *MockStorage_RoleBindings_Call) Run(run func(ctx context.Context, projectID *uint64)) *MockStorage_RoleBindings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *uint64
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 uint64
-		if args[2] != nil {
-			arg2 = args[2].(uint64)
-		}
-		var arg3 uint64
-		if args[3] != nil {
-			arg3 = args[3].(uint64)
+			arg1 = args[1].(*uint64)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStorage_Projects_Call) Return(projects []*storage.Project, v uint64, err error) *MockStorage_Projects_Call {
-	_c.Call.Return(projects, v, err)
+func (_c *MockStorage_RoleBindings_Call) Return(roleBindings []*storage.RoleBinding, err error) *MockStorage_RoleBindings_Call {
+	_c.Call.Return(roleBindings, err)
 	return _c
 }
 
-func (_c *MockStorage_Projects_Call) RunAndReturn(run func(ctx context.Context, q string, limit uint64, offset uint64) ([]*storage.Project, uint64, error)) *MockStorage_Projects_Call {
+func (_c *MockStorage_RoleBindings_Call) RunAndReturn(run func(ctx context.Context, projectID *uint64) ([]*storage.RoleBinding, error)) *MockStorage_RoleBindings_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Release provides a mock function for the type MockStorage
-func (_mock *MockStorage) Release(ctx context.Context, envID uint64, releaseName string) (*storage.Release, error) {
-	ret := _mock.Called(ctx, envID, releaseName)
+// SetAuditSinkCursor provides a mock function for the type MockStorage
+func (_mock *MockStorage) SetAuditSinkCursor(ctx context.Context, name string, lastID uint64) error {
+	ret := _mock.Called(ctx, name, lastID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Release")
+		panic("no return value specified for SetAuditSinkCursor")
 	}
 
-	var r0 *storage.Release
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (*storage.Release, error)); ok {
-		return returnFunc(ctx, envID, releaseName)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) *storage.Release); ok {
-		r0 = returnFunc(ctx, envID, releaseName)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*storage.Release)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
-		r1 = returnFunc(ctx, envID, releaseName)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) error); ok {
+		r0 = returnFunc(ctx, name, lastID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockStorage_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
-type MockStorage_Release_Call struct {
+// MockStorage_SetAuditSinkCursor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAuditSinkCursor'
+type MockStorage_SetAuditSinkCursor_Call struct {
 	*mock.Call
 }
 
-// Release is a helper method to define mock.On call
+// SetAuditSinkCursor is a helper method to define mock.On call
 //   - ctx context.Context
-//   - envID uint64
-//   - releaseName string
-func (_e *MockStorage_Expecter) Release(ctx interface{}, envID interface{}, releaseName interface{}) *MockStorage_Release_Call {
-	return &MockStorage_Release_Call{Call: _e.mock.On("Release", ctx, envID, releaseName)}
+//   - name string
+//   - lastID uint64
+func (_e *MockStorage_Expecter) SetAuditSinkCursor(ctx interface{}, name interface{}, lastID interface{}) *MockStorage_SetAuditSinkCursor_Call {
+	return &MockStorage_SetAuditSinkCursor_Call{Call: _e.mock.On("SetAuditSinkCursor", ctx, name, lastID)}
 }
 
-func (_c *MockStorage_Release_Call) Run(run func(ctx context.Context, envID uint64, releaseName string)) *MockStorage_Release_Call {
+func (_c *MockStorage_SetAuditSinkCursor_Call) Run(run func(ctx context.Context, name string, lastID uint64)) *MockStorage_SetAuditSinkCursor_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 uint64
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(uint64)
+			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 uint64
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(uint64)
 		}
 		run(
 			arg0,
@@ -1281,58 +2866,58 @@ func (_c *MockStorage_Release_Call) Run(run func(ctx context.Context, envID uint
 	return _c
 }
 
-func (_c *MockStorage_Release_Call) Return(release *storage.Release, err error) *MockStorage_Release_Call {
-	_c.Call.Return(release, err)
+func (_c *MockStorage_SetAuditSinkCursor_Call) Return(err error) *MockStorage_SetAuditSinkCursor_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockStorage_Release_Call) RunAndReturn(run func(ctx context.Context, envID uint64, releaseName string) (*storage.Release, error)) *MockStorage_Release_Call {
+func (_c *MockStorage_SetAuditSinkCursor_Call) RunAndReturn(run func(ctx context.Context, name string, lastID uint64) error) *MockStorage_SetAuditSinkCursor_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Releases provides a mock function for the type MockStorage
-func (_mock *MockStorage) Releases(ctx context.Context, projectName string, envName string) ([]*storage.Release, error) {
-	ret := _mock.Called(ctx, projectName, envName)
+// SubjectRoleBindings provides a mock function for the type MockStorage
+func (_mock *MockStorage) SubjectRoleBindings(ctx context.Context, username string, groups []string) ([]*storage.RoleBinding, error) {
+	ret := _mock.Called(ctx, username, groups)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Releases")
+		panic("no return value specified for SubjectRoleBindings")
 	}
 
-	var r0 []*storage.Release
+	var r0 []*storage.RoleBinding
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]*storage.Release, error)); ok {
-		return returnFunc(ctx, projectName, envName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]*storage.RoleBinding, error)); ok {
+		return returnFunc(ctx, username, groups)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []*storage.Release); ok {
-		r0 = returnFunc(ctx, projectName, envName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []*storage.RoleBinding); ok {
+		r0 = returnFunc(ctx, username, groups)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*storage.Release)
+			r0 = ret.Get(0).([]*storage.RoleBinding)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, projectName, envName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, username, groups)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStorage_Releases_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Releases'
-type MockStorage_Releases_Call struct {
+// MockStorage_SubjectRoleBindings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubjectRoleBindings'
+type MockStorage_SubjectRoleBindings_Call struct {
 	*mock.Call
 }
 
-// Releases is a helper method to define mock.On call
+// SubjectRoleBindings is a helper method to define mock.On call
 //   - ctx context.Context
-//   - projectName string
-//   - envName string
-func (_e *MockStorage_Expecter) Releases(ctx interface{}, projectName interface{}, envName interface{}) *MockStorage_Releases_Call {
-	return &MockStorage_Releases_Call{Call: _e.mock.On("Releases", ctx, projectName, envName)}
+//   - username string
+//   - groups []string
+func (_e *MockStorage_Expecter) SubjectRoleBindings(ctx interface{}, username interface{}, groups interface{}) *MockStorage_SubjectRoleBindings_Call {
+	return &MockStorage_SubjectRoleBindings_Call{Call: _e.mock.On("SubjectRoleBindings", ctx, username, groups)}
 }
 
-func (_c *MockStorage_Releases_Call) Run(run func(ctx context.Context, projectName string, envName string)) *MockStorage_Releases_Call {
+func (_c *MockStorage_SubjectRoleBindings_Call) Run(run func(ctx context.Context, username string, groups []string)) *MockStorage_SubjectRoleBindings_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1342,9 +2927,9 @@ func (_c *MockStorage_Releases_Call) Run(run func(ctx context.Context, projectNa
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 []string
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].([]string)
 		}
 		run(
 			arg0,
@@ -1355,12 +2940,12 @@ func (_c *MockStorage_Releases_Call) Run(run func(ctx context.Context, projectNa
 	return _c
 }
 
-func (_c *MockStorage_Releases_Call) Return(releases []*storage.Release, err error) *MockStorage_Releases_Call {
-	_c.Call.Return(releases, err)
+func (_c *MockStorage_SubjectRoleBindings_Call) Return(roleBindings []*storage.RoleBinding, err error) *MockStorage_SubjectRoleBindings_Call {
+	_c.Call.Return(roleBindings, err)
 	return _c
 }
 
-func (_c *MockStorage_Releases_Call) RunAndReturn(run func(ctx context.Context, projectName string, envName string) ([]*storage.Release, error)) *MockStorage_Releases_Call {
+func (_c *MockStorage_SubjectRoleBindings_Call) RunAndReturn(run func(ctx context.Context, username string, groups []string) ([]*storage.RoleBinding, error)) *MockStorage_SubjectRoleBindings_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1542,6 +3127,150 @@ func (_c *MockStorage_UpsertConfigs_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// UseTOTPRecoveryCode provides a mock function for the type MockStorage
+func (_mock *MockStorage) UseTOTPRecoveryCode(ctx context.Context, id uint64, codeHash string) (bool, error) {
+	ret := _mock.Called(ctx, id, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPRecoveryCode")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (bool, error)); ok {
+		return returnFunc(ctx, id, codeHash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) bool); ok {
+		r0 = returnFunc(ctx, id, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, id, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_UseTOTPRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPRecoveryCode'
+type MockStorage_UseTOTPRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseTOTPRecoveryCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - codeHash string
+func (_e *MockStorage_Expecter) UseTOTPRecoveryCode(ctx interface{}, id interface{}, codeHash interface{}) *MockStorage_UseTOTPRecoveryCode_Call {
+	return &MockStorage_UseTOTPRecoveryCode_Call{Call: _e.mock.On("UseTOTPRecoveryCode", ctx, id, codeHash)}
+}
+
+func (_c *MockStorage_UseTOTPRecoveryCode_Call) Run(run func(ctx context.Context, id uint64, codeHash string)) *MockStorage_UseTOTPRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UseTOTPRecoveryCode_Call) Return(b bool, err error) *MockStorage_UseTOTPRecoveryCode_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStorage_UseTOTPRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, id uint64, codeHash string) (bool, error)) *MockStorage_UseTOTPRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseTOTPStep provides a mock function for the type MockStorage
+func (_mock *MockStorage) UseTOTPStep(ctx context.Context, id uint64, step int64) (bool, error) {
+	ret := _mock.Called(ctx, id, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPStep")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, int64) (bool, error)); ok {
+		return returnFunc(ctx, id, step)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, int64) bool); ok {
+		r0 = returnFunc(ctx, id, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, int64) error); ok {
+		r1 = returnFunc(ctx, id, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStorage_UseTOTPStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPStep'
+type MockStorage_UseTOTPStep_Call struct {
+	*mock.Call
+}
+
+// UseTOTPStep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - step int64
+func (_e *MockStorage_Expecter) UseTOTPStep(ctx interface{}, id interface{}, step interface{}) *MockStorage_UseTOTPStep_Call {
+	return &MockStorage_UseTOTPStep_Call{Call: _e.mock.On("UseTOTPStep", ctx, id, step)}
+}
+
+func (_c *MockStorage_UseTOTPStep_Call) Run(run func(ctx context.Context, id uint64, step int64)) *MockStorage_UseTOTPStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStorage_UseTOTPStep_Call) Return(b bool, err error) *MockStorage_UseTOTPStep_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStorage_UseTOTPStep_Call) RunAndReturn(run func(ctx context.Context, id uint64, step int64) (bool, error)) *MockStorage_UseTOTPStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // User provides a mock function for the type MockStorage
 func (_mock *MockStorage) User(ctx context.Context, username string) (*storage.User, error) {
 	ret := _mock.Called(ctx, username)
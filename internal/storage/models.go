@@ -43,14 +43,34 @@ type Audit struct {
 	Actor   string
 	Payload []byte
 	Ts      time.Time
+	// PrevHash and Hash are nil for records written before hash chaining
+	PrevHash []byte
+	Hash     []byte
+}
+
+// AuditCheckpoint signed hash of audit chain record
+type AuditCheckpoint struct {
+	ID        uint64
+	LastID    uint64
+	Hash      []byte
+	Signature []byte
+	Ts        time.Time
 }
 
 // AuditFilter ...
 type AuditFilter struct {
-	Action   string
-	Actor    string
+	Action  string
+	Actor   string
+	Project string
+	Env     string
+	Release string
+	Key     string
+	// FromDate and ToDate are not limited if zero
 	FromDate time.Time
 	ToDate   time.Time
+	// Cursor records with id less than cursor, zero for no cursor
+	Cursor uint64
+	Limit  uint64
 }
 
 // User ...
@@ -60,7 +80,60 @@ type User struct {
 	PasswordHash string
 	IsEnabled    bool
 	Roles        []string
-	CreatedAt    time.Time
+	// MustChangePassword password is one-time and must be changed on next login
+	MustChangePassword bool
+	PasswordChangedAt  *time.Time
+	// Source where user comes from e.g. local, oidc
+	Source string
+	// TokensValidAfter tokens issued before are revoked
+	TokensValidAfter *time.Time
+	// TOTPSecret encrypted secret, set on enrollment before confirmation
+	TOTPSecret  []byte
+	TOTPEnabled bool
+	// TOTPRecoveryCodes hashes of unused recovery codes
+	TOTPRecoveryCodes []string
+	CreatedAt         time.Time
+}
+
+// APIToken ...
+type APIToken struct {
+	ID         uint64
+	UserID     uint64
+	Username   string
+	Name       string
+	TokenHash  string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// RefreshToken ...
+type RefreshToken struct {
+	ID       uint64
+	UserID   uint64
+	Username string
+	// Family all rotated tokens of one session
+	Family    string
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// RoleBinding ...
+type RoleBinding struct {
+	ID uint64
+	// ProjectID nil for all projects
+	ProjectID   *uint64
+	ProjectName string
+	// Kind user or group
+	Kind       string
+	Subject    string
+	EnvPattern string
+	Role       string
+	CreatedAt  time.Time
 }
 
 // ValuesStoragePath values path like foo/bar/baz
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+// APITokens ...
+func (s *Storage) APITokens(ctx context.Context, username string) ([]*storage.APIToken, error) {
+	query := queryBuilder().
+		Select("t.id, t.user_id, u.username, t.name, t.token_hash, t.scopes, t.expires_at, t.last_used_at, t.revoked_at, t.created_at").
+		From("api_tokens t").
+		Join("users u ON u.id = t.user_id").
+		OrderBy("t.id DESC")
+
+	if username != "" {
+		query = query.Where(squirrel.Eq{"u.username": username})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	rows, err := s.manager.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*storage.APIToken
+
+	for rows.Next() {
+		var token storage.APIToken
+		if err := rows.Scan(&token.ID, &token.UserID, &token.Username, &token.Name, &token.TokenHash, &token.Scopes, &token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		tokens = append(tokens, &token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// APITokenByHash ...
+func (s *Storage) APITokenByHash(ctx context.Context, tokenHash string) (*storage.APIToken, error) {
+	query := `
+		SELECT t.id, t.user_id, u.username, t.name, t.token_hash, t.scopes, t.expires_at, t.last_used_at, t.revoked_at, t.created_at FROM api_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.token_hash = $1
+	`
+
+	var token storage.APIToken
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.Username, &token.Name, &token.TokenHash, &token.Scopes, &token.ExpiresAt, &token.LastUsedAt, &token.RevokedAt, &token.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return &token, nil
+}
+
+// CreateAPIToken ...
+func (s *Storage) CreateAPIToken(ctx context.Context, token *storage.APIToken) error {
+	query := "INSERT INTO api_tokens (user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at"
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, token.UserID, token.Name, token.TokenHash, token.Scopes, token.ExpiresAt).Scan(&token.ID, &token.CreatedAt); err != nil {
+		if isAlreadyExistsError(err) {
+			return storage.ErrAlreadyExists
+		}
+
+		return fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAPIToken ...
+func (s *Storage) RevokeAPIToken(ctx context.Context, username string, id uint64) error {
+	query := `
+		UPDATE api_tokens t SET revoked_at = NOW()
+		FROM users u
+		WHERE u.id = t.user_id AND u.username = $1 AND t.id = $2 AND t.revoked_at IS NULL
+	`
+
+	tag, err := s.manager.Conn(ctx).Exec(ctx, query, username, id)
+	if err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// MarkAPITokenUsed update last used time not often than once per minute
+func (s *Storage) MarkAPITokenUsed(ctx context.Context, id uint64) error {
+	query := "UPDATE api_tokens SET last_used_at = NOW() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')"
+
+	if _, err := s.manager.Conn(ctx).Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return nil
+}
@@ -2,10 +2,15 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
 
+	"github.com/DesSolo/rtc/internal/auditchain"
 	"github.com/DesSolo/rtc/internal/storage"
 )
 
@@ -13,10 +18,16 @@ import (
 func (s *Storage) AuditsSearch(ctx context.Context, filter storage.AuditFilter) ([]*storage.Audit, error) {
 	query := queryBuilder().Select("id, action, actor, payload, ts").
 		From("audit_log").
-		Where(squirrel.GtOrEq{"ts": filter.FromDate}).
-		Where(squirrel.LtOrEq{"ts": filter.ToDate}).
 		OrderBy("id DESC")
 
+	if !filter.FromDate.IsZero() {
+		query = query.Where(squirrel.GtOrEq{"ts": filter.FromDate})
+	}
+
+	if !filter.ToDate.IsZero() {
+		query = query.Where(squirrel.LtOrEq{"ts": filter.ToDate})
+	}
+
 	if filter.Action != "" {
 		query = query.Where(squirrel.Eq{"action": filter.Action})
 	}
@@ -25,6 +36,41 @@ func (s *Storage) AuditsSearch(ctx context.Context, filter storage.AuditFilter)
 		query = query.Where(squirrel.Eq{"actor": filter.Actor})
 	}
 
+	// payload filters use containment operator to be served by gin index
+	if filter.Project != "" {
+		query = query.Where(payloadContains(map[string]any{"project": filter.Project}))
+	}
+
+	if filter.Env != "" {
+		query = query.Where(squirrel.Or{
+			payloadContains(map[string]any{"env": filter.Env}),
+			payloadContains(map[string]any{"environment": filter.Env}),
+		})
+	}
+
+	if filter.Release != "" {
+		query = query.Where(payloadContains(map[string]any{"release": filter.Release}))
+	}
+
+	if filter.Key != "" {
+		keys := squirrel.Or{}
+		for _, field := range []string{"items", "added", "type_changed", "deleted"} {
+			keys = append(keys, payloadContains(map[string]any{
+				field: []map[string]string{{"key": filter.Key}},
+			}))
+		}
+
+		query = query.Where(keys)
+	}
+
+	if filter.Cursor != 0 {
+		query = query.Where(squirrel.Lt{"id": filter.Cursor})
+	}
+
+	if filter.Limit != 0 {
+		query = query.Limit(filter.Limit)
+	}
+
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("toSql: %w", err)
@@ -53,13 +99,243 @@ func (s *Storage) AuditsSearch(ctx context.Context, filter storage.AuditFilter)
 	return audits, nil
 }
 
-// AddAuditRecord ...
+// Audit ...
+func (s *Storage) Audit(ctx context.Context, id uint64) (*storage.Audit, error) {
+	query := "SELECT id, action, actor, payload, ts FROM audit_log WHERE id = $1"
+
+	var audit storage.Audit
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, id).Scan(&audit.ID, &audit.Action, &audit.Actor, &audit.Payload, &audit.Ts); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return &audit, nil
+}
+
+// auditChainLockID advisory lock serializes appending records to hash chain
+const auditChainLockID = 0x72746361 // "rtca"
+
+// AddAuditRecord appends record to hash chain, chain is locked until transaction end
 func (s *Storage) AddAuditRecord(ctx context.Context, audit *storage.Audit) error {
-	query := "INSERT INTO audit_log (action, actor, payload) VALUES ($1, $2, $3) RETURNING id"
+	if txFromContext(ctx) == nil {
+		return s.manager.WithTransaction(ctx, func(ctx context.Context) error {
+			return s.addAuditRecord(ctx, audit)
+		})
+	}
+
+	return s.addAuditRecord(ctx, audit)
+}
+
+func (s *Storage) addAuditRecord(ctx context.Context, audit *storage.Audit) error {
+	conn := s.manager.Conn(ctx)
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", auditChainLockID); err != nil {
+		return fmt.Errorf("pool.Exec lock: %w", err)
+	}
+
+	var prevHash []byte
+	if err := conn.QueryRow(ctx, "SELECT hash FROM audit_log WHERE hash IS NOT NULL ORDER BY id DESC LIMIT 1").Scan(&prevHash); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("pool.QueryRow prev hash: %w", err)
+		}
+	}
+
+	audit.Ts = time.Now().UTC().Truncate(time.Microsecond)
+
+	// payload is normalized by jsonb, hash is calculated of stored value
+	query := "INSERT INTO audit_log (action, actor, payload, ts) VALUES ($1, $2, $3, $4) RETURNING id, payload"
 
-	if err := s.manager.Conn(ctx).QueryRow(ctx, query, audit.Action, audit.Actor, audit.Payload).Scan(&audit.ID); err != nil {
+	if err := conn.QueryRow(ctx, query, audit.Action, audit.Actor, audit.Payload, audit.Ts).Scan(&audit.ID, &audit.Payload); err != nil {
 		return fmt.Errorf("pool.Query: %w", err)
 	}
 
+	audit.PrevHash = prevHash
+	audit.Hash = auditchain.Hash(prevHash, audit.ID, audit.Action, audit.Actor, audit.Payload, audit.Ts)
+
+	if _, err := conn.Exec(ctx, "UPDATE audit_log SET prev_hash = $1, hash = $2 WHERE id = $3", audit.PrevHash, audit.Hash, audit.ID); err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// AuditChain records with hashes in chain order
+func (s *Storage) AuditChain(ctx context.Context, afterID, limit uint64) ([]*storage.Audit, error) {
+	query := "SELECT id, action, actor, payload, ts, prev_hash, hash FROM audit_log WHERE id > $1 ORDER BY id LIMIT $2"
+
+	rows, err := s.manager.Conn(ctx).Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var audits []*storage.Audit
+
+	for rows.Next() {
+		var audit storage.Audit
+		if err := rows.Scan(&audit.ID, &audit.Action, &audit.Actor, &audit.Payload, &audit.Ts, &audit.PrevHash, &audit.Hash); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		audits = append(audits, &audit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return audits, nil
+}
+
+// LastChainedAudit ...
+func (s *Storage) LastChainedAudit(ctx context.Context) (*storage.Audit, error) {
+	query := "SELECT id, action, actor, payload, ts, prev_hash, hash FROM audit_log WHERE hash IS NOT NULL ORDER BY id DESC LIMIT 1"
+
+	var audit storage.Audit
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query).Scan(&audit.ID, &audit.Action, &audit.Actor, &audit.Payload, &audit.Ts, &audit.PrevHash, &audit.Hash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return &audit, nil
+}
+
+// auditArchiveLockID advisory lock serializes archiving of expired records
+const auditArchiveLockID = 0x72746364 // "rtcd"
+
+// LockAuditArchive wait for archiving of other replicas, must be called in transaction, lock is held until its end
+func (s *Storage) LockAuditArchive(ctx context.Context) error {
+	if txFromContext(ctx) == nil {
+		return errors.New("transaction is required")
+	}
+
+	if _, err := s.manager.Conn(ctx).Exec(ctx, "SELECT pg_advisory_xact_lock($1)", auditArchiveLockID); err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAudits records with id in (afterID, lastID], returns number of deleted records
+func (s *Storage) DeleteAudits(ctx context.Context, afterID, lastID uint64) (uint64, error) {
+	query := "DELETE FROM audit_log WHERE id > $1 AND id <= $2"
+
+	tag, err := s.manager.Conn(ctx).Exec(ctx, query, afterID, lastID)
+	if err != nil {
+		return 0, fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return uint64(tag.RowsAffected()), nil // nolint:gosec
+}
+
+// ImportAudit insert record as is with its id and hashes, payload is replaced by stored value.
+// Returns false if record with id already exists.
+func (s *Storage) ImportAudit(ctx context.Context, audit *storage.Audit) (bool, error) {
+	query := `INSERT INTO audit_log (id, action, actor, payload, ts, prev_hash, hash) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO NOTHING RETURNING payload`
+
+	err := s.manager.Conn(ctx).QueryRow(ctx, query, audit.ID, audit.Action, audit.Actor, audit.Payload, audit.Ts, audit.PrevHash, audit.Hash).Scan(&audit.Payload)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return true, nil
+}
+
+// AuditCheckpoints ...
+func (s *Storage) AuditCheckpoints(ctx context.Context) ([]*storage.AuditCheckpoint, error) {
+	query := "SELECT id, last_id, hash, signature, ts FROM audit_checkpoints ORDER BY id"
+
+	rows, err := s.manager.Conn(ctx).Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var checkpoints []*storage.AuditCheckpoint
+
+	for rows.Next() {
+		var checkpoint storage.AuditCheckpoint
+		if err := rows.Scan(&checkpoint.ID, &checkpoint.LastID, &checkpoint.Hash, &checkpoint.Signature, &checkpoint.Ts); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		checkpoints = append(checkpoints, &checkpoint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows: %w", err)
+	}
+
+	return checkpoints, nil
+}
+
+// LastAuditCheckpoint ...
+func (s *Storage) LastAuditCheckpoint(ctx context.Context) (*storage.AuditCheckpoint, error) {
+	query := "SELECT id, last_id, hash, signature, ts FROM audit_checkpoints ORDER BY id DESC LIMIT 1"
+
+	var checkpoint storage.AuditCheckpoint
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query).Scan(&checkpoint.ID, &checkpoint.LastID, &checkpoint.Hash, &checkpoint.Signature, &checkpoint.Ts); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+// AddAuditCheckpoint ...
+func (s *Storage) AddAuditCheckpoint(ctx context.Context, checkpoint *storage.AuditCheckpoint) error {
+	query := "INSERT INTO audit_checkpoints (last_id, hash, signature) VALUES ($1, $2, $3) RETURNING id, ts"
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, checkpoint.LastID, checkpoint.Hash, checkpoint.Signature).Scan(&checkpoint.ID, &checkpoint.Ts); err != nil {
+		return fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
 	return nil
 }
+
+// AuditSinkCursor id of last record delivered to sink
+func (s *Storage) AuditSinkCursor(ctx context.Context, name string) (uint64, error) {
+	query := "SELECT last_id FROM audit_sink_cursors WHERE name = $1"
+
+	var lastID uint64
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, name).Scan(&lastID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, storage.ErrNotFound
+		}
+
+		return 0, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return lastID, nil
+}
+
+// SetAuditSinkCursor cursor is never moved back, e.g. by replica delivering the same records
+func (s *Storage) SetAuditSinkCursor(ctx context.Context, name string, lastID uint64) error {
+	query := `INSERT INTO audit_sink_cursors (name, last_id) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET last_id = GREATEST(audit_sink_cursors.last_id, EXCLUDED.last_id), updated_at = NOW()`
+
+	if _, err := s.manager.Conn(ctx).Exec(ctx, query, name, lastID); err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+func payloadContains(value map[string]any) squirrel.Sqlizer {
+	// error is not possible for map of strings and slices
+	data, _ := json.Marshal(value) // nolint:errchkjson
+
+	return squirrel.Expr("payload @> ?::jsonb", string(data))
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+
+	"github.com/DesSolo/rtc/migrations"
+)
+
+// Migrator applies embedded sql migrations
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// NewMigrator ...
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS)
+	if err != nil {
+		return nil, fmt.Errorf("goose.NewProvider: %w", err)
+	}
+
+	return &Migrator{provider: provider}, nil
+}
+
+// Up apply all pending migrations
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	results, err := m.provider.Up(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("provider.Up: %w", err)
+	}
+
+	return results, nil
+}
+
+// Down rollback last applied migration
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	result, err := m.provider.Down(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("provider.Down: %w", err)
+	}
+
+	return result, nil
+}
+
+// Close closes database handle of migrator
+func (m *Migrator) Close() error {
+	if err := m.provider.Close(); err != nil {
+		return fmt.Errorf("provider.Close: %w", err)
+	}
+
+	return nil
+}
+
+// Status ...
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("provider.Status: %w", err)
+	}
+
+	return statuses, nil
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+// RefreshTokenByHash ...
+func (s *Storage) RefreshTokenByHash(ctx context.Context, tokenHash string) (*storage.RefreshToken, error) {
+	query := `
+		SELECT t.id, t.user_id, u.username, t.family, t.token_hash, t.expires_at, t.revoked_at, t.created_at FROM refresh_tokens t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.token_hash = $1
+	`
+
+	var token storage.RefreshToken
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, tokenHash).Scan(&token.ID, &token.UserID, &token.Username, &token.Family, &token.TokenHash, &token.ExpiresAt, &token.RevokedAt, &token.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return &token, nil
+}
+
+// CreateRefreshToken ...
+func (s *Storage) CreateRefreshToken(ctx context.Context, token *storage.RefreshToken) error {
+	query := "INSERT INTO refresh_tokens (user_id, family, token_hash, expires_at) VALUES ($1, $2, $3, $4) RETURNING id, created_at"
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, token.UserID, token.Family, token.TokenHash, token.ExpiresAt).Scan(&token.ID, &token.CreatedAt); err != nil {
+		return fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeRefreshToken revoke token if it's not revoked yet
+// returns storage.ErrNotFound if token already revoked (concurrent rotation)
+func (s *Storage) RevokeRefreshToken(ctx context.Context, id uint64) error {
+	query := "UPDATE refresh_tokens SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL"
+
+	tag, err := s.manager.Conn(ctx).Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+
+	return nil
+}
+
+// RevokeRefreshTokenFamily ...
+func (s *Storage) RevokeRefreshTokenFamily(ctx context.Context, family string) error {
+	query := "UPDATE refresh_tokens SET revoked_at = NOW() WHERE family = $1 AND revoked_at IS NULL"
+
+	if _, err := s.manager.Conn(ctx).Exec(ctx, query, family); err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUserRefreshTokens ...
+func (s *Storage) RevokeUserRefreshTokens(ctx context.Context, userID uint64) error {
+	query := "UPDATE refresh_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL"
+
+	if _, err := s.manager.Conn(ctx).Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/DesSolo/rtc/internal/storage"
+)
+
+const roleBindingsColumns = "b.id, b.project_id, COALESCE(p.name, '*'), b.kind, b.subject, b.env_pattern, b.role, b.created_at"
+
+// RoleBindings bindings of project (bindings to all projects if projectID is nil)
+func (s *Storage) RoleBindings(ctx context.Context, projectID *uint64) ([]*storage.RoleBinding, error) {
+	query := queryBuilder().
+		Select(roleBindingsColumns).
+		From("role_bindings b").
+		LeftJoin("projects p ON p.id = b.project_id").
+		Where(squirrel.Eq{"b.project_id": projectID}).
+		OrderBy("b.id")
+
+	return s.queryRoleBindings(ctx, query)
+}
+
+// SubjectRoleBindings bindings of user and its groups in all projects
+func (s *Storage) SubjectRoleBindings(ctx context.Context, username string, groups []string) ([]*storage.RoleBinding, error) {
+	query := queryBuilder().
+		Select(roleBindingsColumns).
+		From("role_bindings b").
+		LeftJoin("projects p ON p.id = b.project_id").
+		Where(squirrel.Or{
+			squirrel.Eq{"b.kind": "user", "b.subject": username},
+			squirrel.And{
+				squirrel.Eq{"b.kind": "group"},
+				squirrel.Expr("b.subject = ANY(?)", groups),
+			},
+		}).
+		OrderBy("b.id")
+
+	return s.queryRoleBindings(ctx, query)
+}
+
+func (s *Storage) queryRoleBindings(ctx context.Context, query squirrel.SelectBuilder) ([]*storage.RoleBinding, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	rows, err := s.manager.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var bindings []*storage.RoleBinding
+
+	for rows.Next() {
+		var binding storage.RoleBinding
+		if err := rows.Scan(&binding.ID, &binding.ProjectID, &binding.ProjectName, &binding.Kind, &binding.Subject, &binding.EnvPattern, &binding.Role, &binding.CreatedAt); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %w", err)
+		}
+
+		bindings = append(bindings, &binding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %w", err)
+	}
+
+	return bindings, nil
+}
+
+// CreateRoleBinding ...
+func (s *Storage) CreateRoleBinding(ctx context.Context, binding *storage.RoleBinding) error {
+	query := "INSERT INTO role_bindings (project_id, kind, subject, env_pattern, role) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at"
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, binding.ProjectID, binding.Kind, binding.Subject, binding.EnvPattern, binding.Role).Scan(&binding.ID, &binding.CreatedAt); err != nil {
+		if isAlreadyExistsError(err) {
+			return storage.ErrAlreadyExists
+		}
+
+		return fmt.Errorf("row.Scan: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRoleBinding delete binding of project and return it
+func (s *Storage) DeleteRoleBinding(ctx context.Context, projectID *uint64, id uint64) (*storage.RoleBinding, error) {
+	query := `
+		DELETE FROM role_bindings
+		WHERE id = $1 AND project_id IS NOT DISTINCT FROM $2
+		RETURNING id, project_id, kind, subject, env_pattern, role, created_at
+	`
+
+	var binding storage.RoleBinding
+
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, id, projectID).Scan(&binding.ID, &binding.ProjectID, &binding.Kind, &binding.Subject, &binding.EnvPattern, &binding.Role, &binding.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("pool.QueryRow: %w", err)
+	}
+
+	return &binding, nil
+}
@@ -10,10 +10,12 @@ import (
 	"github.com/DesSolo/rtc/internal/storage"
 )
 
+const usersColumns = "id, username, password_hash, is_enabled, roles, must_change_password, password_changed_at, source, tokens_valid_after, totp_secret, totp_enabled, totp_recovery_codes, created_at"
+
 // Users ...
 func (s *Storage) Users(ctx context.Context, q string, limit, offset uint64) ([]*storage.User, uint64, error) {
 	query := queryBuilder().
-		Select("id, username, password_hash, is_enabled, roles, created_at, COUNT(*) OVER()").
+		Select(usersColumns, "COUNT(*) OVER()").
 		From("users").
 		Limit(limit).
 		Offset(offset).
@@ -41,7 +43,7 @@ func (s *Storage) Users(ctx context.Context, q string, limit, offset uint64) ([]
 
 	for rows.Next() {
 		var user storage.User
-		if err := rows.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.IsEnabled, &user.Roles, &user.CreatedAt, &total); err != nil {
+		if err := rows.Scan(&user.ID, &user.Username, &user.PasswordHash, &user.IsEnabled, &user.Roles, &user.MustChangePassword, &user.PasswordChangedAt, &user.Source, &user.TokensValidAfter, &user.TOTPSecret, &user.TOTPEnabled, &user.TOTPRecoveryCodes, &user.CreatedAt, &total); err != nil {
 			return nil, 0, fmt.Errorf("rows.Scan: %w", err)
 		}
 
@@ -53,11 +55,11 @@ func (s *Storage) Users(ctx context.Context, q string, limit, offset uint64) ([]
 
 // User ...
 func (s *Storage) User(ctx context.Context, username string) (*storage.User, error) {
-	query := "SELECT id, username, password_hash, is_enabled, roles, created_at FROM users WHERE username = $1"
+	query := "SELECT " + usersColumns + " FROM users WHERE username = $1"
 
 	var user storage.User
 
-	if err := s.manager.Conn(ctx).QueryRow(ctx, query, username).Scan(&user.ID, &user.Username, &user.PasswordHash, &user.IsEnabled, &user.Roles, &user.CreatedAt); err != nil {
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, username).Scan(&user.ID, &user.Username, &user.PasswordHash, &user.IsEnabled, &user.Roles, &user.MustChangePassword, &user.PasswordChangedAt, &user.Source, &user.TokensValidAfter, &user.TOTPSecret, &user.TOTPEnabled, &user.TOTPRecoveryCodes, &user.CreatedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, storage.ErrNotFound
 		}
@@ -70,9 +72,9 @@ func (s *Storage) User(ctx context.Context, username string) (*storage.User, err
 
 // CreateUser ...
 func (s *Storage) CreateUser(ctx context.Context, user *storage.User) error {
-	query := "INSERT INTO users (username, password_hash, is_enabled, roles) VALUES ($1, $2, $3, $4) RETURNING id, created_at"
+	query := "INSERT INTO users (username, password_hash, is_enabled, roles, must_change_password, source) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at"
 
-	if err := s.manager.Conn(ctx).QueryRow(ctx, query, user.Username, user.PasswordHash, user.IsEnabled, user.Roles).Scan(&user.ID, &user.CreatedAt); err != nil {
+	if err := s.manager.Conn(ctx).QueryRow(ctx, query, user.Username, user.PasswordHash, user.IsEnabled, user.Roles, user.MustChangePassword, user.Source).Scan(&user.ID, &user.CreatedAt); err != nil {
 		if isAlreadyExistsError(err) {
 			return storage.ErrAlreadyExists
 		}
@@ -85,11 +87,37 @@ func (s *Storage) CreateUser(ctx context.Context, user *storage.User) error {
 
 // UpdateUser ...
 func (s *Storage) UpdateUser(ctx context.Context, id uint64, user *storage.User) error {
-	query := "UPDATE users SET password_hash=$1, is_enabled=$2, roles=$3 WHERE id=$4"
+	query := "UPDATE users SET password_hash=$1, is_enabled=$2, roles=$3, must_change_password=$4, password_changed_at=$5, tokens_valid_after=$6, totp_secret=$7, totp_enabled=$8, totp_recovery_codes=$9 WHERE id=$10"
 
-	if _, err := s.manager.Conn(ctx).Exec(ctx, query, user.PasswordHash, user.IsEnabled, user.Roles, id); err != nil {
+	if _, err := s.manager.Conn(ctx).Exec(ctx, query, user.PasswordHash, user.IsEnabled, user.Roles, user.MustChangePassword, user.PasswordChangedAt, user.TokensValidAfter, user.TOTPSecret, user.TOTPEnabled, user.TOTPRecoveryCodes, id); err != nil {
 		return fmt.Errorf("pool.Exec: %w", err)
 	}
 
 	return nil
 }
+
+// UseTOTPStep remember step of accepted TOTP code.
+// Returns false if same or later step is already used, so code can't be replayed.
+func (s *Storage) UseTOTPStep(ctx context.Context, id uint64, step int64) (bool, error) {
+	query := "UPDATE users SET totp_last_step=$1 WHERE id=$2 AND totp_last_step < $1"
+
+	tag, err := s.manager.Conn(ctx).Exec(ctx, query, step, id)
+	if err != nil {
+		return false, fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// UseTOTPRecoveryCode remove recovery code of user.
+// Returns false if code is already used, so concurrent logins can't redeem it twice.
+func (s *Storage) UseTOTPRecoveryCode(ctx context.Context, id uint64, codeHash string) (bool, error) {
+	query := "UPDATE users SET totp_recovery_codes=array_remove(totp_recovery_codes, $1) WHERE id=$2 AND $1=ANY(totp_recovery_codes)"
+
+	tag, err := s.manager.Conn(ctx).Exec(ctx, query, codeHash, id)
+	if err != nil {
+		return false, fmt.Errorf("pool.Exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
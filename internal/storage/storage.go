@@ -33,12 +33,44 @@ type Storage interface {
 	DeleteConfigs(ctx context.Context, IDs []uint64) error
 
 	AuditsSearch(ctx context.Context, filter AuditFilter) ([]*Audit, error)
+	Audit(ctx context.Context, id uint64) (*Audit, error)
 	AddAuditRecord(ctx context.Context, audit *Audit) error
+	AuditChain(ctx context.Context, afterID, limit uint64) ([]*Audit, error)
+	LastChainedAudit(ctx context.Context) (*Audit, error)
+	LockAuditArchive(ctx context.Context) error
+	DeleteAudits(ctx context.Context, afterID, lastID uint64) (uint64, error)
+	ImportAudit(ctx context.Context, audit *Audit) (bool, error)
+
+	AuditCheckpoints(ctx context.Context) ([]*AuditCheckpoint, error)
+	LastAuditCheckpoint(ctx context.Context) (*AuditCheckpoint, error)
+	AddAuditCheckpoint(ctx context.Context, checkpoint *AuditCheckpoint) error
+
+	AuditSinkCursor(ctx context.Context, name string) (uint64, error)
+	SetAuditSinkCursor(ctx context.Context, name string, lastID uint64) error
 
 	Users(ctx context.Context, q string, limit, offset uint64) ([]*User, uint64, error)
 	User(ctx context.Context, username string) (*User, error)
 	CreateUser(ctx context.Context, user *User) error
 	UpdateUser(ctx context.Context, id uint64, user *User) error
+	UseTOTPStep(ctx context.Context, id uint64, step int64) (bool, error)
+	UseTOTPRecoveryCode(ctx context.Context, id uint64, codeHash string) (bool, error)
+
+	APITokens(ctx context.Context, username string) ([]*APIToken, error)
+	APITokenByHash(ctx context.Context, tokenHash string) (*APIToken, error)
+	CreateAPIToken(ctx context.Context, token *APIToken) error
+	RevokeAPIToken(ctx context.Context, username string, id uint64) error
+	MarkAPITokenUsed(ctx context.Context, id uint64) error
+
+	RefreshTokenByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+	RevokeRefreshToken(ctx context.Context, id uint64) error
+	RevokeRefreshTokenFamily(ctx context.Context, family string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID uint64) error
+
+	RoleBindings(ctx context.Context, projectID *uint64) ([]*RoleBinding, error)
+	SubjectRoleBindings(ctx context.Context, username string, groups []string) ([]*RoleBinding, error)
+	CreateRoleBinding(ctx context.Context, binding *RoleBinding) error
+	DeleteRoleBinding(ctx context.Context, projectID *uint64, id uint64) (*RoleBinding, error)
 }
 
 // ValuesStorage ...
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE role_bindings (
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER,
+    kind VARCHAR(16) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    env_pattern VARCHAR(255) NOT NULL DEFAULT '*',
+    role VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT fk_role_bindings_projects
+        FOREIGN KEY (project_id)
+        REFERENCES projects(id)
+        ON DELETE CASCADE
+);
+
+-- project_id is NULL for bindings to all projects
+CREATE UNIQUE INDEX idx_role_bindings_unique ON role_bindings(COALESCE(project_id, 0), kind, subject, env_pattern);
+CREATE INDEX idx_role_bindings_subject ON role_bindings(kind, subject);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS role_bindings;
+-- +goose StatementEnd
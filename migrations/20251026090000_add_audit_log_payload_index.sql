@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_audit_log_payload ON audit_log USING GIN (payload jsonb_path_ops);
+
+CREATE INDEX idx_audit_log_actor ON audit_log(actor);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_log_actor;
+
+DROP INDEX IF EXISTS idx_audit_log_payload;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE audit_log ADD COLUMN prev_hash BYTEA;
+
+ALTER TABLE audit_log ADD COLUMN hash BYTEA;
+
+CREATE TABLE audit_checkpoints (
+    id SERIAL PRIMARY KEY,
+    last_id INTEGER NOT NULL,
+    hash BYTEA NOT NULL,
+    signature BYTEA NOT NULL,
+    ts TIMESTAMP NOT NULL default NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_checkpoints;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS hash;
+
+ALTER TABLE audit_log DROP COLUMN IF EXISTS prev_hash;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE audit_sink_cursors (
+    name VARCHAR(255) PRIMARY KEY,
+    last_id INTEGER NOT NULL,
+    updated_at TIMESTAMP NOT NULL default NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_sink_cursors;
+-- +goose StatementEnd
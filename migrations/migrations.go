@@ -0,0 +1,10 @@
+/*
+	This file is intended to embed sql migrations into the server binary.
+*/
+
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS // nolint:revive